@@ -1,19 +1,83 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
-	"golang.org/x/oauth2"
 )
 
+// ProviderKind determines how a login provider is talked to
+type ProviderKind string
+
+const (
+	ProviderDiscord ProviderKind = "discord"
+	ProviderGitHub  ProviderKind = "github"
+	ProviderGoogle  ProviderKind = "google"
+	ProviderOIDC    ProviderKind = "oidc" // generic OpenID Connect issuer
+)
+
+// reservedProviderNames are fixed routes next to /api/auth/{name}, which a
+// provider can't be named after
+var reservedProviderNames = []string{"providers", "user", "logout", "identities", "tokens", "sessions"}
+
+// providerNamePattern matches names of login providers, which are used in
+// routes and stored with the identities of users
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// OAuthProviderConfig holds the configuration of a login provider
+type OAuthProviderConfig struct {
+	Kind ProviderKind
+	// name used in routes, e.g. /api/auth/{name}
+	Name         string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// only used by generic OpenID Connect providers
+	IssuerURL string
+}
+
+// SessionStoreKind determines where sessions are persisted
+type SessionStoreKind string
+
+const (
+	SessionStoreMemory SessionStoreKind = "memory" // lost on restart, for development
+	SessionStoreFile   SessionStoreKind = "file"   // one JSON file per session
+)
+
+// SessionStoreConfig holds the configuration of the server side session store
+type SessionStoreConfig struct {
+	Kind SessionStoreKind
+	// directory of the file store
+	Path string
+	// how long a session stays valid
+	MaxAge time.Duration
+}
+
 // Config holds application configuration
 type Config struct {
-	DiscordOAuthConfig *oauth2.Config
-	SessionSecret      string
-	FrontendURL        string
-	GRPCServerAddress  string
+	OAuthProviders []OAuthProviderConfig
+	SessionSecret  string
+	SessionStore   SessionStoreConfig
+	// how often profiles are refreshed from the login providers, 0 disables it
+	ProfileRefreshInterval time.Duration
+	CursorSecret           string
+	FrontendURL            string
+	BackendURL             string
+	GRPCServerAddress      string
+	// how long notes stay in the trash before they are purged, 0 keeps them
+	TrashRetention time.Duration
+	// how long a suggest request waits for a newer one of the same client
+	SuggestDebounce time.Duration
+	// how long suggestions are waited for, after the debounce delay
+	SuggestTimeout time.Duration
+	// IPs or CIDRs of reverse proxies whose X-Forwarded-For header is trusted
+	TrustedProxies []string
 }
 
 var AppConfig *Config
@@ -25,16 +89,11 @@ func Load() *Config {
 		log.Println("No .env file found, using environment variables")
 	}
 
-	clientID := os.Getenv("DISCORD_CLIENT_ID")
-	clientSecret := os.Getenv("DISCORD_CLIENT_SECRET")
-	redirectURL := os.Getenv("DISCORD_REDIRECT_URI")
 	sessionSecret := os.Getenv("SESSION_SECRET")
 	frontendURL := os.Getenv("FRONTEND_URL")
+	backendURL := os.Getenv("BACKEND_URL")
 	grpcServerAddress := os.Getenv("GRPC_SERVER_ADDRESS")
-
-	if clientID == "" || clientSecret == "" {
-		log.Fatal("DISCORD_CLIENT_ID or DISCORD_CLIENT_SECRET is not set")
-	}
+	cursorSecret := os.Getenv("CURSOR_SECRET")
 
 	if grpcServerAddress == "" {
 		log.Fatal("GRPC_SERVER_ADDRESS environment variable is required")
@@ -44,42 +103,201 @@ func Load() *Config {
 		log.Fatal("SESSION_SECRET environment variable is required")
 	}
 
-	if redirectURL == "" {
-		redirectURL = "http://localhost:8080/api/auth/discord/callback"
+	if cursorSecret == "" {
+		cursorSecret = sessionSecret
+	}
+
+	sessionStore := loadSessionStore()
+
+	var profileRefreshInterval time.Duration
+	if interval := os.Getenv("PROFILE_REFRESH_INTERVAL"); interval != "" {
+		duration, err := time.ParseDuration(interval)
+		if err != nil || duration < 0 {
+			log.Fatalf("PROFILE_REFRESH_INTERVAL must be a duration like 24h: %v", interval)
+		}
+		profileRefreshInterval = duration
+	}
+
+	trashRetention := 30 * 24 * time.Hour
+	if retention := os.Getenv("TRASH_RETENTION"); retention != "" {
+		duration, err := time.ParseDuration(retention)
+		if err != nil || duration < 0 {
+			log.Fatalf("TRASH_RETENTION must be a duration like 720h: %v", retention)
+		}
+		trashRetention = duration
+	}
+
+	suggestDebounce := 100 * time.Millisecond
+	if debounce := os.Getenv("SUGGEST_DEBOUNCE"); debounce != "" {
+		duration, err := time.ParseDuration(debounce)
+		if err != nil || duration < 0 {
+			log.Fatalf("SUGGEST_DEBOUNCE must be a duration like 100ms: %v", debounce)
+		}
+		suggestDebounce = duration
+	}
+
+	suggestTimeout := 250 * time.Millisecond
+	if timeout := os.Getenv("SUGGEST_TIMEOUT"); timeout != "" {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil || duration <= 0 {
+			log.Fatalf("SUGGEST_TIMEOUT must be a positive duration like 250ms: %v", timeout)
+		}
+		suggestTimeout = duration
+	}
+
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
 	}
 
 	if frontendURL == "" {
 		frontendURL = "http://localhost:5173"
 	}
 
-	discordOAuthConfig := &oauth2.Config{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		RedirectURL:  redirectURL,
-		Scopes:       []string{"identify", "email"},
-		Endpoint: oauth2.Endpoint{
-			AuthURL:  "https://discord.com/api/oauth2/authorize",
-			TokenURL: "https://discord.com/api/oauth2/token",
-		},
+	if backendURL == "" {
+		backendURL = "http://localhost:8080"
+	}
+
+	// login providers
+	var providers []OAuthProviderConfig
+	for _, provider := range []struct {
+		kind   ProviderKind
+		prefix string
+	}{
+		{ProviderDiscord, "DISCORD"},
+		{ProviderGitHub, "GITHUB"},
+		{ProviderGoogle, "GOOGLE"},
+		{ProviderOIDC, "OIDC"},
+	} {
+		cfg, ok := loadProvider(provider.kind, provider.prefix, backendURL)
+		if !ok {
+			continue
+		}
+		for _, other := range providers {
+			if other.Name == cfg.Name {
+				log.Fatalf("Login provider name %q is used twice, set OIDC_PROVIDER_NAME to another name", cfg.Name)
+			}
+		}
+		providers = append(providers, cfg)
+	}
+	if len(providers) == 0 {
+		log.Fatal("No login provider configured, set e.g. DISCORD_CLIENT_ID and DISCORD_CLIENT_SECRET")
 	}
 
 	AppConfig = &Config{
-		DiscordOAuthConfig: discordOAuthConfig,
-		SessionSecret:      sessionSecret,
-		FrontendURL:        frontendURL,
-		GRPCServerAddress:  grpcServerAddress,
+		OAuthProviders:         providers,
+		SessionSecret:          sessionSecret,
+		SessionStore:           sessionStore,
+		ProfileRefreshInterval: profileRefreshInterval,
+		TrashRetention:         trashRetention,
+		SuggestDebounce:        suggestDebounce,
+		SuggestTimeout:         suggestTimeout,
+		TrustedProxies:         trustedProxies,
+		CursorSecret:           cursorSecret,
+		FrontendURL:            frontendURL,
+		BackendURL:             backendURL,
+		GRPCServerAddress:      grpcServerAddress,
 	}
 	PrintConfig(AppConfig)
 	return AppConfig
 }
 
+// loadProvider reads the configuration of a login provider from environment
+// variables starting with prefix, e.g. GITHUB_CLIENT_ID.
+// It returns false if the provider is not configured.
+func loadProvider(kind ProviderKind, prefix string, backendURL string) (OAuthProviderConfig, bool) {
+	clientID := os.Getenv(prefix + "_CLIENT_ID")
+	clientSecret := os.Getenv(prefix + "_CLIENT_SECRET")
+	if clientID == "" && clientSecret == "" {
+		return OAuthProviderConfig{}, false
+	}
+	if clientID == "" || clientSecret == "" {
+		log.Fatalf("%s_CLIENT_ID or %s_CLIENT_SECRET is not set", prefix, prefix)
+	}
+
+	name := string(kind)
+	if kind == ProviderOIDC && os.Getenv("OIDC_PROVIDER_NAME") != "" {
+		name = strings.ToLower(os.Getenv("OIDC_PROVIDER_NAME"))
+		if !providerNamePattern.MatchString(name) {
+			log.Fatalf("OIDC_PROVIDER_NAME %q may only contain lowercase letters, digits and dashes", name)
+		}
+		if slices.Contains(reservedProviderNames, name) {
+			log.Fatalf("OIDC_PROVIDER_NAME %q is reserved, reserved names are %s", name, strings.Join(reservedProviderNames, ", "))
+		}
+	}
+
+	issuerURL := os.Getenv(prefix + "_ISSUER_URL")
+	if kind == ProviderOIDC && issuerURL == "" {
+		log.Fatal("OIDC_ISSUER_URL environment variable is required for OpenID Connect login")
+	}
+
+	redirectURL := os.Getenv(prefix + "_REDIRECT_URI")
+	if redirectURL == "" {
+		redirectURL = fmt.Sprintf("%s/api/auth/%s/callback", strings.TrimSuffix(backendURL, "/"), name)
+	}
+
+	return OAuthProviderConfig{
+		Kind:         kind,
+		Name:         name,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		IssuerURL:    issuerURL,
+	}, true
+}
+
+// loadSessionStore reads the session store configuration. Sessions are kept
+// in memory for 30 days by default.
+func loadSessionStore() SessionStoreConfig {
+	cfg := SessionStoreConfig{
+		Kind:   SessionStoreKind(strings.ToLower(os.Getenv("SESSION_STORE"))),
+		Path:   os.Getenv("SESSION_STORE_PATH"),
+		MaxAge: 30 * 24 * time.Hour,
+	}
+	if cfg.Kind == "" {
+		cfg.Kind = SessionStoreMemory
+	}
+	if cfg.Kind != SessionStoreMemory && cfg.Kind != SessionStoreFile {
+		log.Fatalf("SESSION_STORE must be %q or %q", SessionStoreMemory, SessionStoreFile)
+	}
+	if cfg.Path == "" {
+		cfg.Path = "sessions"
+	}
+	if maxAge := os.Getenv("SESSION_MAX_AGE"); maxAge != "" {
+		duration, err := time.ParseDuration(maxAge)
+		if err != nil || duration <= 0 {
+			log.Fatalf("SESSION_MAX_AGE must be a positive duration like 720h: %v", maxAge)
+		}
+		cfg.MaxAge = duration
+	}
+	return cfg
+}
+
 // PrintConfig logs some key configuration values.
 func PrintConfig(cfg *Config) {
-	log.Println("Discord OAuth Config:")
-	log.Println("  ClientID:      ", cfg.DiscordOAuthConfig.ClientID) // Consider masking in production
-	log.Println("  RedirectURL:   ", cfg.DiscordOAuthConfig.RedirectURL)
-	log.Println("  Scopes:        ", cfg.DiscordOAuthConfig.Scopes)
+	for _, provider := range cfg.OAuthProviders {
+		log.Printf("OAuth Provider %s:", provider.Name)
+		log.Println("  ClientID:      ", provider.ClientID) // Consider masking in production
+		log.Println("  RedirectURL:   ", provider.RedirectURL)
+		if provider.IssuerURL != "" {
+			log.Println("  IssuerURL:     ", provider.IssuerURL)
+		}
+	}
 	// Avoid printing sensitive values: clientSecret and sessionSecret.
+	log.Println("Session Store:    ", cfg.SessionStore.Kind)
+	if cfg.ProfileRefreshInterval > 0 {
+		log.Println("Profile Refresh:  ", cfg.ProfileRefreshInterval)
+	}
+	if cfg.TrashRetention > 0 {
+		log.Println("Trash Retention:  ", cfg.TrashRetention)
+	}
+	log.Println("Suggest Debounce: ", cfg.SuggestDebounce)
+	log.Println("Suggest Timeout:  ", cfg.SuggestTimeout)
+	if len(cfg.TrustedProxies) > 0 {
+		log.Println("Trusted Proxies:  ", strings.Join(cfg.TrustedProxies, ", "))
+	}
 	log.Println("Frontend URL:     ", cfg.FrontendURL)
 	log.Println("gRPC Server Addr:", cfg.GRPCServerAddress)
 }
@@ -3,34 +3,44 @@ package controllers
 import (
 	"crypto/rand"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/KuramaSyu/WerSu-Rest/src/config"
-	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/oauth"
+	"github.com/KuramaSyu/WerSu-Rest/src/profile"
 	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/KuramaSyu/WerSu-Rest/src/sessionstore"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
-	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // AuthController handles authentication logic
 type AuthController struct {
-	OAuthConfig *oauth2.Config
+	providers   *oauth.Registry
 	userService *proto.UserServiceClient
+	sessions    *sessionstore.Store
 }
 
 // NewAuthController creates a new auth controller
-func NewAuthController(oauthConfig *oauth2.Config, userService *proto.UserServiceClient) *AuthController {
+func NewAuthController(providers *oauth.Registry, userService *proto.UserServiceClient, store *sessionstore.Store) *AuthController {
 	return &AuthController{
-		OAuthConfig: oauthConfig,
+		providers:   providers,
 		userService: userService,
+		sessions:    store,
 	}
 }
 
+type ProviderReply struct {
+	Name     string `json:"name" example:"github"`
+	LoginURL string `json:"login_url" example:"/api/auth/github"`
+}
+
 // GenerateState creates a random state string for OAuth
 func (ac *AuthController) GenerateState() (string, error) {
 	b := make([]byte, 16)
@@ -40,133 +50,221 @@ func (ac *AuthController) GenerateState() (string, error) {
 	return base64.URLEncoding.EncodeToString(b), nil
 }
 
-// Login initiates Discord OAuth flow
+// providerFromPath returns the login provider named in the :provider path parameter
+func (ac *AuthController) providerFromPath(c *gin.Context) (oauth.Provider, error) {
+	name := c.Params.ByName("provider")
+	provider, ok := ac.providers.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown login provider %q", name)
+	}
+	return provider, nil
+}
+
+// GetProviders godoc
+// @Summary List login providers
+// @Description Lists the configured login providers. A login is started by navigating to login_url.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} []ProviderReply
+// @Router /auth/providers [get]
+func (ac *AuthController) GetProviders(c *gin.Context) {
+	providers := []ProviderReply{}
+	for _, name := range ac.providers.Names() {
+		providers = append(providers, ProviderReply{Name: name, LoginURL: "/api/auth/" + name})
+	}
+	c.JSON(http.StatusOK, providers)
+}
+
+// the purpose of an OAuth flow, stored in the session while it runs
+const (
+	intentLogin = "login"
+	intentLink  = "link" // link the identity to the logged in user
+)
+
+// Login initiates the OAuth flow of the provider named in the path
 func (ac *AuthController) Login(c *gin.Context) {
+	provider, err := ac.providerFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusNotFound, err)
+		return
+	}
+	ac.startOAuthFlow(c, provider, intentLogin)
+}
+
+// startOAuthFlow stores the state of a new OAuth flow in the session and
+// redirects to the consent page of the provider
+func (ac *AuthController) startOAuthFlow(c *gin.Context, provider oauth.Provider, intent string) {
 	state, err := ac.GenerateState()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate state"})
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to generate state: %w", err))
+		return
+	}
+
+	url, err := provider.AuthCodeURL(state)
+	if err != nil {
+		SetGinError(c, http.StatusBadGateway, fmt.Errorf("failed to reach login provider: %w", err))
 		return
 	}
 
 	session := sessions.Default(c)
 	session.Set("state", state)
+	session.Set("state_provider", provider.Name())
+	session.Set("state_intent", intent)
 	if err := session.Save(); err != nil {
 		log.Printf("Save session failed: %v", err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to save session: %w", err))
 		return
 	}
 
-	url := ac.OAuthConfig.AuthCodeURL(state)
 	c.Redirect(http.StatusTemporaryRedirect, url)
 }
 
-// Callback handles OAuth callback from Discord
+// Callback handles the OAuth callback of the provider named in the path.
+// Depending on how the flow was started, the user is logged in or the
+// identity is linked to the logged in user.
 func (ac *AuthController) Callback(c *gin.Context) {
+	provider, err := ac.providerFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusNotFound, err)
+		return
+	}
+
 	session := sessions.Default(c)
 	savedState := session.Get("state")
+	savedProvider := session.Get("state_provider")
+	intent := session.Get("state_intent")
 	queryState := c.Query("state")
 
-	if savedState == nil || savedState != queryState {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid state parameter"})
+	if savedState == nil || savedState != queryState || savedProvider != provider.Name() {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid state parameter"))
 		return
 	}
 
 	session.Delete("state")
+	session.Delete("state_provider")
+	session.Delete("state_intent")
 	session.Save()
 
 	code := c.Query("code")
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Code not found"})
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("code not found"))
 		return
 	}
 
-	token, err := ac.OAuthConfig.Exchange(c, code)
+	token, err := provider.Exchange(c, code)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to exchange code for token"})
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to exchange code for token: %w", err))
 		return
 	}
 
-	client := ac.OAuthConfig.Client(c, token)
-	resp, err := client.Get("https://discord.com/api/users/@me")
+	identity, err := provider.FetchIdentity(c, token)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user info"})
+		SetGinError(c, http.StatusBadGateway, fmt.Errorf("failed to get user info: %w", err))
 		return
 	}
-	defer resp.Body.Close()
 
-	var d_user models.DiscordUser
-	if err := json.NewDecoder(resp.Body).Decode(&d_user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse user info"})
+	if intent == intentLink {
+		ac.completeLink(c, identity)
 		return
 	}
 
-	//session.Set("d_user", *user)
-	discordId := int64(d_user.DiscordId)
-	grpcUser, err := (*ac.userService).GetUser(c, &proto.GetUserRequest{
-		DiscordId: &discordId,
-	})
-
+	grpcUser, err := ac.findOrCreateUser(c, identity)
 	if err != nil {
-		println("%v", err.Error())
-		// failed to get user -> post user
-		grpcUser, err = (*ac.userService).PostUser(c, &proto.PostUserRequest{
-			DiscordId:     int64(d_user.DiscordId),
-			Avatar:        d_user.Avatar,
-			Username:      d_user.Username,
-			Discriminator: d_user.Discriminator,
-			Email:         d_user.Email,
-		})
-		if err != nil {
-			// failed to post user -> error
-			log.Printf("user: %v; Error: %v", d_user, err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to post user to gRPC service"})
-			return
-		}
+		// failed to fetch or post user -> error
+		log.Printf("identity: %v; Error: %v", identity, err)
+		SetGrpcError(c, err)
+		return
 	}
-	user := models.User{
-		ID:            grpcUser.Id,
-		DiscordId:     models.Snowflake(grpcUser.DiscordId),
-		Username:      grpcUser.Username,
-		Discriminator: grpcUser.Discriminator,
-		Avatar:        grpcUser.Avatar,
-		Email:         grpcUser.Email,
+
+	// keep the profile in sync with its provider. A failed update shouldn't
+	// prevent the login
+	if updated, err := profile.Sync(c, ac.userService, grpcUser, identity); err != nil {
+		log.Printf("Failed to update profile of user %v: %v", grpcUser.Id, err)
+	} else {
+		grpcUser = updated
 	}
-	session.Set("user", user)
+	// the session gets a new ID on login, so an ID planted before the login
+	// can't be used to act as the user
+	if err := ac.sessions.Rotate(c.Request, sessionstore.CookieName); err != nil {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to renew session: %w", err))
+		return
+	}
+	session.Set("user", grpcUser.ToModel())
+	session.Set(profile.LoginTokenKey, profile.NewLoginToken(identity, token))
 
-	log.Printf("User %v logged in via Discord OAuth, gRPC ID: %v", grpcUser.Username, grpcUser.Id)
+	log.Printf("User %v logged in via %s OAuth, gRPC ID: %v", grpcUser.Username, provider.Name(), grpcUser.Id)
 	if err := session.Save(); err != nil {
 		log.Printf("user: %v; Error: %v", grpcUser, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to save session: %w", err))
 		return
 	}
 	redirect_url := fmt.Sprintf("%v", config.AppConfig.FrontendURL)
 	c.Redirect(http.StatusTemporaryRedirect, redirect_url)
 }
 
+// findOrCreateUser returns the user of a provider identity. If the identity is
+// unknown to the gRPC service, a new user is posted.
+func (ac *AuthController) findOrCreateUser(c *gin.Context, identity *oauth.Identity) (*proto.User, error) {
+	grpcIdentity := &proto.Identity{Provider: identity.Provider, Subject: identity.Subject}
+	getUserRequest := &proto.GetUserRequest{Identity: grpcIdentity}
+	postUserRequest := &proto.PostUserRequest{
+		Avatar:        identity.Avatar,
+		Username:      identity.Username,
+		Discriminator: identity.Discriminator,
+		Email:         identity.Email,
+		Identity:      grpcIdentity,
+	}
+
+	// Discord users are also known by their Discord ID
+	if identity.Provider == string(config.ProviderDiscord) {
+		discordId, err := strconv.ParseInt(identity.Subject, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Discord ID %q: %w", identity.Subject, err)
+		}
+		getUserRequest.DiscordId = &discordId
+		postUserRequest.DiscordId = discordId
+	}
+
+	grpcUser, err := (*ac.userService).GetUser(c, getUserRequest)
+	if status.Code(err) != codes.NotFound {
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch user via gRPC service: %w", err)
+		}
+		return grpcUser, nil
+	}
+
+	// user not found -> post user
+	grpcUser, err = (*ac.userService).PostUser(c, postUserRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post user to gRPC service: %w", err)
+	}
+	return grpcUser, nil
+}
+
 // GetUser returns the current authenticated user
 func (ac *AuthController) GetUser(c *gin.Context) {
-	user, _, err := UserFromSession(c)
-	if user == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not logged in"})
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
 		return
 	}
 
 	// fetch again
-	discord_id := int64(user.DiscordId)
-	user_backend, err := (*ac.userService).GetUser(c, &proto.GetUserRequest{DiscordId: &discord_id})
+	user_backend, err := (*ac.userService).GetUser(c, &proto.GetUserRequest{Id: &user.ID})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user from gRPC service"})
+		SetGrpcError(c, fmt.Errorf("failed to fetch user from gRPC service: %w", err))
 		return
 	}
 	c.JSON(http.StatusOK, user_backend.ParseJS())
 }
 
-// Logout clears the user session
+// Logout deletes the user session on the server and clears the cookie
 func (ac *AuthController) Logout(c *gin.Context) {
 	session := sessions.Default(c)
 	session.Clear()
+	session.Options(sessions.Options{Path: "/", MaxAge: -1})
 	if err := session.Save(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear session"})
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to clear session: %w", err))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
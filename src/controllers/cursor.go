@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CursorCodec creates and verifies the opaque cursor tokens used to page
+// through search results. A token is the base64 encoded position of the last
+// seen note, followed by an HMAC signature, so clients can't forge positions.
+type CursorCodec struct {
+	secret []byte
+}
+
+// cursorPayload is the signed content of a cursor token
+type cursorPayload struct {
+	// search type and hashed query and filters the cursor belongs to
+	SearchType int32  `json:"t"`
+	Query      string `json:"q"`
+
+	// position of the last seen note
+	UpdatedAt int64   `json:"u,omitempty"` // unix nanoseconds
+	Score     float64 `json:"s,omitempty"`
+	ID        int32   `json:"i,omitempty"`
+
+	// used instead of the position, if the gRPC service doesn't report one
+	Offset int32 `json:"o,omitempty"`
+}
+
+func NewCursorCodec(secret []byte) *CursorCodec {
+	return &CursorCodec{secret: secret}
+}
+
+// Encode creates the cursor token which points behind the given position
+// within the results of the search request.
+//
+// Parameters:
+//   - req: The search request the position belongs to
+//   - position: The position of the last seen note. If nil, the cursor
+//     falls back to the offset behind the current page
+func (cc *CursorCodec) Encode(req *proto.GetSearchNotesRequest, position *proto.SearchCursor) (string, error) {
+	payload := cursorPayload{
+		SearchType: int32(req.SearchType),
+		Query:      hashQuery(req),
+	}
+	if position != nil {
+		payload.UpdatedAt = position.GetUpdatedAt().AsTime().UnixNano()
+		payload.Score = position.Score
+		payload.ID = position.Id
+	} else {
+		payload.Offset = req.Offset + req.Limit
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + cc.sign(encoded), nil
+}
+
+// Decode verifies a cursor token and applies the position it contains to the
+// search request. The token must have been created for the same search type,
+// query and filters.
+func (cc *CursorCodec) Decode(token string, req *proto.GetSearchNotesRequest) error {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(cc.sign(encoded))) {
+		return fmt.Errorf("invalid cursor")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid cursor: %w", err)
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	if payload.SearchType != int32(req.SearchType) || payload.Query != hashQuery(req) {
+		return fmt.Errorf("cursor belongs to a different search")
+	}
+
+	if payload.ID == 0 {
+		req.Offset = payload.Offset
+		return nil
+	}
+	req.After = &proto.SearchCursor{
+		Score: payload.Score,
+		Id:    payload.ID,
+	}
+	if payload.UpdatedAt != 0 {
+		req.After.UpdatedAt = timestamppb.New(time.Unix(0, payload.UpdatedAt))
+	}
+	return nil
+}
+
+func (cc *CursorCodec) sign(encoded string) string {
+	mac := hmac.New(sha256.New, cc.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// hashQuery shortens the query and filters of a search, so cursors of long
+// queries stay small and don't leak the query itself
+func hashQuery(req *proto.GetSearchNotesRequest) string {
+	query := req.Query
+	if len(req.Tags) > 0 {
+		query += "\x00" + strings.Join(req.Tags, "\x00")
+	}
+	if req.NotebookId != nil {
+		query += fmt.Sprintf("\x00notebook:%d:%t", *req.NotebookId, req.IncludeSubNotebooks)
+	}
+	for _, weight := range req.Weights {
+		query += fmt.Sprintf("\x00weight:%d:%g", weight.SearchType, weight.Weight)
+	}
+	if req.UpdatedAfter != nil {
+		query += fmt.Sprintf("\x00after:%d", req.UpdatedAfter.AsTime().Unix())
+	}
+	if req.UpdatedBefore != nil {
+		query += fmt.Sprintf("\x00before:%d", req.UpdatedBefore.AsTime().Unix())
+	}
+	for _, authorID := range req.AuthorIds {
+		query += fmt.Sprintf("\x00author:%d", authorID)
+	}
+	for _, tag := range req.ExcludedTags {
+		query += "\x00-tag:" + tag
+	}
+	for _, phrase := range req.TitlePhrases {
+		query += "\x00title:" + phrase
+	}
+	for _, term := range req.ExcludedTerms {
+		query += "\x00-" + term
+	}
+	sum := sha256.Sum256([]byte(query))
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
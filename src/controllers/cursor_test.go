@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// searchRequest returns a search request which the modifiers are applied to
+func searchRequest(modifiers ...func(req *proto.GetSearchNotesRequest)) *proto.GetSearchNotesRequest {
+	notebookID := int32(3)
+	req := &proto.GetSearchNotesRequest{
+		Query:               "sprint review",
+		SearchType:          proto.GetSearchNotesRequest_Fuzzy,
+		Limit:               20,
+		Tags:                []string{"infra"},
+		NotebookId:          &notebookID,
+		IncludeSubNotebooks: true,
+		UserId:              1,
+	}
+	for _, modify := range modifiers {
+		modify(req)
+	}
+	return req
+}
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+	updatedAt := time.Date(2026, 1, 31, 12, 0, 0, 123, time.UTC)
+
+	t.Run("position", func(t *testing.T) {
+		token, err := codec.Encode(searchRequest(), &proto.SearchCursor{
+			UpdatedAt: timestamppb.New(updatedAt),
+			Score:     0.75,
+			Id:        42,
+		})
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		req := searchRequest()
+		if err := codec.Decode(token, req); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if req.After == nil || req.After.Id != 42 || req.After.Score != 0.75 || !req.After.UpdatedAt.AsTime().Equal(updatedAt) {
+			t.Errorf("Decode() set After = %v, want note 42 with score 0.75 updated at %v", req.After, updatedAt)
+		}
+		if req.Offset != 0 {
+			t.Errorf("Decode() set Offset = %d, want 0", req.Offset)
+		}
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		token, err := codec.Encode(searchRequest(func(req *proto.GetSearchNotesRequest) { req.Offset = 40 }), nil)
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		req := searchRequest()
+		if err := codec.Decode(token, req); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if req.Offset != 60 || req.After != nil {
+			t.Errorf("Decode() set Offset = %d and After = %v, want offset 60", req.Offset, req.After)
+		}
+	})
+}
+
+func TestCursorCodecRejectsTamperedTokens(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+	token, err := codec.Encode(searchRequest(), &proto.SearchCursor{Id: 42, Score: 0.5})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	encoded, signature, _ := strings.Cut(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"t":3,"q":"x","i":1}`))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "without signature", token: encoded},
+		{name: "changed payload", token: forged + "." + signature},
+		{name: "changed signature", token: encoded + "." + strings.ToUpper(signature)},
+		{name: "signed with another secret", token: func() string {
+			token, _ := NewCursorCodec([]byte("other")).Encode(searchRequest(), &proto.SearchCursor{Id: 42})
+			return token
+		}()},
+		{name: "garbage", token: "not a cursor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := searchRequest()
+			if err := codec.Decode(tt.token, req); err == nil {
+				t.Errorf("Decode(%q) error = nil, want invalid cursor", tt.token)
+			}
+			if req.After != nil || req.Offset != 0 {
+				t.Errorf("Decode(%q) changed the request", tt.token)
+			}
+		})
+	}
+}
+
+func TestCursorCodecRejectsDifferentSearch(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+	token, err := codec.Encode(searchRequest(), &proto.SearchCursor{Id: 42})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *proto.GetSearchNotesRequest)
+	}{
+		{"search type", func(req *proto.GetSearchNotesRequest) { req.SearchType = proto.GetSearchNotesRequest_Context }},
+		{"query", func(req *proto.GetSearchNotesRequest) { req.Query = "sprint retro" }},
+		{"tags", func(req *proto.GetSearchNotesRequest) { req.Tags = []string{"infra", "ops"} }},
+		{"notebook", func(req *proto.GetSearchNotesRequest) { req.NotebookId = nil }},
+		{"sub notebooks", func(req *proto.GetSearchNotesRequest) { req.IncludeSubNotebooks = false }},
+		{"weights", func(req *proto.GetSearchNotesRequest) {
+			req.Weights = []*proto.SearchWeight{{SearchType: proto.GetSearchNotesRequest_Fuzzy, Weight: 2}}
+		}},
+		{"updated after", func(req *proto.GetSearchNotesRequest) { req.UpdatedAfter = timestamppb.Now() }},
+		{"updated before", func(req *proto.GetSearchNotesRequest) { req.UpdatedBefore = timestamppb.Now() }},
+		{"authors", func(req *proto.GetSearchNotesRequest) { req.AuthorIds = []int32{7} }},
+		{"excluded tags", func(req *proto.GetSearchNotesRequest) { req.ExcludedTags = []string{"draft"} }},
+		{"title phrases", func(req *proto.GetSearchNotesRequest) { req.TitlePhrases = []string{"review"} }},
+		{"excluded terms", func(req *proto.GetSearchNotesRequest) { req.ExcludedTerms = []string{"old"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := searchRequest(tt.modify)
+			if err := codec.Decode(token, req); err == nil {
+				t.Error("Decode() error = nil, want cursor of a different search")
+			}
+		})
+	}
+
+	// the page size and position may change between pages
+	req := searchRequest(func(req *proto.GetSearchNotesRequest) { req.Limit = 50 })
+	if err := codec.Decode(token, req); err != nil {
+		t.Errorf("Decode() with another limit error = %v", err)
+	}
+}
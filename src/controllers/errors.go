@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StatusClientClosedRequest is the non-standard HTTP status code used when the
+// client canceled the request before a response was written.
+const StatusClientClosedRequest = 499
+
+// ErrorReply is the legacy body of error responses, which is still sent to
+// clients that don't accept application/problem+json.
+type ErrorReply struct {
+	// human readable error message
+	Error string `json:"error" example:"note not found"`
+
+	// machine readable error code
+	Code string `json:"code" example:"NOT_FOUND"`
+}
+
+// grpcToHTTPStatus maps gRPC status codes to HTTP status codes.
+var grpcToHTTPStatus = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           StatusClientClosedRequest,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// httpToGrpcCode maps HTTP status codes to the gRPC status code which is used
+// as machine readable error code, for errors which don't originate from gRPC.
+var httpToGrpcCode = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusUnauthorized:         codes.Unauthenticated,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.AlreadyExists,
+	http.StatusGone:                 codes.NotFound,
+	http.StatusPreconditionFailed:   codes.FailedPrecondition,
+	http.StatusPreconditionRequired: codes.FailedPrecondition,
+	http.StatusTooManyRequests:      codes.ResourceExhausted,
+	StatusClientClosedRequest:       codes.Canceled,
+	http.StatusInternalServerError:  codes.Internal,
+	http.StatusNotImplemented:       codes.Unimplemented,
+	http.StatusBadGateway:           codes.Unavailable,
+	http.StatusServiceUnavailable:   codes.Unavailable,
+	http.StatusGatewayTimeout:       codes.DeadlineExceeded,
+}
+
+// HTTPStatusFromGrpc maps a gRPC status code to the matching HTTP status code.
+// Unknown codes are mapped to 500 Internal Server Error.
+func HTTPStatusFromGrpc(code codes.Code) int {
+	if httpStatus, ok := grpcToHTTPStatus[code]; ok {
+		return httpStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// HTTPStatusFromError returns the HTTP status code for an error returned by a
+// gRPC client. Errors which don't carry a gRPC status result in 500.
+func HTTPStatusFromError(err error) int {
+	grpcStatus, ok := status.FromError(err)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	return HTTPStatusFromGrpc(grpcStatus.Code())
+}
+
+// ErrorCode returns the machine readable error code for an error response.
+// If err carries a gRPC status, its code is used. Otherwise the code is derived
+// from the HTTP status code.
+//
+// Returns:
+//   - string: The error code in upper snake case, e.g. NOT_FOUND
+func ErrorCode(httpStatus int, err error) string {
+	code, ok := httpToGrpcCode[httpStatus]
+	if !ok {
+		code = codes.Unknown
+	}
+	if grpcStatus, isGrpc := status.FromError(err); isGrpc && err != nil {
+		code = grpcStatus.Code()
+	}
+	return grpcCodeName(code)
+}
+
+// grpcCodeName converts a gRPC code into upper snake case, e.g.
+// codes.PermissionDenied becomes PERMISSION_DENIED.
+func grpcCodeName(code codes.Code) string {
+	if code == codes.OK {
+		return "OK"
+	}
+	name := code.String()
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// SetGrpcError is a helper function that sends a JSON error response for an
+// error returned by a gRPC client. The HTTP status code is derived from the
+// gRPC status code of the error, which may be wrapped.
+//
+// Parameters:
+//   - c: The Gin context
+//   - err: The error to send in the response body
+func SetGrpcError(c *gin.Context, err error) {
+	SetGinError(c, HTTPStatusFromError(err), err)
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VersionConflictError is returned when a note was changed since the client
+// fetched it. Its current version is reported in the problem details.
+type VersionConflictError struct {
+	NoteId         int32
+	CurrentVersion int32
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("note %d was changed in the meantime, its current version is %d", e.NoteId, e.CurrentVersion)
+}
+
+// NoteETag returns the entity tag of a note. It's derived from the revision,
+// which changes with every change of the note, and the access level of the
+// user, which can also change through the notebook of the note.
+func NoteETag(note *proto.Note) string {
+	return fmt.Sprintf(`"%d.%d"`, note.Revision, note.AccessLevel)
+}
+
+// entityTag is a single tag of an If-Match or If-None-Match header
+type entityTag struct {
+	// opaque is the tag including its quotes, but without the weak prefix
+	opaque string
+	weak   bool
+}
+
+// parseEntityTags parses the list of entity tags of an If-Match or
+// If-None-Match header. wildcard is true for "*".
+func parseEntityTags(header string) (tags []entityTag, wildcard bool, err error) {
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "*" {
+			return nil, true, nil
+		}
+		tag := entityTag{}
+		if strings.HasPrefix(part, "W/") {
+			tag.weak = true
+			part = strings.TrimPrefix(part, "W/")
+		}
+		if len(part) < 2 || part[0] != '"' || part[len(part)-1] != '"' || strings.Contains(part[1:len(part)-1], `"`) {
+			return nil, false, fmt.Errorf("invalid entity tag %s", part)
+		}
+		tag.opaque = part
+		tags = append(tags, tag)
+	}
+	return tags, false, nil
+}
+
+// expectedRevision checks the If-Match header of a request which changes a
+// note. It returns the revision which the gRPC service should expect, or nil
+// if the client accepts any revision.
+func expectedRevision(c *gin.Context, note *proto.Note) (*int64, int, error) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return nil, http.StatusPreconditionRequired, fmt.Errorf("If-Match header with the ETag of note %d required", note.Id)
+	}
+	tags, wildcard, err := parseEntityTags(header)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid If-Match header: %w", err)
+	}
+	if wildcard {
+		return nil, http.StatusOK, nil
+	}
+	// If-Match only matches strong tags
+	etag := NoteETag(note)
+	for _, tag := range tags {
+		if !tag.weak && tag.opaque == etag {
+			return &note.Revision, http.StatusOK, nil
+		}
+	}
+	c.Header("ETag", etag)
+	return nil, http.StatusPreconditionFailed, &VersionConflictError{NoteId: note.Id, CurrentVersion: note.Version}
+}
+
+// notModified reports whether the If-None-Match header of a request matches
+// the note, so it doesn't need to be sent again
+func notModified(c *gin.Context, note *proto.Note) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	tags, wildcard, err := parseEntityTags(header)
+	if err != nil {
+		return false
+	}
+	if wildcard {
+		return true
+	}
+	// If-None-Match also matches weak tags
+	etag := NoteETag(note)
+	for _, tag := range tags {
+		if tag.opaque == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// setVersionConflictError responds to a change which the gRPC service
+// rejected, because the note was changed after its revision was checked
+func setVersionConflictError(c *gin.Context, noteService *proto.NoteServiceClient, user *models.User, id int32, err error) {
+	if status.Code(err) != codes.Aborted {
+		SetGrpcError(c, err)
+		return
+	}
+	note, code, fetchErr := fetchNoteWithAccess(c, noteService, id, user, models.AccessRead)
+	if fetchErr != nil {
+		SetGinError(c, code, fetchErr)
+		return
+	}
+	c.Header("ETag", NoteETag(note))
+	SetGinError(c, http.StatusPreconditionFailed, &VersionConflictError{NoteId: id, CurrentVersion: note.Version})
+}
+
+// currentVersionFromError returns the current version of a note reported by a
+// VersionConflictError
+func currentVersionFromError(err error) *int32 {
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		return &conflict.CurrentVersion
+	}
+	return nil
+}
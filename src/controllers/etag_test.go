@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseEntityTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       string
+		want         []entityTag
+		wantWildcard bool
+		wantErr      bool
+	}{
+		{name: "strong", header: `"4.2"`, want: []entityTag{{opaque: `"4.2"`}}},
+		{name: "weak", header: `W/"4.2"`, want: []entityTag{{opaque: `"4.2"`, weak: true}}},
+		{
+			name:   "list",
+			header: ` "1.2" , W/"3.2","" `,
+			want:   []entityTag{{opaque: `"1.2"`}, {opaque: `"3.2"`, weak: true}, {opaque: `""`}},
+		},
+		{name: "wildcard", header: "*", wantWildcard: true},
+		{name: "unquoted", header: "4.2", wantErr: true},
+		{name: "missing closing quote", header: `"4.2`, wantErr: true},
+		{name: "single quote", header: `"`, wantErr: true},
+		{name: "quote inside", header: `"4"2"`, wantErr: true},
+		{name: "lowercase weak prefix", header: `w/"4.2"`, wantErr: true},
+		{name: "empty entry", header: `"4.2",`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, wildcard, err := parseEntityTags(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEntityTags(%q) error = %v, wantErr %v", tt.header, err, tt.wantErr)
+			}
+			if wildcard != tt.wantWildcard {
+				t.Errorf("parseEntityTags(%q) wildcard = %v, want %v", tt.header, wildcard, tt.wantWildcard)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tags, tt.want) {
+				t.Errorf("parseEntityTags(%q) = %+v, want %+v", tt.header, tags, tt.want)
+			}
+		})
+	}
+}
+
+// etagContext returns a context of a request with the header
+func etagContext(name, value string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPatch, "/notes/1", nil)
+	if value != "" {
+		c.Request.Header.Set(name, value)
+	}
+	return c, w
+}
+
+func TestExpectedRevision(t *testing.T) {
+	note := &proto.Note{Id: 1, Version: 3, Revision: 7, AccessLevel: proto.NotePermission_Write}
+	etag := NoteETag(note)
+
+	tests := []struct {
+		name     string
+		header   string
+		wantCode int
+		wantNil  bool
+	}{
+		{name: "missing", header: "", wantCode: http.StatusPreconditionRequired, wantNil: true},
+		{name: "malformed", header: "7", wantCode: http.StatusBadRequest, wantNil: true},
+		{name: "wildcard", header: "*", wantCode: http.StatusOK, wantNil: true},
+		{name: "matching", header: etag, wantCode: http.StatusOK},
+		{name: "matching in list", header: `"1.2", ` + etag, wantCode: http.StatusOK},
+		{name: "weak", header: "W/" + etag, wantCode: http.StatusPreconditionFailed, wantNil: true},
+		{name: "old revision", header: `"6.3"`, wantCode: http.StatusPreconditionFailed, wantNil: true},
+		{name: "other access level", header: `"7.1"`, wantCode: http.StatusPreconditionFailed, wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := etagContext("If-Match", tt.header)
+			revision, code, err := expectedRevision(c, note)
+			if code != tt.wantCode {
+				t.Fatalf("expectedRevision() code = %d, want %d (error: %v)", code, tt.wantCode, err)
+			}
+			if (err != nil) != (code != http.StatusOK) {
+				t.Errorf("expectedRevision() error = %v with code %d", err, code)
+			}
+			if tt.wantNil != (revision == nil) || (revision != nil && *revision != note.Revision) {
+				t.Errorf("expectedRevision() revision = %v, want nil: %v", revision, tt.wantNil)
+			}
+			if code != http.StatusPreconditionFailed {
+				return
+			}
+			var conflict *VersionConflictError
+			if !errors.As(err, &conflict) || conflict.CurrentVersion != note.Version {
+				t.Errorf("expectedRevision() error = %v, want a VersionConflictError", err)
+			}
+			if got := w.Header().Get("ETag"); got != etag {
+				t.Errorf("ETag = %q, want %q", got, etag)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	note := &proto.Note{Id: 1, Version: 3, Revision: 7, AccessLevel: proto.NotePermission_Write}
+	etag := NoteETag(note)
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "missing", header: "", want: false},
+		{name: "matching", header: etag, want: true},
+		{name: "weak", header: "W/" + etag, want: true},
+		{name: "wildcard", header: "*", want: true},
+		{name: "tags changed", header: `"6.3"`, want: false},
+		{name: "access level changed", header: `"7.1"`, want: false},
+		{name: "malformed", header: "7", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := etagContext("If-None-Match", tt.header)
+			if got := notModified(c, note); got != tt.want {
+				t.Errorf("notModified() with %q = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
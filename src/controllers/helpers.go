@@ -10,6 +10,7 @@ import (
 )
 
 // UserFromSession retrieves the authenticated user from the current session.
+// Requests authenticated with a personal access token resolve to the owner of the token.
 // It returns the user model, an HTTP status code, and an error if the user is not authenticated
 // or if the session data is malformed.
 //
@@ -18,6 +19,11 @@ import (
 //   - int: HTTP status code (200 for success, 401 for unauthorized, 500 for internal error)
 //   - error: Error message if retrieval fails
 func UserFromSession(c *gin.Context) (*models.User, int, error) {
+	if tokenUser, ok := c.Get(apiTokenUserKey); ok {
+		user := tokenUser.(models.User)
+		return &user, http.StatusOK, nil
+	}
+
 	session := sessions.Default(c)
 	userData := session.Get("user")
 	if userData == nil {
@@ -32,14 +38,21 @@ func UserFromSession(c *gin.Context) (*models.User, int, error) {
 	return &grpc_user, http.StatusOK, nil
 }
 
-// SetGinError is a helper function that sends a JSON error response.
-// It formats the error message and sets the appropriate HTTP status code.
+// SetGinError is a helper function that sends an error response.
+// The body is an RFC 7807 problem details object (application/problem+json).
+// Clients which explicitly accept application/json but not problem+json get the
+// legacy {"error", "code"} body instead. Use SetGrpcError for errors returned by
+// gRPC clients.
 //
 // Parameters:
 //   - c: The Gin context
 //   - status: HTTP status code to return
 //   - err: The error to send in the response body
 func SetGinError(c *gin.Context, status int, err error) {
-	c.JSON(status, gin.H{"error": err.Error()})
-
+	if wantsLegacyError(c) {
+		c.AbortWithStatusJSON(status, ErrorReply{Error: err.Error(), Code: ErrorCode(status, err)})
+		return
+	}
+	c.Header("Content-Type", MIMEProblemJSON)
+	c.AbortWithStatusJSON(status, NewProblemDetails(c, status, err))
 }
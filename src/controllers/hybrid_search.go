@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/fusion"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// HybridDepth is the number of results taken from every search fused by a
+// hybrid search. It is the same for every page, so the fused ranking doesn't
+// change while paging through it.
+const HybridDepth = MaxSearchLimit
+
+// MaxSearchWeight is the highest weight of a search fused by a hybrid search
+const MaxSearchWeight = 10
+
+// noteStream is the stream of notes returned by a search
+type noteStream interface {
+	Recv() (*proto.MinimalNote, error)
+}
+
+// sliceStream streams notes which are already known
+type sliceStream struct {
+	notes []*proto.MinimalNote
+}
+
+func (s *sliceStream) Recv() (*proto.MinimalNote, error) {
+	if len(s.notes) == 0 {
+		return nil, io.EOF
+	}
+	note := s.notes[0]
+	s.notes = s.notes[1:]
+	return note, nil
+}
+
+// parseSearchWeights parses the weights of a hybrid search given as
+// search_type:weight. The result is sorted by search type, so equal weights
+// always hash to the same cursor.
+func parseSearchWeights(values []string) ([]*proto.SearchWeight, error) {
+	weights := []*proto.SearchWeight{}
+	for _, value := range values {
+		name, number, found := strings.Cut(value, ":")
+		if !found || !slices.Contains(HybridSearchTypes, SearchType(name)) {
+			return nil, fmt.Errorf("invalid weight %q: must be search_type:weight with a search type out of %v", value, HybridSearchTypes)
+		}
+		weight, err := strconv.ParseFloat(number, 64)
+		if err != nil || math.IsNaN(weight) || weight < 0 || weight > MaxSearchWeight {
+			return nil, fmt.Errorf("invalid weight %q: must be a number between 0 and %d", value, MaxSearchWeight)
+		}
+		searchType := MapSearchTypeToProto(SearchType(name))
+		if slices.ContainsFunc(weights, func(w *proto.SearchWeight) bool { return w.SearchType == searchType }) {
+			return nil, fmt.Errorf("invalid weight %q: %s is weighted twice", value, name)
+		}
+		weights = append(weights, &proto.SearchWeight{SearchType: searchType, Weight: weight})
+	}
+	slices.SortFunc(weights, func(a, b *proto.SearchWeight) int {
+		return int(a.SearchType) - int(b.SearchType)
+	})
+	if len(weights) == len(HybridSearchTypes) && !slices.ContainsFunc(weights, func(w *proto.SearchWeight) bool { return w.Weight > 0 }) {
+		return nil, fmt.Errorf("invalid weights: at least one search needs a weight above 0")
+	}
+	return weights, nil
+}
+
+// search runs a search request. Hybrid searches are run as several single
+// searches, whose results are fused.
+func (uc *SearchNotesController) search(ctx context.Context, req *proto.GetSearchNotesRequest) (noteStream, error) {
+	if req.SearchType != proto.GetSearchNotesRequest_Hybrid {
+		return (*uc.NoteService).SearchNotes(ctx, req)
+	}
+	notes, err := uc.hybridSearch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &sliceStream{notes: notes}, nil
+}
+
+// hybridSearch runs the searches fused by a hybrid search concurrently and
+// returns the page of the fused results the request asks for. Every result
+// reports how the single searches ranked it.
+func (uc *SearchNotesController) hybridSearch(ctx context.Context, req *proto.GetSearchNotesRequest) ([]*proto.MinimalNote, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// results of the single searches, in the order of HybridSearchTypes
+	results := make([][]*proto.MinimalNote, len(HybridSearchTypes))
+	rankings := make([]fusion.Ranking[int32], len(HybridSearchTypes))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for i, searchType := range HybridSearchTypes {
+		single := protobuf.Clone(req).(*proto.GetSearchNotesRequest)
+		single.SearchType = MapSearchTypeToProto(searchType)
+		single.Limit = HybridDepth
+		single.Offset = 0
+		single.After = nil
+		single.Weights = nil
+
+		rankings[i].Weight = 1
+		for _, weight := range req.Weights {
+			if weight.SearchType == single.SearchType {
+				rankings[i].Weight = weight.Weight
+			}
+		}
+		if rankings[i].Weight == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			notes, err := uc.collect(ctx, single)
+			if err == nil {
+				results[i] = notes
+				return
+			}
+			// the fused ranking is incomplete without this search, so the
+			// others are canceled. Their errors are caused by this one
+			mu.Lock()
+			defer mu.Unlock()
+			if firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	for i, notes := range results {
+		for _, note := range notes {
+			rankings[i].Items = append(rankings[i].Items, note.Id)
+		}
+	}
+	fused := fusion.Reciprocal(rankings)
+
+	// the page of the fused results
+	start := min(int(req.Offset), len(fused))
+	end := min(start+int(req.Limit), len(fused))
+	notes := make([]*proto.MinimalNote, 0, end-start)
+	for _, result := range fused[start:end] {
+		var note *proto.MinimalNote
+		for i, rank := range result.Ranks {
+			if rank == 0 {
+				continue
+			}
+			found := results[i][rank-1]
+			if note == nil {
+				note = protobuf.Clone(found).(*proto.MinimalNote)
+				note.Cursor = nil
+				note.Matches = nil
+				note.Score = result.Score
+			}
+			note.Matches = append(note.Matches, &proto.SearchMatch{
+				SearchType: MapSearchTypeToProto(HybridSearchTypes[i]),
+				Rank:       int32(rank),
+				Score:      found.Score,
+			})
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// collect runs a single search and receives all of its results
+func (uc *SearchNotesController) collect(ctx context.Context, req *proto.GetSearchNotesRequest) ([]*proto.MinimalNote, error) {
+	stream, err := (*uc.NoteService).SearchNotes(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s search failed: %w", MapSearchTypeFromProto(req.SearchType), err)
+	}
+	var notes []*proto.MinimalNote
+	for {
+		note, err := stream.Recv()
+		if err == io.EOF {
+			return notes, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s search failed: %w", MapSearchTypeFromProto(req.SearchType), err)
+		}
+		notes = append(notes, note)
+	}
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"github.com/KuramaSyu/WerSu-Rest/src/oauth"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+)
+
+type IdentityReply struct {
+	Provider string    `json:"provider" example:"github"`
+	Subject  string    `json:"subject" example:"583231"`
+	Username string    `json:"username" example:"octocat"`
+	Email    string    `json:"email" example:"octocat@github.com"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+// IdentityReplyFromProto converts a protobuf LinkedIdentity message to an IdentityReply struct.
+func IdentityReplyFromProto(identity *proto.LinkedIdentity) IdentityReply {
+	return IdentityReply{
+		Provider: identity.Identity.GetProvider(),
+		Subject:  identity.Identity.GetSubject(),
+		Username: identity.Username,
+		Email:    identity.Email,
+		LinkedAt: identity.LinkedAt.AsTime(),
+	}
+}
+
+// GetIdentities godoc
+// @Summary List linked login identities
+// @Description Lists the login identities linked to the logged in user. Each of them can be used to log in.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} []IdentityReply
+// @Failure 401 {object} ProblemDetails
+// @Router /auth/identities [get]
+func (ac *AuthController) GetIdentities(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*ac.userService).GetIdentities(c, &proto.GetIdentitiesRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch identities via gRPC service: %w", err))
+		return
+	}
+
+	identities := []IdentityReply{}
+	for _, identity := range response.Identities {
+		identities = append(identities, IdentityReplyFromProto(identity))
+	}
+	c.JSON(http.StatusOK, identities)
+}
+
+// LinkIdentity godoc
+// @Summary Link a login identity
+// @Description Starts the OAuth flow of a provider. Afterwards the identity is linked to the logged in user
+// @Description and the browser is redirected to the frontend with the query parameter linked={provider}.
+// @Tags auth
+// @Param provider path string true "Login provider" example(github)
+// @Success 307
+// @Failure 404 {object} ProblemDetails
+// @Router /auth/identities/{provider}/link [get]
+func (ac *AuthController) LinkIdentity(c *gin.Context) {
+	if _, code, err := UserFromSession(c); err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	provider, err := ac.providerFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusNotFound, err)
+		return
+	}
+	ac.startOAuthFlow(c, provider, intentLink)
+}
+
+// completeLink links the identity of a finished OAuth flow to the logged in user
+func (ac *AuthController) completeLink(c *gin.Context, identity *oauth.Identity) {
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service call
+	_, err = (*ac.userService).LinkIdentity(c, &proto.LinkIdentityRequest{
+		UserId:   user.ID,
+		Identity: &proto.Identity{Provider: identity.Provider, Subject: identity.Subject},
+		Username: identity.Username,
+		Email:    identity.Email,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to link identity via gRPC service: %w", err))
+		return
+	}
+
+	log.Printf("User %v linked %s identity %v", user.ID, identity.Provider, identity.Subject)
+	redirect_url := fmt.Sprintf("%v?linked=%v", config.AppConfig.FrontendURL, url.QueryEscape(identity.Provider))
+	c.Redirect(http.StatusTemporaryRedirect, redirect_url)
+}
+
+// DeleteIdentity godoc
+// @Summary Unlink a login identity
+// @Description Unlinks a login identity from the logged in user. The last identity can't be unlinked.
+// @Tags auth
+// @Param provider path string true "Login provider" example(github)
+// @Param subject path string true "ID of the user at the provider" example(583231)
+// @Success 204
+// @Failure 400 {object} ProblemDetails "The identity is the last one of the user"
+// @Failure 404 {object} ProblemDetails
+// @Router /auth/identities/{provider}/{subject} [delete]
+func (ac *AuthController) DeleteIdentity(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	identity := &proto.Identity{
+		Provider: c.Params.ByName("provider"),
+		Subject:  c.Params.ByName("subject"),
+	}
+
+	// the user must be able to log in afterwards
+	response, err := (*ac.userService).GetIdentities(c, &proto.GetIdentitiesRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch identities via gRPC service: %w", err))
+		return
+	}
+	linked := false
+	for _, linkedIdentity := range response.Identities {
+		if linkedIdentity.Identity.GetProvider() == identity.Provider && linkedIdentity.Identity.GetSubject() == identity.Subject {
+			linked = true
+		}
+	}
+	if !linked {
+		SetGinError(c, http.StatusNotFound, fmt.Errorf("identity %s/%s is not linked", identity.Provider, identity.Subject))
+		return
+	}
+	if len(response.Identities) == 1 {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("the last identity can't be unlinked"))
+		return
+	}
+
+	// gRPC service call
+	_, err = (*ac.userService).UnlinkIdentity(c, &proto.UnlinkIdentityRequest{UserId: user.ID, Identity: identity})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to unlink identity via gRPC service: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
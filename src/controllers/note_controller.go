@@ -27,28 +27,63 @@ type NoteReply struct {
 	Content   string    `json:"content"`
 	UpdatedAt time.Time `json:"updated_at"`
 	AuthorId  int32     `json:"author_id"`
+	// number of the current version
+	Version int32    `json:"version" example:"3"`
+	Tags    []string `json:"tags" example:"work,ideas"`
+	// null if the note isn't in a notebook
+	NotebookId *int32 `json:"notebook_id" example:"3"`
+
+	// access of the requesting user
+	AccessLevel models.AccessLevel `json:"access_level" example:"owner"`
+	// who the note is shared with. Other users than the author only see
+	// the permissions which grant them access
+	Permissions []NotePermissionReply `json:"permissions"`
 }
 
 type PostNoteRequest struct {
-	Title   string `json:"title" binding:"required" example:"My Note Title"`
-	Content string `json:"content" binding:"required" example:"This is the content of my note."`
+	Title   string   `json:"title" binding:"required" example:"My Note Title"`
+	Content string   `json:"content" binding:"required" example:"This is the content of my note."`
+	Tags    []string `json:"tags" binding:"omitempty,max=20" example:"work,ideas"`
+	// notebook to create the note in. Requires write access on it
+	NotebookId *int32 `json:"notebook_id" binding:"omitempty,min=1" example:"3"`
+}
+
+// PatchNoteRequest contains the fields of a note which should be changed.
+// Fields which are omitted are left untouched.
+type PatchNoteRequest struct {
+	Title   *string `json:"title" binding:"omitempty,min=1" example:"My new Note Title"`
+	Content *string `json:"content" binding:"omitempty" example:"This is the new content of my note."`
+	// replaces all tags, an empty list removes them
+	Tags *[]string `json:"tags" binding:"omitempty,max=20" example:"work,ideas"`
+	// moves the note into another notebook, 0 moves it out of its notebook
+	NotebookId *int32 `json:"notebook_id" binding:"omitempty,min=0" example:"3"`
 }
 
 // NoteReplyFromProto converts a protobuf Note message to a NoteReply struct.
 //
 // Parameters:
 //   - note: A pointer to a proto.Note message to be converted
+//   - user: The user the note is sent to
 //
 // Returns:
 //   - NoteReply: A NoteReply struct populated with data from the proto.Note
-func NoteReplyFromProto(note *proto.Note) NoteReply {
-	return NoteReply{
-		Id:        note.Id,
-		Title:     note.Title,
-		Content:   note.Content,
-		UpdatedAt: note.UpdatedAt.AsTime(),
-		AuthorId:  note.AuthorId,
+func NoteReplyFromProto(note *proto.Note, user *models.User) NoteReply {
+	reply := NoteReply{
+		Id:          note.Id,
+		Title:       note.Title,
+		Content:     note.Content,
+		UpdatedAt:   note.UpdatedAt.AsTime(),
+		AuthorId:    note.AuthorId,
+		Version:     note.Version,
+		Tags:        append([]string{}, note.Tags...),
+		NotebookId:  note.NotebookId,
+		AccessLevel: NoteAccessLevel(note, user),
+		Permissions: []NotePermissionReply{},
 	}
+	for _, permission := range note.Permissions {
+		reply.Permissions = append(reply.Permissions, NotePermissionReplyFromProto(permission))
+	}
+	return reply
 }
 
 func NewNoteController(noteService *proto.NoteServiceClient) *NoteController {
@@ -62,8 +97,12 @@ func NewNoteController(noteService *proto.NoteServiceClient) *NoteController {
 // @Accept json
 // @Produce json
 // @Param id path int true "Note ID"
+// @Param If-None-Match header string false "ETag of a cached copy of the note"
 // @Success 200 {object} NoteReply
-// @Failure 400 {object} map[string]string
+// @Header 200 {string} ETag "Revision of the note"
+// @Success 304 "The cached copy is still current"
+// @Failure 400 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
 // @Router /notes/{id} [get]
 func (uc *NoteController) GetNote(c *gin.Context) {
 	// get user from session
@@ -81,21 +120,31 @@ func (uc *NoteController) GetNote(c *gin.Context) {
 	}
 
 	// gRPC service
-	note, err := (*uc.NoteService).GetNote(
-		c, &proto.GetNoteRequest{Id: int32(id), UserId: user.ID},
-	)
-	c.JSON(http.StatusOK, NoteReplyFromProto(note))
+	note, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessRead)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	c.Header("ETag", NoteETag(note))
+	if notModified(c, note) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, NoteReplyFromProto(note, user))
 }
 
 // PostNote godoc
 // @Summary Post a Note
 // @Description Creates a new Note via gRPC service
+// @Description [[Note Title]] and [[#42]] links in the content are stored, see /notes/{id}/links.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param payload body PostNoteRequest true "Note ID"
 // @Success 200 {object} NoteReply
-// @Failure 400 {object} map[string]string
+// @Header 200 {string} ETag "Revision of the note"
+// @Failure 400 {object} ProblemDetails
 // @Router /notes [post]
 func (uc *NoteController) PostNote(c *gin.Context) {
 	// get user from session
@@ -111,19 +160,192 @@ func (uc *NoteController) PostNote(c *gin.Context) {
 		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
 		return
 	}
+	tags, err := models.NormalizeTags(postNoteRequest.Tags)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// check permissions
+	if postNoteRequest.NotebookId != nil {
+		if _, code, err := fetchNotebookWithAccess(c, uc.NoteService, *postNoteRequest.NotebookId, user, models.AccessWrite); err != nil {
+			SetGinError(c, code, err)
+			return
+		}
+	}
 
 	// gRPC service call
 	grpcPostNoteRequest := proto.PostNoteRequest{
-		Title:    postNoteRequest.Title,
-		Content:  &postNoteRequest.Content,
-		AuthorId: user.ID,
+		Title:      postNoteRequest.Title,
+		Content:    &postNoteRequest.Content,
+		AuthorId:   user.ID,
+		Tags:       tags,
+		NotebookId: postNoteRequest.NotebookId,
+		Links:      NoteLinksToProto(postNoteRequest.Content),
 	}
 	note, err := (*uc.NoteService).PostNote(c, &grpcPostNoteRequest)
 	if err != nil {
-		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to post note via gRPC service: %w", err))
+		SetGrpcError(c, fmt.Errorf("failed to post note via gRPC service: %w", err))
 		return
 	}
 
 	// respond with created note
-	c.JSON(http.StatusOK, NoteReplyFromProto(note))
+	c.Header("ETag", NoteETag(note))
+	c.JSON(http.StatusOK, NoteReplyFromProto(note, user))
+}
+
+// PatchNote godoc
+// @Summary Update a Note
+// @Description Alters title, content and/or tags of a Note via gRPC service. Requires write access on the note.
+// @Description Moving the note into another notebook is restricted to the author and requires write access on the notebook.
+// @Description The If-Match header has to contain the ETag of the note, so changes made in the meantime aren't overwritten. Every change, including one of only the tags or the notebook, changes the ETag.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param payload body PatchNoteRequest true "Fields to update"
+// @Param If-Match header string true "ETag of the note, or * to overwrite any revision"
+// @Success 200 {object} NoteReply
+// @Header 200 {string} ETag "New revision of the note"
+// @Failure 400 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Failure 412 {object} ProblemDetails "The note was changed in the meantime"
+// @Failure 428 {object} ProblemDetails "If-Match header missing"
+// @Router /notes/{id} [patch]
+func (uc *NoteController) PatchNote(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// parse request body
+	var patchNoteRequest PatchNoteRequest
+	if err := c.ShouldBindJSON(&patchNoteRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if patchNoteRequest.Title == nil && patchNoteRequest.Content == nil && patchNoteRequest.Tags == nil && patchNoteRequest.NotebookId == nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: nothing to update"))
+		return
+	}
+	var tags *proto.TagList
+	if patchNoteRequest.Tags != nil {
+		normalized, err := models.NormalizeTags(*patchNoteRequest.Tags)
+		if err != nil {
+			SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+		tags = &proto.TagList{Tags: normalized}
+	}
+	var links *proto.NoteLinkList
+	if patchNoteRequest.Content != nil {
+		links = &proto.NoteLinkList{Links: NoteLinksToProto(*patchNoteRequest.Content)}
+	}
+
+	// check permissions. Moving changes who inherits access, so it is
+	// restricted to the author like sharing
+	level := models.AccessWrite
+	if patchNoteRequest.NotebookId != nil {
+		level = models.AccessOwner
+	}
+	current, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, level)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+	if notebookId := patchNoteRequest.NotebookId; notebookId != nil && *notebookId != 0 {
+		if _, code, err := fetchNotebookWithAccess(c, uc.NoteService, *notebookId, user, models.AccessWrite); err != nil {
+			SetGinError(c, code, err)
+			return
+		}
+	}
+	revision, code, err := expectedRevision(c, current)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	note, err := (*uc.NoteService).AlterNote(c, &proto.AlterNoteRequest{
+		Id:               int32(id),
+		Title:            patchNoteRequest.Title,
+		Content:          patchNoteRequest.Content,
+		UserId:           user.ID,
+		ExpectedRevision: revision,
+		Tags:             tags,
+		NotebookId:       patchNoteRequest.NotebookId,
+		Links:            links,
+	})
+	if err != nil {
+		setVersionConflictError(c, uc.NoteService, user, int32(id), fmt.Errorf("failed to alter note via gRPC service: %w", err))
+		return
+	}
+
+	// respond with updated note
+	c.Header("ETag", NoteETag(note))
+	c.JSON(http.StatusOK, NoteReplyFromProto(note, user))
+}
+
+// DeleteNote godoc
+// @Summary Delete a Note
+// @Description Moves a Note to the trash via gRPC service, from where it can be restored until it is purged. Only the author may do this.
+// @Description The If-Match header has to contain the ETag of the note, so changes made in the meantime aren't lost.
+// @Tags users
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param If-Match header string true "ETag of the note, or * to delete any revision"
+// @Success 204
+// @Failure 400 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Failure 412 {object} ProblemDetails "The note was changed in the meantime"
+// @Failure 428 {object} ProblemDetails "If-Match header missing"
+// @Router /notes/{id} [delete]
+func (uc *NoteController) DeleteNote(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// check permissions
+	current, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessOwner)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+	revision, code, err := expectedRevision(c, current)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	_, err = (*uc.NoteService).DeleteNote(c, &proto.DeleteNoteRequest{
+		Id:               int32(id),
+		UserId:           user.ID,
+		ExpectedRevision: revision,
+	})
+	if err != nil {
+		setVersionConflictError(c, uc.NoteService, user, int32(id), fmt.Errorf("failed to delete note via gRPC service: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
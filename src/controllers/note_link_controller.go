@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/KuramaSyu/WerSu-Rest/src/wikilink"
+	"github.com/gin-gonic/gin"
+)
+
+// resolution status of a NoteLinkReply
+const (
+	LinkStatusResolved  = "resolved"
+	LinkStatusDangling  = "dangling"
+	LinkStatusAmbiguous = "ambiguous"
+)
+
+// NoteLinkReply is a [[link]] in the content of a note
+type NoteLinkReply struct {
+	// the link as written, without brackets: a title or #id
+	Target string `json:"target" example:"My other Note"`
+	// resolved, dangling if no note matches, or ambiguous if several
+	// notes have the title
+	Status string `json:"status" example:"resolved"`
+	// the linked note, null for dangling links. For ambiguous links the
+	// latest updated note
+	Note *MinimalNote `json:"note"`
+}
+
+type GetNoteGraphRequest struct {
+	// only include notes inside this notebook
+	NotebookId int32 `form:"notebook_id" binding:"omitempty,min=1" example:"3"`
+	// whether notes in sub notebooks of notebook_id are included, defaults to true
+	Recursive *bool `form:"recursive" binding:"omitempty" example:"true"`
+	Limit     int32 `form:"limit" binding:"omitempty,min=1,max=2000" example:"500"`
+}
+
+// DefaultGraphLimit is the number of notes in a graph if no limit is given
+const DefaultGraphLimit = 500
+
+type NoteGraphNode struct {
+	Id         int32    `json:"id" example:"42"`
+	Title      string   `json:"title" example:"My Note"`
+	Tags       []string `json:"tags" example:"work,ideas"`
+	NotebookId *int32   `json:"notebook_id" example:"3"`
+}
+
+// NoteGraphEdge is a link from the source to the target note
+type NoteGraphEdge struct {
+	Source int32 `json:"source" example:"42"`
+	Target int32 `json:"target" example:"7"`
+}
+
+// NoteGraphReply contains notes and the links between them
+type NoteGraphReply struct {
+	Nodes []NoteGraphNode `json:"nodes"`
+	Edges []NoteGraphEdge `json:"edges"`
+}
+
+// NoteLinksToProto parses the [[links]] in the content of a note
+func NoteLinksToProto(content string) []*proto.NoteLink {
+	links := []*proto.NoteLink{}
+	for _, link := range wikilink.Parse(content) {
+		links = append(links, &proto.NoteLink{Title: link.Title, NoteId: link.NoteId})
+	}
+	return links
+}
+
+// NoteLinkReplyFromProto converts a protobuf ResolvedNoteLink message to a NoteLinkReply struct.
+func NoteLinkReplyFromProto(link *proto.ResolvedNoteLink, userID int32) NoteLinkReply {
+	reply := NoteLinkReply{
+		Target: link.Link.Title,
+		Status: LinkStatusDangling,
+	}
+	if link.Link.NoteId != 0 {
+		reply.Target = fmt.Sprintf("#%d", link.Link.NoteId)
+	}
+	switch link.Status {
+	case proto.ResolvedNoteLink_Resolved:
+		reply.Status = LinkStatusResolved
+	case proto.ResolvedNoteLink_Ambiguous:
+		reply.Status = LinkStatusAmbiguous
+	}
+	if link.Note != nil {
+		note := ConvertProtoMinimalNoteToRest(link.Note, userID)
+		reply.Note = &note
+	}
+	return reply
+}
+
+// GetLinks godoc
+// @Summary List the links of a note
+// @Description Lists the [[Note Title]] and [[#42]] links in the content of a note in order of their appearance,
+// @Description with the notes they point to. Links to notes the user can't read are reported as dangling.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} []NoteLinkReply
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/links [get]
+func (uc *NoteController) GetLinks(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessRead); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	response, err := (*uc.NoteService).GetNoteLinks(c, &proto.GetNoteLinksRequest{
+		NoteId: int32(id),
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch links via gRPC service: %w", err))
+		return
+	}
+
+	links := []NoteLinkReply{}
+	for _, link := range response.Links {
+		links = append(links, NoteLinkReplyFromProto(link, user.ID))
+	}
+	c.JSON(http.StatusOK, links)
+}
+
+// GetBacklinks godoc
+// @Summary List the notes linking to a note
+// @Description Lists the notes the logged in user can read, which link to the note by its title or ID.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} []MinimalNote
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/backlinks [get]
+func (uc *NoteController) GetBacklinks(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessRead); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	stream, err := (*uc.NoteService).GetNoteBacklinks(c, &proto.GetNoteLinksRequest{
+		NoteId: int32(id),
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch backlinks via gRPC service: %w", err))
+		return
+	}
+
+	notes := []MinimalNote{}
+	for {
+		note, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			SetGrpcError(c, fmt.Errorf("failed to receive backlinks via gRPC service: %w", err))
+			return
+		}
+		notes = append(notes, ConvertProtoMinimalNoteToRest(note, user.ID))
+	}
+	c.JSON(http.StatusOK, notes)
+}
+
+// GetGraph godoc
+// @Summary Get the link graph of notes
+// @Description Returns the notes the logged in user can read as nodes, latest updated first, and the links between them as edges.
+// @Description Only links between the returned notes are included.
+// @Tags notes
+// @Produce json
+// @Param notebook_id query int false "Only include notes inside this notebook"
+// @Param recursive query bool false "Include notes in sub notebooks of notebook_id" default(true)
+// @Param limit query int false "Maximum number of notes" default(500) maximum(2000)
+// @Success 200 {object} NoteGraphReply
+// @Failure 400 {object} ProblemDetails
+// @Router /notes/graph [get]
+func (uc *NoteController) GetGraph(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse query
+	var getNoteGraphRequest GetNoteGraphRequest
+	if err := c.ShouldBindQuery(&getNoteGraphRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
+		return
+	}
+	if getNoteGraphRequest.Limit == 0 {
+		getNoteGraphRequest.Limit = DefaultGraphLimit
+	}
+
+	// gRPC service call
+	grpcNoteGraphRequest := proto.GetNoteGraphRequest{
+		UserId: user.ID,
+		Limit:  getNoteGraphRequest.Limit,
+	}
+	if getNoteGraphRequest.NotebookId != 0 {
+		grpcNoteGraphRequest.NotebookId = &getNoteGraphRequest.NotebookId
+		grpcNoteGraphRequest.IncludeSubNotebooks = getNoteGraphRequest.Recursive == nil || *getNoteGraphRequest.Recursive
+	}
+	graph, err := (*uc.NoteService).GetNoteGraph(c, &grpcNoteGraphRequest)
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch graph via gRPC service: %w", err))
+		return
+	}
+
+	reply := NoteGraphReply{
+		Nodes: []NoteGraphNode{},
+		Edges: []NoteGraphEdge{},
+	}
+	for _, node := range graph.Nodes {
+		reply.Nodes = append(reply.Nodes, NoteGraphNode{
+			Id:         node.Id,
+			Title:      node.Title,
+			Tags:       append([]string{}, node.Tags...),
+			NotebookId: node.NotebookId,
+		})
+	}
+	for _, edge := range graph.Edges {
+		reply.Edges = append(reply.Edges, NoteGraphEdge{Source: edge.FromId, Target: edge.ToId})
+	}
+	c.JSON(http.StatusOK, reply)
+}
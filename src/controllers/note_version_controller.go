@@ -0,0 +1,343 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/diff"
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// NoteVersionReply describes a version of a note
+type NoteVersionReply struct {
+	Version   int32     `json:"version" example:"3"`
+	Title     string    `json:"title" example:"My Note"`
+	AuthorId  int32     `json:"author_id" example:"1"`
+	CreatedAt time.Time `json:"created_at"`
+	// version which was restored by this one
+	RestoredFrom *int32 `json:"restored_from" example:"1"`
+}
+
+// NoteVersionContentReply is a version of a note including its content
+type NoteVersionContentReply struct {
+	NoteVersionReply
+	Content string `json:"content" example:"This is the content of my note."`
+}
+
+type GetNoteVersionsRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0" example:"0"`
+}
+
+// diff formats of GetNoteDiffRequest
+const (
+	DiffModeUnified = "unified"
+	DiffModeWords   = "words"
+)
+
+type GetNoteDiffRequest struct {
+	From int32 `form:"from" binding:"required,min=1" example:"1"`
+	// defaults to the current version
+	To   int32  `form:"to" binding:"omitempty,min=1" example:"3"`
+	Mode string `form:"mode" binding:"omitempty,oneof=unified words" example:"unified"`
+}
+
+// NoteDiffReply contains the changes of the content between two versions,
+// either as unified diff or as word level segments
+type NoteDiffReply struct {
+	NoteId    int32          `json:"note_id" example:"42"`
+	From      int32          `json:"from" example:"1"`
+	To        int32          `json:"to" example:"3"`
+	Mode      string         `json:"mode" example:"unified"`
+	TitleFrom string         `json:"title_from" example:"My Note"`
+	TitleTo   string         `json:"title_to" example:"My renamed Note"`
+	Unified   string         `json:"unified,omitempty" example:"--- version 1\n+++ version 3\n@@ -1 +1 @@\n-old\n+new\n"`
+	Words     []diff.Segment `json:"words,omitempty"`
+}
+
+// NoteVersionReplyFromProto converts a protobuf NoteVersion message to a NoteVersionReply struct.
+func NoteVersionReplyFromProto(version *proto.NoteVersion) NoteVersionReply {
+	return NoteVersionReply{
+		Version:      version.Version,
+		Title:        version.Title,
+		AuthorId:     version.AuthorId,
+		CreatedAt:    version.CreatedAt.AsTime(),
+		RestoredFrom: version.RestoredFrom,
+	}
+}
+
+// versionFromPath reads the :version path parameter
+func versionFromPath(c *gin.Context) (int32, error) {
+	version, err := strconv.Atoi(c.Params.ByName("version"))
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid version %q", c.Params.ByName("version"))
+	}
+	return int32(version), nil
+}
+
+// GetVersions godoc
+// @Summary List versions of a note
+// @Description Lists the versions of a note, latest first. A version is stored with every change of the title or content.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param limit query int false "Number of versions to return (default 20, max 100)"
+// @Param offset query int false "Number of versions to skip"
+// @Success 200 {object} []NoteVersionReply
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/versions [get]
+func (uc *NoteController) GetVersions(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// parse query
+	var getNoteVersionsRequest GetNoteVersionsRequest
+	if err := c.ShouldBindQuery(&getNoteVersionsRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
+		return
+	}
+	if getNoteVersionsRequest.Limit == 0 {
+		getNoteVersionsRequest.Limit = DefaultSearchLimit
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessRead); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	response, err := (*uc.NoteService).GetNoteVersions(c, &proto.GetNoteVersionsRequest{
+		NoteId: int32(id),
+		Limit:  getNoteVersionsRequest.Limit,
+		Offset: getNoteVersionsRequest.Offset,
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch versions via gRPC service: %w", err))
+		return
+	}
+
+	versions := []NoteVersionReply{}
+	for _, version := range response.Versions {
+		versions = append(versions, NoteVersionReplyFromProto(version))
+	}
+	c.JSON(http.StatusOK, versions)
+}
+
+// GetVersion godoc
+// @Summary Get a version of a note
+// @Description Fetches the title and content of a note as they were in the given version.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param version path int true "Version"
+// @Success 200 {object} NoteVersionContentReply
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/versions/{version} [get]
+func (uc *NoteController) GetVersion(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+	version, err := versionFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessRead); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	noteVersion, err := (*uc.NoteService).GetNoteVersion(c, &proto.GetNoteVersionRequest{
+		NoteId:  int32(id),
+		Version: version,
+		UserId:  user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch version via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, NoteVersionContentReply{
+		NoteVersionReply: NoteVersionReplyFromProto(noteVersion),
+		Content:          noteVersion.Content,
+	})
+}
+
+// GetDiff godoc
+// @Summary Compare two versions of a note
+// @Description Computes the changes of the content between two versions, as unified diff (default) or as word level segments.
+// @Description The titles of both versions are returned as they are.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param from query int true "Older version"
+// @Param to query int false "Newer version, defaults to the current one"
+// @Param mode query string false "unified or words"
+// @Success 200 {object} NoteDiffReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/versions/diff [get]
+func (uc *NoteController) GetDiff(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// parse query
+	var getNoteDiffRequest GetNoteDiffRequest
+	if err := c.ShouldBindQuery(&getNoteDiffRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
+		return
+	}
+	if getNoteDiffRequest.Mode == "" {
+		getNoteDiffRequest.Mode = DiffModeUnified
+	}
+
+	// check permissions
+	note, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessRead)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+	if getNoteDiffRequest.To == 0 {
+		getNoteDiffRequest.To = note.Version
+	}
+
+	// gRPC service calls
+	var versions [2]*proto.NoteVersion
+	for i, version := range []int32{getNoteDiffRequest.From, getNoteDiffRequest.To} {
+		versions[i], err = (*uc.NoteService).GetNoteVersion(c, &proto.GetNoteVersionRequest{
+			NoteId:  int32(id),
+			Version: version,
+			UserId:  user.ID,
+		})
+		if err != nil {
+			SetGrpcError(c, fmt.Errorf("failed to fetch version %d via gRPC service: %w", version, err))
+			return
+		}
+	}
+	from, to := versions[0], versions[1]
+
+	reply := NoteDiffReply{
+		NoteId:    int32(id),
+		From:      from.Version,
+		To:        to.Version,
+		Mode:      getNoteDiffRequest.Mode,
+		TitleFrom: from.Title,
+		TitleTo:   to.Title,
+	}
+	switch getNoteDiffRequest.Mode {
+	case DiffModeWords:
+		reply.Words = diff.Words(from.Content, to.Content)
+	default:
+		reply.Unified = diff.Unified(
+			fmt.Sprintf("version %d", from.Version),
+			fmt.Sprintf("version %d", to.Version),
+			from.Content,
+			to.Content,
+		)
+	}
+	c.JSON(http.StatusOK, reply)
+}
+
+// RestoreVersion godoc
+// @Summary Restore a version of a note
+// @Description Sets title and content of a note back to an older version. This is stored as a new version, so no change is lost.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param version path int true "Version to restore"
+// @Success 200 {object} NoteReply
+// @Header 200 {string} ETag "New revision of the note"
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/versions/{version}/restore [post]
+func (uc *NoteController) RestoreVersion(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+	version, err := versionFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessWrite); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service calls. The content of the version is needed for its links
+	noteVersion, err := (*uc.NoteService).GetNoteVersion(c, &proto.GetNoteVersionRequest{
+		NoteId:  int32(id),
+		Version: version,
+		UserId:  user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch version via gRPC service: %w", err))
+		return
+	}
+	note, err := (*uc.NoteService).RestoreNoteVersion(c, &proto.RestoreNoteVersionRequest{
+		NoteId:  int32(id),
+		Version: version,
+		UserId:  user.ID,
+		Links:   NoteLinksToProto(noteVersion.Content),
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to restore version via gRPC service: %w", err))
+		return
+	}
+
+	c.Header("ETag", NoteETag(note))
+	c.JSON(http.StatusOK, NoteReplyFromProto(note, user))
+}
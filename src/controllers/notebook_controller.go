@@ -0,0 +1,489 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NotebookController handles the notebooks notes are grouped in
+type NotebookController struct {
+	NoteService *proto.NoteServiceClient
+}
+
+func NewNotebookController(noteService *proto.NoteServiceClient) *NotebookController {
+	return &NotebookController{NoteService: noteService}
+}
+
+type NotebookReply struct {
+	Id        int32     `json:"id" example:"3"`
+	Name      string    `json:"name" example:"Meetings"`
+	ParentId  *int32    `json:"parent_id" example:"1"`
+	OwnerId   int32     `json:"owner_id" example:"1"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// number of notes directly inside the notebook
+	NoteCount int32 `json:"note_count" example:"12"`
+
+	// access of the requesting user, including access inherited from parent notebooks
+	AccessLevel models.AccessLevel `json:"access_level" example:"owner"`
+	// who the notebook is shared with. Other users than the owner only see
+	// the permissions which grant them access
+	Permissions []NotePermissionReply `json:"permissions"`
+}
+
+// kinds of nodes in the notebook tree
+const (
+	NotebookKindNotebook = "notebook"
+	// a pinned saved search. Its ID is the ID of the saved search and its
+	// notes are listed by /searches/{id}/results
+	NotebookKindSmart = "smart"
+)
+
+// NotebookTreeReply is a notebook with all notebooks inside it
+type NotebookTreeReply struct {
+	NotebookReply
+	// notebook, or smart for a pinned saved search
+	Kind     string              `json:"kind" example:"notebook"`
+	Children []NotebookTreeReply `json:"children"`
+}
+
+type PostNotebookRequest struct {
+	Name     string `json:"name" binding:"required,max=100" example:"Meetings"`
+	ParentId *int32 `json:"parent_id" binding:"omitempty,min=1" example:"1"`
+}
+
+// PatchNotebookRequest contains the fields of a notebook which should be
+// changed. Fields which are omitted are left untouched.
+type PatchNotebookRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=100" example:"Team Meetings"`
+	// moves the notebook, 0 moves it to the top level
+	ParentId *int32 `json:"parent_id" binding:"omitempty,min=0" example:"2"`
+}
+
+// NotebookReplyFromProto converts a protobuf Notebook message to a NotebookReply struct.
+//
+// Parameters:
+//   - notebook: A pointer to a proto.Notebook message to be converted
+//   - user: The user the notebook is sent to
+func NotebookReplyFromProto(notebook *proto.Notebook, user *models.User) NotebookReply {
+	reply := NotebookReply{
+		Id:          notebook.Id,
+		Name:        notebook.Name,
+		ParentId:    notebook.ParentId,
+		OwnerId:     notebook.OwnerId,
+		CreatedAt:   notebook.CreatedAt.AsTime(),
+		UpdatedAt:   notebook.UpdatedAt.AsTime(),
+		NoteCount:   notebook.NoteCount,
+		AccessLevel: NotebookAccessLevel(notebook, user),
+		Permissions: []NotePermissionReply{},
+	}
+	for _, permission := range notebook.Permissions {
+		reply.Permissions = append(reply.Permissions, NotePermissionReplyFromProto(permission))
+	}
+	return reply
+}
+
+// NotebookAccessLevel returns the access a user has on a notebook, like
+// NoteAccessLevel does for notes
+func NotebookAccessLevel(notebook *proto.Notebook, user *models.User) models.AccessLevel {
+	if notebook.OwnerId == user.ID {
+		return models.AccessOwner
+	}
+	if notebook.AccessLevel != proto.NotePermission_None {
+		return AccessLevelFromProto(notebook.AccessLevel)
+	}
+	return AccessLevelFromProto(userPermissionLevel(notebook.Permissions, user))
+}
+
+// isInsideNotebook reports whether the notebook is the notebook with the given
+// ID or nested in it, by walking up its parents. Parents the user can't read
+// end the walk, since the user owns the notebook with the given ID and
+// therefore can read everything inside it.
+//
+// Returns:
+//   - bool: Whether the notebook is inside the one with the given ID
+//   - int: HTTP status code (200 for success, otherwise derived from the gRPC error)
+//   - error: Error message if a parent can't be fetched
+func isInsideNotebook(c *gin.Context, noteService *proto.NoteServiceClient, notebook *proto.Notebook, id int32, user *models.User) (bool, int, error) {
+	visited := map[int32]bool{}
+	for notebook.Id != id {
+		visited[notebook.Id] = true
+		if notebook.ParentId == nil || visited[*notebook.ParentId] {
+			return false, http.StatusOK, nil
+		}
+		parent, err := (*noteService).GetNotebook(c, &proto.GetNotebookRequest{Id: *notebook.ParentId, UserId: user.ID})
+		if code := status.Code(err); code == codes.NotFound || code == codes.PermissionDenied {
+			return false, http.StatusOK, nil
+		}
+		if err != nil {
+			return false, HTTPStatusFromError(err), fmt.Errorf("failed to fetch notebook via gRPC service: %w", err)
+		}
+		notebook = parent
+	}
+	return true, http.StatusOK, nil
+}
+
+// fetchNotebookWithAccess fetches a notebook and checks whether the user has
+// at least the given access on it, like fetchNoteWithAccess does for notes
+func fetchNotebookWithAccess(c *gin.Context, noteService *proto.NoteServiceClient, id int32, user *models.User, level models.AccessLevel) (*proto.Notebook, int, error) {
+	notebook, err := (*noteService).GetNotebook(c, &proto.GetNotebookRequest{Id: id, UserId: user.ID})
+	if err != nil {
+		return nil, HTTPStatusFromError(err), fmt.Errorf("failed to fetch notebook via gRPC service: %w", err)
+	}
+	access := NotebookAccessLevel(notebook, user)
+	if !access.Includes(models.AccessRead) {
+		// don't reveal that the notebook exists
+		return nil, http.StatusNotFound, fmt.Errorf("notebook %d not found", id)
+	}
+	if level == models.AccessOwner && access != models.AccessOwner {
+		return nil, http.StatusForbidden, fmt.Errorf("only the owner of notebook %d may do this", id)
+	}
+	if !access.Includes(level) {
+		return nil, http.StatusForbidden, fmt.Errorf("%s access on notebook %d required", level, id)
+	}
+	return notebook, http.StatusOK, nil
+}
+
+// SmartNotebookReplyFromProto converts a pinned saved search to a node of the
+// notebook tree. It is placed inside the notebook the search is restricted to.
+func SmartNotebookReplyFromProto(search *proto.SavedSearch) NotebookTreeReply {
+	return NotebookTreeReply{
+		NotebookReply: NotebookReply{
+			Id:          search.Id,
+			Name:        search.Name,
+			ParentId:    search.NotebookId,
+			OwnerId:     search.OwnerId,
+			CreatedAt:   search.CreatedAt.AsTime(),
+			UpdatedAt:   search.UpdatedAt.AsTime(),
+			AccessLevel: models.AccessOwner,
+			Permissions: []NotePermissionReply{},
+		},
+		Kind:     NotebookKindSmart,
+		Children: []NotebookTreeReply{},
+	}
+}
+
+// buildNotebookTree nests the notebooks below their parents. Notebooks whose
+// parent isn't part of the list become top level notebooks. Pinned saved
+// searches are added as smart notebooks in the same way. Siblings are sorted
+// by name, smart notebooks after the other ones.
+func buildNotebookTree(notebooks []*proto.Notebook, searches []*proto.SavedSearch, user *models.User) []NotebookTreeReply {
+	known := map[int32]bool{}
+	for _, notebook := range notebooks {
+		known[notebook.Id] = true
+	}
+	children := map[int32][]*proto.Notebook{} // parent ID, 0 for top level
+	for _, notebook := range notebooks {
+		parent := notebook.GetParentId()
+		if !known[parent] {
+			parent = 0
+		}
+		children[parent] = append(children[parent], notebook)
+	}
+	smart := map[int32][]*proto.SavedSearch{} // notebook ID, 0 for top level
+	for _, search := range searches {
+		if !search.Pinned {
+			continue
+		}
+		parent := search.GetNotebookId()
+		if !known[parent] {
+			parent = 0
+		}
+		smart[parent] = append(smart[parent], search)
+	}
+
+	var build func(parent int32) []NotebookTreeReply
+	build = func(parent int32) []NotebookTreeReply {
+		nodes := []NotebookTreeReply{}
+		for _, notebook := range children[parent] {
+			nodes = append(nodes, NotebookTreeReply{
+				NotebookReply: NotebookReplyFromProto(notebook, user),
+				Kind:          NotebookKindNotebook,
+				Children:      build(notebook.Id),
+			})
+		}
+		for _, search := range smart[parent] {
+			nodes = append(nodes, SmartNotebookReplyFromProto(search))
+		}
+		slices.SortFunc(nodes, func(a, b NotebookTreeReply) int {
+			if a.Kind != b.Kind {
+				return strings.Compare(a.Kind, b.Kind) // "notebook" before "smart"
+			}
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+		return nodes
+	}
+	return build(0)
+}
+
+// GetNotebooks godoc
+// @Summary List notebooks as tree
+// @Description Lists all notebooks the logged in user has access to, nested below their parents.
+// @Description Shared notebooks whose parent isn't accessible appear at the top level.
+// @Description Pinned saved searches appear as nodes of kind smart inside the notebook they are restricted to,
+// @Description or at the top level.
+// @Tags notebooks
+// @Produce json
+// @Success 200 {object} []NotebookTreeReply
+// @Failure 401 {object} ProblemDetails
+// @Router /notebooks [get]
+func (nc *NotebookController) GetNotebooks(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*nc.NoteService).GetNotebooks(c, &proto.GetNotebooksRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch notebooks via gRPC service: %w", err))
+		return
+	}
+	searches, err := (*nc.NoteService).GetSavedSearches(c, &proto.GetSavedSearchesRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch saved searches via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, buildNotebookTree(response.Notebooks, searches.Searches, user))
+}
+
+// GetNotebook godoc
+// @Summary Get notebook by ID
+// @Tags notebooks
+// @Produce json
+// @Param id path int true "Notebook ID"
+// @Success 200 {object} NotebookReply
+// @Failure 404 {object} ProblemDetails
+// @Router /notebooks/{id} [get]
+func (nc *NotebookController) GetNotebook(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// gRPC service
+	notebook, code, err := fetchNotebookWithAccess(c, nc.NoteService, int32(id), user, models.AccessRead)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+	c.JSON(http.StatusOK, NotebookReplyFromProto(notebook, user))
+}
+
+// PostNotebook godoc
+// @Summary Create a notebook
+// @Description Creates a notebook, optionally inside another one. Requires write access on the parent.
+// @Tags notebooks
+// @Accept json
+// @Produce json
+// @Param payload body PostNotebookRequest true "Notebook to create"
+// @Success 201 {object} NotebookReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Router /notebooks [post]
+func (nc *NotebookController) PostNotebook(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse request body
+	var postNotebookRequest PostNotebookRequest
+	if err := c.ShouldBindJSON(&postNotebookRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// check permissions
+	if postNotebookRequest.ParentId != nil {
+		if _, code, err := fetchNotebookWithAccess(c, nc.NoteService, *postNotebookRequest.ParentId, user, models.AccessWrite); err != nil {
+			SetGinError(c, code, err)
+			return
+		}
+	}
+
+	// gRPC service call
+	notebook, err := (*nc.NoteService).PostNotebook(c, &proto.PostNotebookRequest{
+		Name:     strings.TrimSpace(postNotebookRequest.Name),
+		ParentId: postNotebookRequest.ParentId,
+		UserId:   user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to post notebook via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, NotebookReplyFromProto(notebook, user))
+}
+
+// PatchNotebook godoc
+// @Summary Rename or move a notebook
+// @Description Renaming requires write access. Moving requires ownership of the notebook and write access on the new parent.
+// @Description A notebook can't be moved into itself or one of its sub notebooks.
+// @Description The REST API only checks this up front to give a clear error. The gRPC service enforces it atomically, so a conflicting concurrent move is rejected the same way.
+// @Tags notebooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Notebook ID"
+// @Param payload body PatchNotebookRequest true "Fields to update"
+// @Success 200 {object} NotebookReply
+// @Failure 400 {object} ProblemDetails "Invalid request, or the new parent is the notebook itself or inside it"
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notebooks/{id} [patch]
+func (nc *NotebookController) PatchNotebook(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// parse request body
+	var patchNotebookRequest PatchNotebookRequest
+	if err := c.ShouldBindJSON(&patchNotebookRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if patchNotebookRequest.Name == nil && patchNotebookRequest.ParentId == nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: nothing to update"))
+		return
+	}
+	if patchNotebookRequest.Name != nil {
+		name := strings.TrimSpace(*patchNotebookRequest.Name)
+		patchNotebookRequest.Name = &name
+	}
+	if patchNotebookRequest.ParentId != nil && *patchNotebookRequest.ParentId == int32(id) {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("a notebook can't be its own parent"))
+		return
+	}
+
+	// check permissions. Moving changes who inherits access, so it is
+	// restricted to the owner like sharing
+	level := models.AccessWrite
+	if patchNotebookRequest.ParentId != nil {
+		level = models.AccessOwner
+	}
+	if _, code, err := fetchNotebookWithAccess(c, nc.NoteService, int32(id), user, level); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+	if parentId := patchNotebookRequest.ParentId; parentId != nil && *parentId != 0 {
+		parent, code, err := fetchNotebookWithAccess(c, nc.NoteService, *parentId, user, models.AccessWrite)
+		if err != nil {
+			SetGinError(c, code, err)
+			return
+		}
+		inside, code, err := isInsideNotebook(c, nc.NoteService, parent, int32(id), user)
+		if err != nil {
+			SetGinError(c, code, err)
+			return
+		}
+		// only a pre-check for a clear error, the gRPC service enforces it
+		// atomically
+		if inside {
+			SetGinError(c, http.StatusBadRequest, notebookCycleError(*parentId, int32(id)))
+			return
+		}
+	}
+
+	// gRPC service call
+	notebook, err := (*nc.NoteService).AlterNotebook(c, &proto.AlterNotebookRequest{
+		Id:       int32(id),
+		Name:     patchNotebookRequest.Name,
+		ParentId: patchNotebookRequest.ParentId,
+		UserId:   user.ID,
+	})
+	if patchNotebookRequest.ParentId != nil && status.Code(err) == codes.FailedPrecondition {
+		// the notebook was moved concurrently, so the parent is inside it now
+		SetGinError(c, http.StatusBadRequest, notebookCycleError(*patchNotebookRequest.ParentId, int32(id)))
+		return
+	}
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to alter notebook via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, NotebookReplyFromProto(notebook, user))
+}
+
+// notebookCycleError is returned when a notebook would be moved into one of
+// its sub notebooks
+func notebookCycleError(parentId int32, id int32) error {
+	return fmt.Errorf("notebook %d is inside notebook %d and can't become its parent", parentId, id)
+}
+
+// DeleteNotebook godoc
+// @Summary Delete a notebook
+// @Description Deletes a notebook. The notes and notebooks inside are moved to its parent, so nothing is lost.
+// @Description Only the owner may do this.
+// @Tags notebooks
+// @Param id path int true "Notebook ID"
+// @Success 204
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notebooks/{id} [delete]
+func (nc *NotebookController) DeleteNotebook(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNotebookWithAccess(c, nc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	_, err = (*nc.NoteService).DeleteNotebook(c, &proto.DeleteNotebookRequest{
+		Id:     int32(id),
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to delete notebook via gRPC service: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPermissions godoc
+// @Summary List who has access to a notebook
+// @Description Lists the users and roles a notebook is shared with. Only the owner may do this.
+// @Tags notebooks
+// @Produce json
+// @Param id path int true "Notebook ID"
+// @Success 200 {object} []NotePermissionReply
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notebooks/{id}/permissions [get]
+func (nc *NotebookController) GetPermissions(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNotebookWithAccess(c, nc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	response, err := (*nc.NoteService).GetNotebookPermissions(c, &proto.GetNotebookPermissionsRequest{
+		NotebookId: int32(id),
+		UserId:     user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch permissions via gRPC service: %w", err))
+		return
+	}
+
+	permissions := []NotePermissionReply{}
+	for _, permission := range response.Permissions {
+		permissions = append(permissions, NotePermissionReplyFromProto(permission))
+	}
+	c.JSON(http.StatusOK, permissions)
+}
+
+// PutPermission godoc
+// @Summary Share a notebook
+// @Description Grants a user or all users with a role read, comment or write access on a notebook.
+// @Description The access is inherited by all notes and notebooks inside, unless they set their own permission for the user or role.
+// @Description An existing permission of the user or role is replaced. Only the owner may do this.
+// @Tags notebooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Notebook ID"
+// @Param subject_type path string true "Whom to grant access" Enums(users, roles)
+// @Param subject_id path int true "User or role ID"
+// @Param payload body PutNotePermissionRequest true "Access to grant"
+// @Success 200 {object} NotePermissionReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notebooks/{id}/permissions/{subject_type}/{subject_id} [put]
+func (nc *NotebookController) PutPermission(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+	permission, err := permissionFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, err)
+		return
+	}
+	if permission.UserId == user.ID {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("the owner always has access"))
+		return
+	}
+
+	// parse request body
+	var putNotePermissionRequest PutNotePermissionRequest
+	if err := c.ShouldBindJSON(&putNotePermissionRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	permission.Level = AccessLevelToProto(putNotePermissionRequest.Level)
+
+	// check permissions
+	if _, code, err := fetchNotebookWithAccess(c, nc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	granted, err := (*nc.NoteService).GrantNotebookPermission(c, &proto.GrantNotebookPermissionRequest{
+		NotebookId: int32(id),
+		Permission: permission,
+		UserId:     user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to grant permission via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, NotePermissionReplyFromProto(granted))
+}
+
+// DeletePermission godoc
+// @Summary Stop sharing a notebook
+// @Description Revokes the access of a user or role on a notebook. Only the owner may do this.
+// @Tags notebooks
+// @Param id path int true "Notebook ID"
+// @Param subject_type path string true "Whose access to revoke" Enums(users, roles)
+// @Param subject_id path int true "User or role ID"
+// @Success 204
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notebooks/{id}/permissions/{subject_type}/{subject_id} [delete]
+func (nc *NotebookController) DeletePermission(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+	permission, err := permissionFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNotebookWithAccess(c, nc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	_, err = (*nc.NoteService).RevokeNotebookPermission(c, &proto.RevokeNotebookPermissionRequest{
+		NotebookId: int32(id),
+		Permission: permission,
+		UserId:     user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to revoke permission via gRPC service: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,368 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// NotePermissionReply is access to a note granted to a user or a role
+type NotePermissionReply struct {
+	UserId int32              `json:"user_id,omitempty" example:"7"`
+	RoleId int32              `json:"role_id,omitempty"`
+	Level  models.AccessLevel `json:"level" example:"read"`
+}
+
+type PutNotePermissionRequest struct {
+	Level models.AccessLevel `json:"level" binding:"required,oneof=read comment write" example:"comment"`
+}
+
+type GetSharedNotesRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0" example:"0"`
+}
+
+// path segments of the subject a permission is granted to
+const (
+	permissionSubjectUsers = "users"
+	permissionSubjectRoles = "roles"
+)
+
+var accessLevelsFromProto = map[proto.NotePermission_Level]models.AccessLevel{
+	proto.NotePermission_None:    models.AccessNone,
+	proto.NotePermission_Read:    models.AccessRead,
+	proto.NotePermission_Comment: models.AccessComment,
+	proto.NotePermission_Write:   models.AccessWrite,
+}
+
+// AccessLevelFromProto converts a protobuf permission level to an AccessLevel
+func AccessLevelFromProto(level proto.NotePermission_Level) models.AccessLevel {
+	if accessLevel, ok := accessLevelsFromProto[level]; ok {
+		return accessLevel
+	}
+	return models.AccessNone
+}
+
+// AccessLevelToProto converts an AccessLevel to a protobuf permission level.
+// AccessOwner can't be granted and maps to Write.
+func AccessLevelToProto(level models.AccessLevel) proto.NotePermission_Level {
+	if level == models.AccessOwner {
+		return proto.NotePermission_Write
+	}
+	for protoLevel, accessLevel := range accessLevelsFromProto {
+		if accessLevel == level {
+			return protoLevel
+		}
+	}
+	return proto.NotePermission_None
+}
+
+// NotePermissionReplyFromProto converts a protobuf NotePermission message to a NotePermissionReply struct.
+func NotePermissionReplyFromProto(permission *proto.NotePermission) NotePermissionReply {
+	return NotePermissionReply{
+		UserId: permission.UserId,
+		RoleId: permission.RoleId,
+		Level:  AccessLevelFromProto(permission.Level),
+	}
+}
+
+// NoteAccessLevel returns the access a user has on a note. The author is the
+// owner, other users get the access reported by the gRPC service, which knows
+// their roles. Only if it reports none, a permission granted to the user
+// directly is used. Role permissions are never used here, since the REST layer
+// doesn't know which roles the user has.
+func NoteAccessLevel(note *proto.Note, user *models.User) models.AccessLevel {
+	if note.AuthorId == user.ID {
+		return models.AccessOwner
+	}
+	if note.AccessLevel != proto.NotePermission_None {
+		return AccessLevelFromProto(note.AccessLevel)
+	}
+	return AccessLevelFromProto(userPermissionLevel(note.Permissions, user))
+}
+
+// userPermissionLevel returns the highest level of the permissions granted to
+// the user directly
+func userPermissionLevel(permissions []*proto.NotePermission, user *models.User) proto.NotePermission_Level {
+	level := proto.NotePermission_None
+	for _, permission := range permissions {
+		if permission.RoleId != 0 || permission.UserId != user.ID {
+			continue
+		}
+		if permission.Level > level {
+			level = permission.Level
+		}
+	}
+	return level
+}
+
+// fetchNoteWithAccess fetches a note and checks whether the user has at least
+// the given access on it.
+//
+// Returns:
+//   - *proto.Note: The note if the user has the access
+//   - int: HTTP status code (200 for success, 404 if the user can't read the note,
+//     403 if the user can read but lacks the access, otherwise derived from the gRPC error)
+//   - error: Error message if the note can't be fetched or accessed
+func fetchNoteWithAccess(c *gin.Context, noteService *proto.NoteServiceClient, id int32, user *models.User, level models.AccessLevel) (*proto.Note, int, error) {
+	note, err := (*noteService).GetNote(c, &proto.GetNoteRequest{Id: id, UserId: user.ID})
+	if err != nil {
+		return nil, HTTPStatusFromError(err), fmt.Errorf("failed to fetch note via gRPC service: %w", err)
+	}
+	access := NoteAccessLevel(note, user)
+	if !access.Includes(models.AccessRead) {
+		// don't reveal that the note exists
+		return nil, http.StatusNotFound, fmt.Errorf("note %d not found", id)
+	}
+	if level == models.AccessOwner && access != models.AccessOwner {
+		return nil, http.StatusForbidden, fmt.Errorf("only the author of note %d may do this", id)
+	}
+	if !access.Includes(level) {
+		return nil, http.StatusForbidden, fmt.Errorf("%s access on note %d required", level, id)
+	}
+	return note, http.StatusOK, nil
+}
+
+// permissionFromPath reads the subject of a permission from the
+// :subject_type and :subject_id path parameters
+func permissionFromPath(c *gin.Context) (*proto.NotePermission, error) {
+	subjectId, err := strconv.Atoi(c.Params.ByName("subject_id"))
+	if err != nil || subjectId <= 0 {
+		return nil, fmt.Errorf("invalid subject ID %q", c.Params.ByName("subject_id"))
+	}
+	switch c.Params.ByName("subject_type") {
+	case permissionSubjectUsers:
+		return &proto.NotePermission{UserId: int32(subjectId)}, nil
+	case permissionSubjectRoles:
+		return &proto.NotePermission{RoleId: int32(subjectId)}, nil
+	default:
+		return nil, fmt.Errorf("invalid subject type %q, expected users or roles", c.Params.ByName("subject_type"))
+	}
+}
+
+// GetPermissions godoc
+// @Summary List who has access to a note
+// @Description Lists the users and roles a note is shared with. Only the author may do this.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} []NotePermissionReply
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/permissions [get]
+func (uc *NoteController) GetPermissions(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	response, err := (*uc.NoteService).GetNotePermissions(c, &proto.GetNotePermissionsRequest{
+		NoteId: int32(id),
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch permissions via gRPC service: %w", err))
+		return
+	}
+
+	permissions := []NotePermissionReply{}
+	for _, permission := range response.Permissions {
+		permissions = append(permissions, NotePermissionReplyFromProto(permission))
+	}
+	c.JSON(http.StatusOK, permissions)
+}
+
+// PutPermission godoc
+// @Summary Share a note
+// @Description Grants a user or all users with a role read, comment or write access on a note.
+// @Description An existing permission of the user or role is replaced. Only the author may do this.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param subject_type path string true "Whom to grant access" Enums(users, roles)
+// @Param subject_id path int true "User or role ID"
+// @Param payload body PutNotePermissionRequest true "Access to grant"
+// @Success 200 {object} NotePermissionReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/permissions/{subject_type}/{subject_id} [put]
+func (uc *NoteController) PutPermission(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+	permission, err := permissionFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, err)
+		return
+	}
+	if permission.UserId == user.ID {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("the author always has access"))
+		return
+	}
+
+	// parse request body
+	var putNotePermissionRequest PutNotePermissionRequest
+	if err := c.ShouldBindJSON(&putNotePermissionRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	permission.Level = AccessLevelToProto(putNotePermissionRequest.Level)
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	granted, err := (*uc.NoteService).GrantNotePermission(c, &proto.GrantNotePermissionRequest{
+		NoteId:     int32(id),
+		Permission: permission,
+		UserId:     user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to grant permission via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, NotePermissionReplyFromProto(granted))
+}
+
+// DeletePermission godoc
+// @Summary Stop sharing a note
+// @Description Revokes the access of a user or role on a note. Only the author may do this.
+// @Tags notes
+// @Param id path int true "Note ID"
+// @Param subject_type path string true "Whose access to revoke" Enums(users, roles)
+// @Param subject_id path int true "User or role ID"
+// @Success 204
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/permissions/{subject_type}/{subject_id} [delete]
+func (uc *NoteController) DeletePermission(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+	permission, err := permissionFromPath(c)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	_, err = (*uc.NoteService).RevokeNotePermission(c, &proto.RevokeNotePermissionRequest{
+		NoteId:     int32(id),
+		Permission: permission,
+		UserId:     user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to revoke permission via gRPC service: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSharedNotes godoc
+// @Summary List notes shared with me
+// @Description Lists notes of other users which the logged in user has access to, latest first.
+// @Tags notes
+// @Produce json
+// @Param limit query int false "Maximum results to return" default(20) maximum(100)
+// @Param offset query int false "Pagination offset"
+// @Success 200 {object} []MinimalNote
+// @Failure 400 {object} ProblemDetails
+// @Router /notes/shared [get]
+func (uc *NoteController) GetSharedNotes(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse query
+	var getSharedNotesRequest GetSharedNotesRequest
+	if err := c.ShouldBindQuery(&getSharedNotesRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
+		return
+	}
+	if getSharedNotesRequest.Limit == 0 {
+		getSharedNotesRequest.Limit = DefaultSearchLimit
+	}
+
+	// gRPC service call
+	stream, err := (*uc.NoteService).GetSharedNotes(c, &proto.GetSharedNotesRequest{
+		UserId: user.ID,
+		Limit:  getSharedNotesRequest.Limit,
+		Offset: getSharedNotesRequest.Offset,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch shared notes via gRPC service: %w", err))
+		return
+	}
+
+	notes := []MinimalNote{}
+	for {
+		note, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			SetGrpcError(c, fmt.Errorf("failed to receive shared notes via gRPC service: %w", err))
+			return
+		}
+		notes = append(notes, ConvertProtoMinimalNoteToRest(note, user.ID))
+	}
+	c.JSON(http.StatusOK, notes)
+}
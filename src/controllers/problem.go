@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/middleware"
+	"github.com/KuramaSyu/WerSu-Rest/src/searchquery"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// MIMEProblemJSON is the media type of RFC 7807 problem details
+const MIMEProblemJSON = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details object, which is the body of
+// every error response of the REST API.
+type ProblemDetails struct {
+	// URI reference which identifies the problem type
+	Type string `json:"type" example:"urn:wersu:problem:not-found"`
+
+	// short summary of the problem type
+	Title string `json:"title" example:"Not Found"`
+
+	// HTTP status code
+	Status int `json:"status" example:"404"`
+
+	// explanation specific to this occurrence of the problem
+	Detail string `json:"detail" example:"failed to fetch note via gRPC service: note not found"`
+
+	// URI reference which identifies this occurrence of the problem
+	Instance string `json:"instance" example:"/api/notes/42"`
+
+	// ID of the request, also sent in the X-Request-ID header
+	RequestID string `json:"request_id" example:"4f6c3d0e8b1a4c2e9f7d5b3a1c0e8f6d"`
+
+	// machine readable error code
+	Code string `json:"code" example:"NOT_FOUND"`
+
+	// fields of the request which failed validation
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+
+	// current version of a note which was changed in the meantime
+	CurrentVersion *int32 `json:"current_version,omitempty" example:"3"`
+}
+
+// InvalidParam describes a single request field which failed validation
+type InvalidParam struct {
+	Name   string `json:"name" example:"title"`
+	Reason string `json:"reason" example:"required"`
+	// character offset of the problem in the value, starting at 0. Only set
+	// for values with a syntax, like search queries
+	Position *int `json:"position,omitempty" example:"12"`
+}
+
+func init() {
+	// report validation errors with the JSON or form name of a field instead
+	// of the Go struct field name
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldNameFromTags)
+	}
+}
+
+// fieldNameFromTags returns the name of a struct field as it appears in a
+// request, taken from the json or form struct tag.
+func fieldNameFromTags(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// NewProblemDetails creates the problem details for an error response.
+//
+// Parameters:
+//   - c: The Gin context of the failed request
+//   - status: HTTP status code of the response
+//   - err: The error which caused the problem
+func NewProblemDetails(c *gin.Context, status int, err error) ProblemDetails {
+	code := ErrorCode(status, err)
+	return ProblemDetails{
+		Type:           "urn:wersu:problem:" + strings.ReplaceAll(strings.ToLower(code), "_", "-"),
+		Title:          problemTitle(status),
+		Status:         status,
+		Detail:         err.Error(),
+		Instance:       c.Request.URL.RequestURI(),
+		RequestID:      middleware.GetRequestID(c),
+		Code:           code,
+		InvalidParams:  InvalidParamsFromError(err),
+		CurrentVersion: currentVersionFromError(err),
+	}
+}
+
+// InvalidParamsFromError extracts the offending fields from errors returned by
+// ShouldBindJSON, ShouldBindQuery and friends.
+// It returns nil if the error does not describe invalid fields.
+func InvalidParamsFromError(err error) []InvalidParam {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		params := make([]InvalidParam, 0, len(validationErrors))
+		for _, fieldError := range validationErrors {
+			reason := fieldError.Tag()
+			if fieldError.Param() != "" {
+				reason += "=" + fieldError.Param()
+			}
+			params = append(params, InvalidParam{Name: fieldError.Field(), Reason: reason})
+		}
+		return params
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		return []InvalidParam{{Name: typeError.Field, Reason: "must be of type " + typeError.Type.String()}}
+	}
+
+	var syntaxError *searchquery.SyntaxError
+	if errors.As(err, &syntaxError) {
+		return []InvalidParam{{Name: "query", Reason: syntaxError.Message, Position: &syntaxError.Position}}
+	}
+	return nil
+}
+
+// problemTitle returns the title of a problem with the given status code
+func problemTitle(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	if title := http.StatusText(status); title != "" {
+		return title
+	}
+	return "Error"
+}
+
+// wantsLegacyError reports whether the client explicitly asked for plain JSON
+// and therefore gets the legacy {"error", "code"} body instead of problem details.
+func wantsLegacyError(c *gin.Context) bool {
+	return c.NegotiateFormat(MIMEProblemJSON, gin.MIMEJSON) == gin.MIMEJSON
+}
@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SharePasswordHeader carries the password of a protected share link
+const SharePasswordHeader = "X-Share-Password"
+
+// PublicNoteReply is the view of a note shown to visitors of a share link.
+// It leaves out everything about the author and other users.
+type PublicNoteReply struct {
+	Title     string    `json:"title" example:"My Note"`
+	Content   string    `json:"content" example:"This is the content of my note."`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// markdown renders note content. Raw HTML and dangerous link targets are
+// escaped, since the content is shown to anonymous visitors.
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+var publicNoteTemplate = template.Must(template.New("public_note").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{if .Title}}{{.Title}}{{else}}WerSu{{end}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; line-height: 1.5; }
+pre, code { background: #f4f4f4; border-radius: 4px; }
+pre { padding: .75rem; overflow-x: auto; }
+img { max-width: 100%; }
+footer, .error { color: #666; }
+</style>
+</head>
+<body>
+{{- if not .UpdatedAt.IsZero}}
+<h1>{{.Title}}</h1>
+<article>{{.Content}}</article>
+<footer>Last updated {{.UpdatedAt.Format "2006-01-02 15:04 MST"}}</footer>
+{{- else if .PasswordRequired}}
+<form method="post">
+<p>This note is protected by a password.</p>
+{{- if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<input type="password" name="password" autofocus required>
+<button type="submit">Open</button>
+</form>
+{{- else}}
+<p class="error">{{.Error}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// publicNotePage is the data of publicNoteTemplate
+type publicNotePage struct {
+	Title            string
+	Content          template.HTML
+	UpdatedAt        time.Time
+	PasswordRequired bool
+	Error            string
+}
+
+// GetPublicNote godoc
+// @Summary View a shared note
+// @Description Shows a note via a share link without logging in. Returns JSON or, if the client prefers it, rendered HTML.
+// @Description Password protected links expect the password in the X-Share-Password header.
+// @Tags public
+// @Produce json,html
+// @Param token path string true "Share link token"
+// @Param X-Share-Password header string false "Password of the share link"
+// @Success 200 {object} PublicNoteReply
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Failure 410 {object} ProblemDetails
+// @Failure 429 {object} ProblemDetails "Too many wrong passwords"
+// @Router /public/notes/{token} [get]
+func (sc *ShareLinkController) GetPublicNote(c *gin.Context) {
+	sc.showPublicNote(c, c.GetHeader(SharePasswordHeader))
+}
+
+// PostPublicNote godoc
+// @Summary Unlock a shared note
+// @Description Same as GET, but reads the password from a form field. Used by the password form of the HTML view.
+// @Tags public
+// @Accept x-www-form-urlencoded
+// @Produce json,html
+// @Param token path string true "Share link token"
+// @Param password formData string true "Password of the share link"
+// @Success 200 {object} PublicNoteReply
+// @Failure 401 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Failure 410 {object} ProblemDetails
+// @Failure 429 {object} ProblemDetails "Too many wrong passwords"
+// @Router /public/notes/{token} [post]
+func (sc *ShareLinkController) PostPublicNote(c *gin.Context) {
+	sc.showPublicNote(c, c.PostForm("password"))
+}
+
+func (sc *ShareLinkController) showPublicNote(c *gin.Context, password string) {
+	// the token is part of the URL, so keep it out of caches, search engines
+	// and referrers
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("X-Robots-Tag", "noindex")
+	html := c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML
+
+	// gRPC service call
+	response, err := (*sc.NoteService).GetNoteByShareLink(c, &proto.GetNoteByShareLinkRequest{
+		TokenHash: hashToken(c.Params.ByName("token")),
+	})
+	if status.Code(err) == codes.NotFound {
+		publicNoteError(c, html, http.StatusNotFound, "This link does not exist or was revoked.", errors.New("share link not found"))
+		return
+	}
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch shared note via gRPC service: %w", err))
+		return
+	}
+	link := response.Link
+	if link.ExpiresAt != nil && link.ExpiresAt.AsTime().Before(time.Now()) {
+		publicNoteError(c, html, http.StatusGone, "This link has expired.", errors.New("share link expired"))
+		return
+	}
+
+	// check password
+	if response.PasswordHash != nil {
+		if password == "" {
+			publicNotePasswordError(c, html, "", errors.New("this link requires a password"))
+			return
+		}
+		// limit guessing. Blocked attempts aren't checked, since bcrypt is slow
+		linkKey := strconv.Itoa(int(link.Id))
+		retryAfter := max(sc.LinkAttempts.RetryAfter(linkKey), sc.IPAttempts.RetryAfter(c.ClientIP()))
+		if retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			publicNoteError(c, html, http.StatusTooManyRequests, "Too many wrong passwords, try again later.", errors.New("too many wrong passwords"))
+			return
+		}
+		if bcrypt.CompareHashAndPassword(response.PasswordHash, []byte(password)) != nil {
+			sc.LinkAttempts.Add(linkKey)
+			sc.IPAttempts.Add(c.ClientIP())
+			publicNotePasswordError(c, html, "Wrong password.", errors.New("wrong password"))
+			return
+		}
+	}
+
+	// count the access. A failure here shouldn't keep visitors from the note
+	_, err = (*sc.NoteService).RecordShareLinkAccess(c, &proto.RecordShareLinkAccessRequest{Id: link.Id})
+	if err != nil {
+		log.Printf("Failed to record access of share link %d: %v", link.Id, err)
+	}
+
+	note := response.Note
+	if !html {
+		c.JSON(http.StatusOK, PublicNoteReply{
+			Title:     note.Title,
+			Content:   note.Content,
+			UpdatedAt: note.UpdatedAt.AsTime(),
+		})
+		return
+	}
+
+	var content bytes.Buffer
+	if err := markdown.Convert([]byte(note.Content), &content); err != nil {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to render note: %w", err))
+		return
+	}
+	renderPublicNote(c, http.StatusOK, publicNotePage{
+		Title:     note.Title,
+		Content:   template.HTML(content.String()),
+		UpdatedAt: note.UpdatedAt.AsTime(),
+	})
+}
+
+// publicNoteError responds with an error page to HTML clients and with
+// problem details to everyone else
+func publicNoteError(c *gin.Context, html bool, code int, message string, err error) {
+	if !html {
+		SetGinError(c, code, err)
+		return
+	}
+	c.Error(err)
+	renderPublicNote(c, code, publicNotePage{Error: message})
+}
+
+// publicNotePasswordError asks HTML clients for the password of a share link
+func publicNotePasswordError(c *gin.Context, html bool, message string, err error) {
+	if !html {
+		SetGinError(c, http.StatusUnauthorized, err)
+		return
+	}
+	c.Error(err)
+	renderPublicNote(c, http.StatusUnauthorized, publicNotePage{PasswordRequired: true, Error: message})
+}
+
+func renderPublicNote(c *gin.Context, code int, page publicNotePage) {
+	// rendered notes may contain images, but never scripts
+	c.Header("Content-Security-Policy", "default-src 'none'; img-src https: data:; style-src 'unsafe-inline'; form-action 'self'")
+	c.Header("Content-Type", gin.MIMEHTML+"; charset=utf-8")
+	c.Status(code)
+	if err := publicNoteTemplate.Execute(c.Writer, page); err != nil {
+		log.Printf("Failed to render public note: %v", err)
+	}
+}
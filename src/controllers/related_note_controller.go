@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRelatedLimit is the number of related notes if no limit is given
+const DefaultRelatedLimit = 10
+
+type GetRelatedNotesRequest struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=50" example:"10"`
+	// only return notes with at least this score
+	MinScore float64 `form:"min_score" binding:"omitempty,min=0,max=1" example:"0.5"`
+}
+
+// RelatedNote is a note similar to another one
+type RelatedNote struct {
+	MinimalNote
+	// cosine similarity of the embeddings, 1 for equal ones
+	Score float64 `json:"score" example:"0.83"`
+}
+
+// GetRelatedNotes godoc
+// @Summary List notes related to a note
+// @Description Lists the notes most similar to a note, most similar first. Notes are compared by their embeddings,
+// @Description which are also used by the context search. Only notes the logged in user can read are returned.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param limit query int false "Maximum results to return" default(10) maximum(50)
+// @Param min_score query number false "Minimum similarity between 0 and 1"
+// @Success 200 {object} []RelatedNote
+// @Failure 400 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Failure 409 {object} ProblemDetails "The embedding of the note wasn't computed yet"
+// @Router /notes/{id}/related [get]
+func (uc *NoteController) GetRelatedNotes(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// parse query
+	var getRelatedNotesRequest GetRelatedNotesRequest
+	if err := c.ShouldBindQuery(&getRelatedNotesRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
+		return
+	}
+	if getRelatedNotesRequest.Limit == 0 {
+		getRelatedNotesRequest.Limit = DefaultRelatedLimit
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, uc.NoteService, int32(id), user, models.AccessRead); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	response, err := (*uc.NoteService).GetRelatedNotes(c, &proto.GetRelatedNotesRequest{
+		NoteId:   int32(id),
+		Limit:    getRelatedNotesRequest.Limit,
+		UserId:   user.ID,
+		MinScore: getRelatedNotesRequest.MinScore,
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		SetGinError(c, http.StatusConflict, fmt.Errorf("the embedding of note %d wasn't computed yet, try again later: %w", id, err))
+		return
+	}
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch related notes via gRPC service: %w", err))
+		return
+	}
+
+	notes := []RelatedNote{}
+	for _, related := range response.Notes {
+		notes = append(notes, RelatedNote{
+			MinimalNote: ConvertProtoMinimalNoteToRest(related.Note, user.ID),
+			Score:       related.Score,
+		})
+	}
+	c.JSON(http.StatusOK, notes)
+}
@@ -0,0 +1,465 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// SavedSearchController handles the searches users store to run them again
+type SavedSearchController struct {
+	NoteService *proto.NoteServiceClient
+	// runs the saved searches
+	Searches *SearchNotesController
+}
+
+func NewSavedSearchController(noteService *proto.NoteServiceClient, searches *SearchNotesController) *SavedSearchController {
+	return &SavedSearchController{NoteService: noteService, Searches: searches}
+}
+
+type SavedSearchReply struct {
+	Id         int32      `json:"id" example:"5"`
+	Name       string     `json:"name" example:"Open infra notes"`
+	SearchType SearchType `json:"search_type" example:"keyword"`
+	// the query as saved, including filters like tag:infra
+	Query      string   `json:"query" example:"tag:infra -draft"`
+	Tags       []string `json:"tags" example:"work"`
+	NotebookId *int32   `json:"notebook_id" example:"3"`
+	Recursive  bool     `json:"recursive" example:"true"`
+	Weights    []string `json:"weights" example:"context:2"`
+	// whether the search is shown as smart notebook in the notebook tree
+	Pinned    bool      `json:"pinned" example:"true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PostSavedSearchRequest contains a search like the parameters of
+// /notes/search, without the ones selecting the page
+type PostSavedSearchRequest struct {
+	Name       string     `json:"name" binding:"required,max=100" example:"Open infra notes"`
+	SearchType SearchType `json:"search_type" binding:"required,oneof=context keyword typo_tolerant latest hybrid" example:"keyword"`
+	Query      string     `json:"query" binding:"omitempty,max=1000" example:"tag:infra -draft"`
+	Tags       []string   `json:"tags" binding:"omitempty,max=20" example:"work"`
+	NotebookId *int32     `json:"notebook_id" binding:"omitempty,min=1" example:"3"`
+	// whether notes in sub notebooks of notebook_id are included, defaults to true
+	Recursive *bool    `json:"recursive" binding:"omitempty" example:"true"`
+	Weights   []string `json:"weights" binding:"omitempty,max=3" example:"context:2"`
+	Pinned    bool     `json:"pinned" example:"true"`
+}
+
+// PatchSavedSearchRequest contains the fields of a saved search which should
+// be changed. Fields which are omitted are left untouched.
+type PatchSavedSearchRequest struct {
+	Name       *string     `json:"name" binding:"omitempty,min=1,max=100" example:"Open infra notes"`
+	SearchType *SearchType `json:"search_type" binding:"omitempty,oneof=context keyword typo_tolerant latest hybrid" example:"hybrid"`
+	Query      *string     `json:"query" binding:"omitempty,max=1000" example:"tag:infra -draft"`
+	// replaces all tags
+	Tags *[]string `json:"tags" binding:"omitempty,max=20" example:"work"`
+	// 0 removes the notebook filter
+	NotebookId *int32 `json:"notebook_id" binding:"omitempty,min=0" example:"3"`
+	Recursive  *bool  `json:"recursive" binding:"omitempty" example:"true"`
+	// replaces all weights
+	Weights *[]string `json:"weights" binding:"omitempty,max=3" example:"context:2"`
+	Pinned  *bool     `json:"pinned" binding:"omitempty" example:"false"`
+}
+
+// SavedSearchReplyFromProto converts a protobuf SavedSearch message to a SavedSearchReply struct.
+func SavedSearchReplyFromProto(search *proto.SavedSearch) SavedSearchReply {
+	return SavedSearchReply{
+		Id:         search.Id,
+		Name:       search.Name,
+		SearchType: MapSearchTypeFromProto(search.SearchType),
+		Query:      search.Query,
+		Tags:       append([]string{}, search.Tags...),
+		NotebookId: search.NotebookId,
+		Recursive:  search.IncludeSubNotebooks,
+		Weights:    formatSearchWeights(search.Weights),
+		Pinned:     search.Pinned,
+		CreatedAt:  search.CreatedAt.AsTime(),
+		UpdatedAt:  search.UpdatedAt.AsTime(),
+	}
+}
+
+// formatSearchWeights formats weights as search_type:weight, the way they
+// are passed to /notes/search
+func formatSearchWeights(weights []*proto.SearchWeight) []string {
+	formatted := []string{}
+	for _, weight := range weights {
+		formatted = append(formatted, fmt.Sprintf("%s:%g", MapSearchTypeFromProto(weight.SearchType), weight.Weight))
+	}
+	return formatted
+}
+
+// savedSearchParams returns the parameters of /notes/search, which run the
+// saved search
+func savedSearchParams(search *proto.SavedSearch) GetSearchNotesRequest {
+	return GetSearchNotesRequest{
+		SearchType: MapSearchTypeFromProto(search.SearchType),
+		Query:      search.Query,
+		Tags:       search.Tags,
+		NotebookId: search.GetNotebookId(),
+		Recursive:  &search.IncludeSubNotebooks,
+		Weights:    formatSearchWeights(search.Weights),
+	}
+}
+
+// validateSavedSearch checks whether a saved search can be run, by building
+// the search request out of it. Notebook names in the query must exist
+// already. It returns the normalized tags and weights to store.
+//
+// Returns:
+//   - []string: The normalized tags
+//   - []*proto.SearchWeight: The parsed weights, empty for other than hybrid searches
+//   - int: HTTP status code (200 for success, 400 for invalid searches)
+//   - error: Error message if the search is invalid
+func (sc *SavedSearchController) validateSavedSearch(c *gin.Context, params GetSearchNotesRequest, userID int32) ([]string, []*proto.SearchWeight, int, error) {
+	if _, code, err := sc.Searches.buildSearchNotesRequest(c, &params, userID); err != nil {
+		return nil, nil, code, fmt.Errorf("invalid saved search: %w", err)
+	}
+	tags, err := models.NormalizeTags(params.Tags)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("invalid saved search: %w", err)
+	}
+	weights := []*proto.SearchWeight{}
+	if params.SearchType == SearchByHybrid {
+		// already checked while building the request
+		weights, _ = parseSearchWeights(params.Weights)
+	}
+	return tags, weights, http.StatusOK, nil
+}
+
+// GetSavedSearches godoc
+// @Summary List saved searches
+// @Description Lists the searches the logged in user saved.
+// @Tags searches
+// @Produce json
+// @Success 200 {object} []SavedSearchReply
+// @Failure 401 {object} ProblemDetails
+// @Router /searches [get]
+func (sc *SavedSearchController) GetSavedSearches(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*sc.NoteService).GetSavedSearches(c, &proto.GetSavedSearchesRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch saved searches via gRPC service: %w", err))
+		return
+	}
+
+	searches := []SavedSearchReply{}
+	for _, search := range response.Searches {
+		searches = append(searches, SavedSearchReplyFromProto(search))
+	}
+	c.JSON(http.StatusOK, searches)
+}
+
+// GetSavedSearch godoc
+// @Summary Get saved search by ID
+// @Tags searches
+// @Produce json
+// @Param id path int true "Saved search ID"
+// @Success 200 {object} SavedSearchReply
+// @Failure 404 {object} ProblemDetails
+// @Router /searches/{id} [get]
+func (sc *SavedSearchController) GetSavedSearch(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// gRPC service call
+	search, err := (*sc.NoteService).GetSavedSearch(c, &proto.GetSavedSearchRequest{Id: int32(id), UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch saved search via gRPC service: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, SavedSearchReplyFromProto(search))
+}
+
+// PostSavedSearch godoc
+// @Summary Save a search
+// @Description Saves a search to run it again via /searches/{id}/results. The fields are the same as the
+// @Description parameters of /notes/search and are validated the same way. Pinned searches are shown as
+// @Description smart notebooks in the notebook tree.
+// @Tags searches
+// @Accept json
+// @Produce json
+// @Param payload body PostSavedSearchRequest true "Search to save"
+// @Success 201 {object} SavedSearchReply
+// @Failure 400 {object} ProblemDetails
+// @Router /searches [post]
+func (sc *SavedSearchController) PostSavedSearch(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse request body
+	var postSavedSearchRequest PostSavedSearchRequest
+	if err := c.ShouldBindJSON(&postSavedSearchRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	recursive := postSavedSearchRequest.Recursive == nil || *postSavedSearchRequest.Recursive
+	params := GetSearchNotesRequest{
+		SearchType: postSavedSearchRequest.SearchType,
+		Query:      postSavedSearchRequest.Query,
+		Tags:       postSavedSearchRequest.Tags,
+		Recursive:  &recursive,
+		Weights:    postSavedSearchRequest.Weights,
+	}
+	if postSavedSearchRequest.NotebookId != nil {
+		params.NotebookId = *postSavedSearchRequest.NotebookId
+	}
+	tags, weights, code, err := sc.validateSavedSearch(c, params, user.ID)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	search, err := (*sc.NoteService).PostSavedSearch(c, &proto.PostSavedSearchRequest{
+		Name:                strings.TrimSpace(postSavedSearchRequest.Name),
+		SearchType:          MapSearchTypeToProto(postSavedSearchRequest.SearchType),
+		Query:               postSavedSearchRequest.Query,
+		Tags:                tags,
+		NotebookId:          postSavedSearchRequest.NotebookId,
+		IncludeSubNotebooks: recursive,
+		Weights:             weights,
+		Pinned:              postSavedSearchRequest.Pinned,
+		UserId:              user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to post saved search via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, SavedSearchReplyFromProto(search))
+}
+
+// PatchSavedSearch godoc
+// @Summary Update a saved search
+// @Description Changes the given fields of a saved search. The resulting search is validated like a new one.
+// @Tags searches
+// @Accept json
+// @Produce json
+// @Param id path int true "Saved search ID"
+// @Param payload body PatchSavedSearchRequest true "Fields to update"
+// @Success 200 {object} SavedSearchReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /searches/{id} [patch]
+func (sc *SavedSearchController) PatchSavedSearch(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// parse request body
+	var patchSavedSearchRequest PatchSavedSearchRequest
+	if err := c.ShouldBindJSON(&patchSavedSearchRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if patchSavedSearchRequest == (PatchSavedSearchRequest{}) {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: nothing to update"))
+		return
+	}
+
+	// fetch the saved search, to validate it with the changes applied
+	search, err := (*sc.NoteService).GetSavedSearch(c, &proto.GetSavedSearchRequest{Id: int32(id), UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch saved search via gRPC service: %w", err))
+		return
+	}
+	params := savedSearchParams(search)
+	grpcAlterSavedSearchRequest := proto.AlterSavedSearchRequest{
+		Id:     int32(id),
+		UserId: user.ID,
+	}
+	if patchSavedSearchRequest.Name != nil {
+		name := strings.TrimSpace(*patchSavedSearchRequest.Name)
+		grpcAlterSavedSearchRequest.Name = &name
+	}
+	if patchSavedSearchRequest.SearchType != nil {
+		params.SearchType = *patchSavedSearchRequest.SearchType
+		searchType := MapSearchTypeToProto(params.SearchType)
+		grpcAlterSavedSearchRequest.SearchType = &searchType
+	}
+	if patchSavedSearchRequest.Query != nil {
+		params.Query = *patchSavedSearchRequest.Query
+		grpcAlterSavedSearchRequest.Query = patchSavedSearchRequest.Query
+	}
+	if patchSavedSearchRequest.Tags != nil {
+		params.Tags = *patchSavedSearchRequest.Tags
+	}
+	if patchSavedSearchRequest.NotebookId != nil {
+		params.NotebookId = *patchSavedSearchRequest.NotebookId
+		grpcAlterSavedSearchRequest.NotebookId = patchSavedSearchRequest.NotebookId
+	}
+	if patchSavedSearchRequest.Recursive != nil {
+		params.Recursive = patchSavedSearchRequest.Recursive
+		grpcAlterSavedSearchRequest.IncludeSubNotebooks = patchSavedSearchRequest.Recursive
+	}
+	if patchSavedSearchRequest.Weights != nil {
+		params.Weights = *patchSavedSearchRequest.Weights
+	} else if params.SearchType != SearchByHybrid && len(params.Weights) > 0 {
+		// the weights are dropped when switching to a single search
+		params.Weights = nil
+		grpcAlterSavedSearchRequest.Weights = &proto.SearchWeightList{}
+	}
+	grpcAlterSavedSearchRequest.Pinned = patchSavedSearchRequest.Pinned
+
+	tags, weights, code, err := sc.validateSavedSearch(c, params, user.ID)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+	if patchSavedSearchRequest.Tags != nil {
+		grpcAlterSavedSearchRequest.Tags = &proto.TagList{Tags: tags}
+	}
+	if patchSavedSearchRequest.Weights != nil {
+		grpcAlterSavedSearchRequest.Weights = &proto.SearchWeightList{Weights: weights}
+	}
+
+	// gRPC service call
+	search, err = (*sc.NoteService).AlterSavedSearch(c, &grpcAlterSavedSearchRequest)
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to alter saved search via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, SavedSearchReplyFromProto(search))
+}
+
+// DeleteSavedSearch godoc
+// @Summary Delete a saved search
+// @Tags searches
+// @Param id path int true "Saved search ID"
+// @Success 204
+// @Failure 404 {object} ProblemDetails
+// @Router /searches/{id} [delete]
+func (sc *SavedSearchController) DeleteSavedSearch(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// gRPC service call
+	_, err = (*sc.NoteService).DeleteSavedSearch(c, &proto.DeleteSavedSearchRequest{
+		Id:     int32(id),
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to delete saved search via gRPC service: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetResults godoc
+// @Summary Run a saved search
+// @Description Runs a saved search and returns a page of its results, exactly like /notes/search with the
+// @Description saved parameters. Clients which send "Accept: text/event-stream" get the results as Server-Sent Events.
+// @Description Cursors become invalid when the saved search is changed.
+// @Tags searches
+// @Produce json
+// @Param id path int true "Saved search ID"
+// @Param highlight query bool false "Return fragments with the matched terms highlighted"
+// @Param fragment_size query int false "Characters per fragment" default(150) minimum(20) maximum(1000)
+// @Param fragment_count query int false "Maximum fragments per note" default(3) maximum(10)
+// @Param pre_tag query string false "Inserted before matched terms" default(<mark>)
+// @Param post_tag query string false "Inserted after matched terms" default(</mark>)
+// @Param encoder query string false "html escapes the text around the tags" Enums(html, none) default(html)
+// @Param limit query int false "Maximum results to return" default(20) maximum(100)
+// @Param cursor query string false "Cursor of the page to return"
+// @Param offset query int false "Pagination offset, superseded by cursor"
+// @Success 200 {object} SearchNotesPage
+// @Header 200 {string} Link "link to the next page"
+// @Failure 400 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /searches/{id}/results [get]
+func (sc *SavedSearchController) GetResults(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// parse query
+	var searchPageRequest SearchPageRequest
+	if err := c.ShouldBindQuery(&searchPageRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
+		return
+	}
+
+	// gRPC service call
+	search, err := (*sc.NoteService).GetSavedSearch(c, &proto.GetSavedSearchRequest{Id: int32(id), UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch saved search via gRPC service: %w", err))
+		return
+	}
+	params := savedSearchParams(search)
+	params.SearchPageRequest = searchPageRequest
+	grpcSearchNotesRequest, code, err := sc.Searches.buildSearchNotesRequest(c, &params, user.ID)
+	if err != nil {
+		// e.g. a notebook of the query was renamed
+		SetGinError(c, code, fmt.Errorf("failed to run saved search %d: %w", id, err))
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, MIMEEventStream) == MIMEEventStream {
+		sc.Searches.writeStream(c, grpcSearchNotesRequest)
+		return
+	}
+	sc.Searches.writePage(c, grpcSearchNotesRequest)
+}
@@ -1,23 +1,51 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
 	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/KuramaSyu/WerSu-Rest/src/suggest"
 	"github.com/gin-gonic/gin"
+	protobuf "google.golang.org/protobuf/proto"
 )
 
 // UserController handles user routes
 type SearchNotesController struct {
 	NoteService *proto.NoteServiceClient
+	Cursors     *CursorCodec
+	// queries of searches, which are suggested while typing
+	Recent *suggest.RecentQueries
 }
 
-func NewSearchNoteController(noteService *proto.NoteServiceClient) *SearchNotesController {
-	return &SearchNotesController{NoteService: noteService}
+func NewSearchNoteController(noteService *proto.NoteServiceClient, cursors *CursorCodec, recent *suggest.RecentQueries) *SearchNotesController {
+	return &SearchNotesController{NoteService: noteService, Cursors: cursors, Recent: recent}
 }
 
+const (
+	// number of notes returned, if the client doesn't set a limit
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// highlighting of search results, if the client doesn't configure it
+const (
+	DefaultFragmentSize  = 150
+	DefaultFragmentCount = 3
+	DefaultPreTag        = "<mark>"
+	DefaultPostTag       = "</mark>"
+)
+
+// encoders of highlighted text
+const (
+	HighlightEncoderHTML = "html" // the text is HTML escaped, the tags aren't
+	HighlightEncoderNone = "none"
+)
+
 type SearchType string
 
 const (
@@ -25,8 +53,13 @@ const (
 	SearchByKeyword      SearchType = "keyword"
 	SearchByTypoTolerant SearchType = "typo_tolerant"
 	SearchByLatest       SearchType = "latest"
+	// fuses the rankings of context, keyword and typo_tolerant
+	SearchByHybrid SearchType = "hybrid"
 )
 
+// HybridSearchTypes are the searches fused by a hybrid search
+var HybridSearchTypes = []SearchType{SearchByKeyword, SearchByTypoTolerant, SearchByContext}
+
 // maps the REST API SearchType to gRPC SearchType
 func MapSearchTypeToProto(searchType SearchType) proto.GetSearchNotesRequest_SearchType {
 	switch searchType {
@@ -38,94 +71,491 @@ func MapSearchTypeToProto(searchType SearchType) proto.GetSearchNotesRequest_Sea
 		return proto.GetSearchNotesRequest_Fuzzy
 	case SearchByLatest:
 		return proto.GetSearchNotesRequest_NoSearch
+	case SearchByHybrid:
+		return proto.GetSearchNotesRequest_Hybrid
 	default:
 		return proto.GetSearchNotesRequest_Context
 	}
 }
 
+// maps the gRPC SearchType to REST API SearchType
+func MapSearchTypeFromProto(searchType proto.GetSearchNotesRequest_SearchType) SearchType {
+	switch searchType {
+	case proto.GetSearchNotesRequest_FullTextTitle:
+		return SearchByKeyword
+	case proto.GetSearchNotesRequest_Fuzzy:
+		return SearchByTypoTolerant
+	case proto.GetSearchNotesRequest_NoSearch:
+		return SearchByLatest
+	case proto.GetSearchNotesRequest_Hybrid:
+		return SearchByHybrid
+	default:
+		return SearchByContext
+	}
+}
+
 type GetSearchNotesRequest struct {
 	// the algorithm used to perform the search
 	SearchType SearchType `form:"search_type" binding:"required" example:"context"`
 
-	// the query string to search for
+	// the query string to search for. It may contain filters like tag:infra,
+	// see searchquery.Parse
 	Query string `form:"query" binding:"omitempty" example:"Python programming"`
 
+	// only return notes having all of these tags
+	Tags []string `form:"tags" binding:"omitempty,max=20" example:"work"`
+
+	// only return notes inside this notebook
+	NotebookId int32 `form:"notebook_id" binding:"omitempty,min=1" example:"3"`
+	// whether notes in sub notebooks of notebook_id are included, defaults to true
+	Recursive *bool `form:"recursive" binding:"omitempty" example:"true"`
+
+	// weights of the searches fused by a hybrid search as search_type:weight,
+	// e.g. context:2. Searches which aren't listed get weight 1, weight 0 leaves them out
+	Weights []string `form:"weights" binding:"omitempty,max=3" example:"context:2"`
+
+	SearchPageRequest
+}
+
+// SearchPageRequest selects the page of search results to return and how
+// they are highlighted
+type SearchPageRequest struct {
 	// maximum number of results to return
-	Limit  int32 `form:"limit" binding:"omitempty" example:"10"`
-	Offset int32 `form:"offset" binding:"omitempty" example:"0"`
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100" example:"10"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0" example:"0"`
+
+	// return fragments of the content with the matched terms highlighted.
+	// The other highlight options are only used if this is set
+	Highlight     bool   `form:"highlight" binding:"omitempty" example:"true"`
+	FragmentSize  int32  `form:"fragment_size" binding:"omitempty,min=20,max=1000" example:"150"`
+	FragmentCount int32  `form:"fragment_count" binding:"omitempty,min=1,max=10" example:"3"`
+	PreTag        string `form:"pre_tag" binding:"omitempty,max=64" example:"<mark>"`
+	PostTag       string `form:"post_tag" binding:"omitempty,max=64" example:"</mark>"`
+	Encoder       string `form:"encoder" binding:"omitempty,oneof=html none" example:"html"`
+
+	// opaque cursor of the page to return, taken from next_cursor of the previous page
+	Cursor string `form:"cursor" binding:"omitempty" example:"eyJ0IjoxLCJxIjoiNDdERVFwajhIQlMiLCJ1IjoxNzY3MjI1NjAwMDAwMDAwMDAwLCJpIjo0Mn0.c2lnbmF0dXJl"`
+}
+
+// SearchNotesPage is one page of search results
+type SearchNotesPage struct {
+	Items []MinimalNote `json:"items"`
+
+	// cursor of the next page. Empty if there are no more results
+	NextCursor string `json:"next_cursor" example:"eyJ0IjoxLCJxIjoiNDdERVFwajhIQlMiLCJ1IjoxNzY3MjI1NjAwMDAwMDAwMDAwLCJpIjo0Mn0.c2lnbmF0dXJl"`
+	HasMore    bool   `json:"has_more" example:"true"`
 }
 
 type MinimalNote struct {
-	Id              int32  `json:"id"`
-	Title           string `json:"title"`
-	AuthorId        int32  `json:"author_id"`
-	UpdatedAt       string `json:"updated_at"` // ISO 8601 format
-	StrippedContent string `json:"stripped_content"`
+	Id              int32    `json:"id"`
+	Title           string   `json:"title"`
+	AuthorId        int32    `json:"author_id"`
+	UpdatedAt       string   `json:"updated_at"` // ISO 8601 format
+	StrippedContent string   `json:"stripped_content"`
+	Tags            []string `json:"tags" example:"work,ideas"`
+	NotebookId      *int32   `json:"notebook_id" example:"3"`
+
+	// access of the requesting user
+	AccessLevel models.AccessLevel `json:"access_level" example:"read"`
+
+	// relevance for the query, higher is better. Only set by ranked searches
+	Score *float64 `json:"score,omitempty" example:"0.032"`
+	// how the fused searches ranked the note, only set by hybrid searches
+	Matches []SearchMatch `json:"matches,omitempty"`
+
+	// title with the matched terms between the tags, only set if highlighting was requested
+	HighlightedTitle *string `json:"highlighted_title,omitempty" example:"Learning <mark>Python</mark>"`
+	// parts of the content which matched the query, best first. Only set if highlighting was requested
+	Fragments []Fragment `json:"fragments,omitempty"`
+}
+
+// Fragment is a part of the content of a note which matched the query.
+// Typo tolerant and context searches return the passage most similar to
+// the query, which may not contain any exact match.
+type Fragment struct {
+	Text string `json:"text" example:"… started with <mark>Python</mark> programming last year …"`
+	// position in the content in characters
+	Offset int32   `json:"offset" example:"120"`
+	Score  float64 `json:"score" example:"0.74"`
+}
+
+// SearchMatch reports how a single search ranked a result of a hybrid search
+type SearchMatch struct {
+	SearchType SearchType `json:"search_type" example:"context"`
+	// 1 for the best result
+	Rank  int32   `json:"rank" example:"1"`
+	Score float64 `json:"score" example:"0.87"`
+}
+
+// MIMEEventStream is the media type of Server-Sent Events
+const MIMEEventStream = "text/event-stream"
+
+// names of the Server-Sent Events sent while streaming search results
+const (
+	SearchEventNote = "note"
+	// terminal event. "error" is avoided, since EventSource reserves it for connection errors
+	SearchEventEnd = "end"
+)
+
+type SearchStreamStatus string
+
+const (
+	SearchStreamCompleted SearchStreamStatus = "completed"
+	SearchStreamFailed    SearchStreamStatus = "failed"
+)
+
+// SearchStreamEnd is the payload of the terminal event of a streamed search
+type SearchStreamEnd struct {
+	Status SearchStreamStatus `json:"status" example:"completed"`
+
+	// number of notes sent before the stream ended
+	Count int `json:"count" example:"10"`
+
+	// pagination, like in SearchNotesPage
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+
+	// the reason why the search failed
+	Error *ProblemDetails `json:"error,omitempty"`
 }
 
 // ConvertProtoMinimalNoteToRest converts a proto.MinimalNote to REST MinimalNote
-func ConvertProtoMinimalNoteToRest(protoNote *proto.MinimalNote) MinimalNote {
+// as seen by the user with the given ID
+func ConvertProtoMinimalNoteToRest(protoNote *proto.MinimalNote, userID int32) MinimalNote {
 	updatedAt := ""
 	if protoNote.UpdatedAt != nil {
 		updatedAt = protoNote.UpdatedAt.AsTime().Format(time.RFC3339)
 	}
 
-	return MinimalNote{
+	note := MinimalNote{
 		Id:              protoNote.Id,
 		Title:           protoNote.Title,
 		AuthorId:        protoNote.AuthorId,
 		UpdatedAt:       updatedAt,
 		StrippedContent: protoNote.StrippedContent,
+		Tags:            append([]string{}, protoNote.Tags...),
+		NotebookId:      protoNote.NotebookId,
+		AccessLevel:     AccessLevelFromProto(protoNote.AccessLevel),
+	}
+	if protoNote.AuthorId == userID {
+		note.AccessLevel = models.AccessOwner
+	}
+	if protoNote.Score != 0 {
+		note.Score = &protoNote.Score
+	}
+	note.HighlightedTitle = protoNote.HighlightedTitle
+	for _, fragment := range protoNote.Fragments {
+		note.Fragments = append(note.Fragments, Fragment{
+			Text:   fragment.Text,
+			Offset: fragment.Offset,
+			Score:  fragment.Score,
+		})
 	}
+	for _, match := range protoNote.Matches {
+		note.Matches = append(note.Matches, SearchMatch{
+			SearchType: MapSearchTypeFromProto(match.SearchType),
+			Rank:       match.Rank,
+			Score:      match.Score,
+		})
+	}
+	return note
 }
 
 // GetNote godoc
 // @Summary Get notes by search criteria
-// @Description Search notes via gRPC service
+// @Description Search notes via gRPC service. Results are paginated with cursors: pass next_cursor of a page
+// @Description as cursor to get the next page. The next page is also linked in the Link header.
+// @Description Clients which send "Accept: text/event-stream" get the results as Server-Sent Events, see /notes/search/stream.
+// @Description A hybrid search runs the context, keyword and typo_tolerant searches concurrently and fuses their results
+// @Description with weighted reciprocal rank fusion. Every result reports which searches found it, with rank and score.
+// @Description With highlight set, every result contains the fragments of its content which matched the query.
+// @Description The query may contain filters, which are removed from the text passed to the search algorithm:
+// @Description title:"phrase", tag:name, author:me or author:<user id>, notebook:<id> or notebook:"name" and
+// @Description updated:2026-01-31, updated:>2026-01-01 (also >=, <, <=) or updated:2026-01-01..2026-01-31 with dates in UTC.
+// @Description A leading "-" excludes a tag, word or "phrase". Syntax errors report their position in invalid_params.
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param search_type query string true "Search algorithm" Enums(context, keyword, typo_tolerant, latest)
-// @Param query query string true "Search query"
-// @Param limit query int true "Maximum results to return"
-// @Param offset query int true "Pagination offset"
-// @Success 200 {object} []MinimalNote
-// @Failure 400 {object} map[string]string
+// @Param search_type query string true "Search algorithm" Enums(context, keyword, typo_tolerant, latest, hybrid)
+// @Param query query string false "Search query with optional filters, e.g. sprint tag:infra updated:>2026-01-01 author:me -draft"
+// @Param tags query []string false "Only notes having all of these tags" collectionFormat(multi)
+// @Param notebook_id query int false "Only notes inside this notebook"
+// @Param recursive query bool false "Include notes of sub notebooks" default(true)
+// @Param weights query []string false "Weights of hybrid searches as search_type:weight" collectionFormat(multi)
+// @Param highlight query bool false "Return fragments with the matched terms highlighted"
+// @Param fragment_size query int false "Characters per fragment" default(150) minimum(20) maximum(1000)
+// @Param fragment_count query int false "Maximum fragments per note" default(3) maximum(10)
+// @Param pre_tag query string false "Inserted before matched terms" default(<mark>)
+// @Param post_tag query string false "Inserted after matched terms" default(</mark>)
+// @Param encoder query string false "html escapes the text around the tags" Enums(html, none) default(html)
+// @Param limit query int false "Maximum results to return" default(20) maximum(100)
+// @Param cursor query string false "Cursor of the page to return"
+// @Param offset query int false "Pagination offset, superseded by cursor"
+// @Success 200 {object} SearchNotesPage
+// @Header 200 {string} Link "link to the next page"
+// @Failure 400 {object} ProblemDetails
 // @Router /notes/search [get]
 func (uc *SearchNotesController) GetNotes(c *gin.Context) {
+	if c.NegotiateFormat(gin.MIMEJSON, MIMEEventStream) == MIMEEventStream {
+		uc.StreamNotes(c)
+		return
+	}
+
+	grpcSearchNotesRequest, code, err := uc.parseSearchNotesRequest(c)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+	uc.writePage(c, grpcSearchNotesRequest)
+}
+
+// StreamNotes godoc
+// @Summary Stream notes by search criteria
+// @Description Search notes via gRPC service and send every result as soon as it arrives, using Server-Sent Events.
+// @Description Each result is sent as a "note" event containing a MinimalNote. The stream is terminated by
+// @Description an "end" event containing a SearchStreamEnd, which reports whether the search completed or failed
+// @Description and the cursor of the next page.
+// @Description The query supports the same filters as /notes/search.
+// @Tags users
+// @Produce text/event-stream
+// @Param search_type query string true "Search algorithm" Enums(context, keyword, typo_tolerant, latest, hybrid)
+// @Param query query string false "Search query with optional filters, e.g. sprint tag:infra updated:>2026-01-01 author:me -draft"
+// @Param tags query []string false "Only notes having all of these tags" collectionFormat(multi)
+// @Param notebook_id query int false "Only notes inside this notebook"
+// @Param recursive query bool false "Include notes of sub notebooks" default(true)
+// @Param weights query []string false "Weights of hybrid searches as search_type:weight" collectionFormat(multi)
+// @Param highlight query bool false "Return fragments with the matched terms highlighted"
+// @Param fragment_size query int false "Characters per fragment" default(150) minimum(20) maximum(1000)
+// @Param fragment_count query int false "Maximum fragments per note" default(3) maximum(10)
+// @Param pre_tag query string false "Inserted before matched terms" default(<mark>)
+// @Param post_tag query string false "Inserted after matched terms" default(</mark>)
+// @Param encoder query string false "html escapes the text around the tags" Enums(html, none) default(html)
+// @Param limit query int false "Maximum results to return" default(20) maximum(100)
+// @Param cursor query string false "Cursor of the page to return"
+// @Param offset query int false "Pagination offset, superseded by cursor"
+// @Success 200 {object} MinimalNote
+// @Failure 400 {object} ProblemDetails
+// @Router /notes/search/stream [get]
+func (uc *SearchNotesController) StreamNotes(c *gin.Context) {
+	grpcSearchNotesRequest, code, err := uc.parseSearchNotesRequest(c)
+	if err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+	uc.writeStream(c, grpcSearchNotesRequest)
+}
+
+// writePage runs a search and responds with one page of its results
+func (uc *SearchNotesController) writePage(c *gin.Context, grpcSearchNotesRequest *proto.GetSearchNotesRequest) {
+	// call gRPC service
+	stream, err := uc.search(c, withLookahead(grpcSearchNotesRequest))
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to search notes via gRPC service: %w", err))
+		return
+	}
+
+	// collect all notes from stream
+	var notes []*proto.MinimalNote
+	for {
+		note, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			SetGrpcError(c, fmt.Errorf("failed to receive notes from gRPC service: %w", err))
+			return
+		}
+		notes = append(notes, note)
+	}
+
+	// split off the lookahead note
+	page := SearchNotesPage{Items: []MinimalNote{}}
+	if len(notes) > int(grpcSearchNotesRequest.Limit) {
+		notes = notes[:grpcSearchNotesRequest.Limit]
+		page.HasMore = true
+	}
+	for _, note := range notes {
+		page.Items = append(page.Items, ConvertProtoMinimalNoteToRest(note, grpcSearchNotesRequest.UserId))
+	}
+	if page.HasMore {
+		page.NextCursor, err = uc.Cursors.Encode(grpcSearchNotesRequest, notes[len(notes)-1].Cursor)
+		if err != nil {
+			SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to create cursor: %w", err))
+			return
+		}
+		c.Header("Link", nextPageLink(c, page.NextCursor))
+	}
+
+	// respond
+	c.JSON(http.StatusOK, page)
+}
+
+// writeStream runs a search and sends its results as Server-Sent Events
+func (uc *SearchNotesController) writeStream(c *gin.Context, grpcSearchNotesRequest *proto.GetSearchNotesRequest) {
+	// the gRPC stream is canceled as soon as the client disconnects
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	stream, err := uc.search(ctx, withLookahead(grpcSearchNotesRequest))
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to search notes via gRPC service: %w", err))
+		return
+	}
+
+	c.Header("Content-Type", MIMEEventStream)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // disable proxy buffering
+	c.Status(http.StatusOK)
+
+	// forward every note as soon as it arrives
+	var last *proto.MinimalNote
+	count := 0
+	c.Stream(func(w io.Writer) bool {
+		note, err := stream.Recv()
+		if err == io.EOF {
+			c.SSEvent(SearchEventEnd, SearchStreamEnd{Status: SearchStreamCompleted, Count: count})
+			return false
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				// client is gone, nobody to tell
+				return false
+			}
+			err = fmt.Errorf("failed to receive notes from gRPC service: %w", err)
+			problem := NewProblemDetails(c, HTTPStatusFromError(err), err)
+			c.SSEvent(SearchEventEnd, SearchStreamEnd{Status: SearchStreamFailed, Count: count, Error: &problem})
+			return false
+		}
+		if count == int(grpcSearchNotesRequest.Limit) {
+			// lookahead note: there is another page
+			end := SearchStreamEnd{Status: SearchStreamCompleted, Count: count, HasMore: true}
+			end.NextCursor, err = uc.Cursors.Encode(grpcSearchNotesRequest, last.Cursor)
+			if err != nil {
+				problem := NewProblemDetails(c, http.StatusInternalServerError, fmt.Errorf("failed to create cursor: %w", err))
+				end = SearchStreamEnd{Status: SearchStreamFailed, Count: count, Error: &problem}
+			}
+			c.SSEvent(SearchEventEnd, end)
+			return false
+		}
+		count++
+		last = note
+		c.SSEvent(SearchEventNote, ConvertProtoMinimalNoteToRest(note, grpcSearchNotesRequest.UserId))
+		return true
+	})
+}
+
+// parseSearchNotesRequest reads the user from the session and the search
+// parameters from the query, and builds the gRPC search request out of them.
+//
+// Returns:
+//   - *proto.GetSearchNotesRequest: The request for the gRPC service
+//   - int: HTTP status code (200 for success, 400 for invalid parameters, 401 for unauthorized)
+//   - error: Error message if the request can't be built
+func (uc *SearchNotesController) parseSearchNotesRequest(c *gin.Context) (*proto.GetSearchNotesRequest, int, error) {
 	// get user from session
 	user, code, err := UserFromSession(c)
 	if err != nil {
-		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
-		return
+		return nil, code, fmt.Errorf("not logged in: %w", err)
 	}
 
 	// read query parameters
 	var getSearchNotesRequest GetSearchNotesRequest
 	if err := c.ShouldBindQuery(&getSearchNotesRequest); err != nil {
-		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
-		return
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err)
+	}
+	grpcSearchNotesRequest, code, err := uc.buildSearchNotesRequest(c, &getSearchNotesRequest, user.ID)
+	if err != nil {
+		return nil, code, err
 	}
 
-	// call gRPC service
-	grpcSearchNotesRequest := proto.GetSearchNotesRequest{
+	// remember the query, but not once per page
+	if getSearchNotesRequest.Cursor == "" && getSearchNotesRequest.Offset == 0 {
+		uc.Recent.Add(user.ID, getSearchNotesRequest.Query)
+	}
+	return grpcSearchNotesRequest, http.StatusOK, nil
+}
+
+// buildSearchNotesRequest validates the parameters of a search of the user
+// with the given ID and builds the gRPC search request out of them.
+//
+// Returns:
+//   - *proto.GetSearchNotesRequest: The request for the gRPC service
+//   - int: HTTP status code (200 for success, 400 for invalid parameters)
+//   - error: Error message if the request can't be built
+func (uc *SearchNotesController) buildSearchNotesRequest(ctx context.Context, getSearchNotesRequest *GetSearchNotesRequest, userID int32) (*proto.GetSearchNotesRequest, int, error) {
+	if getSearchNotesRequest.Limit == 0 {
+		getSearchNotesRequest.Limit = DefaultSearchLimit
+	}
+	grpcSearchNotesRequest := &proto.GetSearchNotesRequest{
 		SearchType: MapSearchTypeToProto(getSearchNotesRequest.SearchType),
-		Query:      getSearchNotesRequest.Query,
 		Limit:      getSearchNotesRequest.Limit,
 		Offset:     getSearchNotesRequest.Offset,
-		UserId:     user.ID,
+		UserId:     userID,
 	}
-	stream, err := (*uc.NoteService).SearchNotes(c, &grpcSearchNotesRequest)
-	// collect all notes from stream
-	var notes []MinimalNote = []MinimalNote{}
-	for {
-		note, err := stream.Recv()
+	if code, err := uc.applySearchQuery(ctx, grpcSearchNotesRequest, getSearchNotesRequest); err != nil {
+		return nil, code, err
+	}
+	if getSearchNotesRequest.SearchType == SearchByHybrid {
+		if grpcSearchNotesRequest.Query == "" {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid query parameters: a hybrid search needs a query besides filters")
+		}
+		weights, err := parseSearchWeights(getSearchNotesRequest.Weights)
 		if err != nil {
-			break
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err)
+		}
+		grpcSearchNotesRequest.Weights = weights
+	} else if len(getSearchNotesRequest.Weights) > 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid query parameters: weights are only used by hybrid searches")
+	}
+	if getSearchNotesRequest.Highlight {
+		grpcSearchNotesRequest.Highlight = highlightOptions(&getSearchNotesRequest.SearchPageRequest)
+	}
+	if getSearchNotesRequest.Cursor != "" {
+		if err := uc.Cursors.Decode(getSearchNotesRequest.Cursor, grpcSearchNotesRequest); err != nil {
+			return nil, http.StatusBadRequest, err
 		}
-		notes = append(notes, ConvertProtoMinimalNoteToRest(note))
 	}
+	return grpcSearchNotesRequest, http.StatusOK, nil
+}
 
-	// respond
-	c.JSON(http.StatusOK, notes)
+// highlightOptions returns the highlight options of a search request, with
+// defaults for the options the client didn't set
+func highlightOptions(req *SearchPageRequest) *proto.HighlightOptions {
+	options := &proto.HighlightOptions{
+		FragmentSize:  req.FragmentSize,
+		FragmentCount: req.FragmentCount,
+		PreTag:        req.PreTag,
+		PostTag:       req.PostTag,
+		EscapeHtml:    req.Encoder != HighlightEncoderNone,
+	}
+	if options.FragmentSize == 0 {
+		options.FragmentSize = DefaultFragmentSize
+	}
+	if options.FragmentCount == 0 {
+		options.FragmentCount = DefaultFragmentCount
+	}
+	if options.PreTag == "" && options.PostTag == "" {
+		options.PreTag, options.PostTag = DefaultPreTag, DefaultPostTag
+	}
+	return options
+}
+
+// withLookahead returns a copy of the search request, which asks for one
+// more note than requested. If this note arrives, there is another page.
+func withLookahead(req *proto.GetSearchNotesRequest) *proto.GetSearchNotesRequest {
+	lookahead := protobuf.Clone(req).(*proto.GetSearchNotesRequest)
+	lookahead.Limit++
+	return lookahead
+}
+
+// nextPageLink returns the value of a Link header pointing to the next page
+// of the current request.
+func nextPageLink(c *gin.Context, cursor string) string {
+	next := *c.Request.URL
+	query := next.Query()
+	query.Set("cursor", cursor)
+	query.Del("offset")
+	next.RawQuery = query.Encode()
+	return fmt.Sprintf("<%s>; rel=\"next\"", next.RequestURI())
 }
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/KuramaSyu/WerSu-Rest/src/searchquery"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// applySearchQuery parses the query of a search and moves its filters into
+// the gRPC search request. Only the free text is left as query for the search
+// algorithm. Filters given as query parameters are combined with those of the
+// query.
+//
+// Returns:
+//   - int: HTTP status code (200 for success, 400 for invalid queries)
+//   - error: Error message if the query can't be applied
+func (uc *SearchNotesController) applySearchQuery(ctx context.Context, req *proto.GetSearchNotesRequest, params *GetSearchNotesRequest) (int, error) {
+	query, err := searchquery.Parse(params.Query, req.UserId)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err)
+	}
+	req.Query = query.Text
+
+	tags, err := models.NormalizeTags(append(slices.Clone(params.Tags), query.Tags...))
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err)
+	}
+	// the order doesn't matter, but cursors compare them
+	slices.Sort(tags)
+	slices.Sort(query.ExcludedTags)
+	slices.Sort(query.AuthorIds)
+	req.Tags = tags
+	req.ExcludedTags = query.ExcludedTags
+	req.AuthorIds = query.AuthorIds
+	req.TitlePhrases = query.Titles
+	req.ExcludedTerms = query.ExcludedTerms
+	if query.UpdatedAfter != nil {
+		req.UpdatedAfter = timestamppb.New(*query.UpdatedAfter)
+	}
+	if query.UpdatedBefore != nil {
+		req.UpdatedBefore = timestamppb.New(*query.UpdatedBefore)
+	}
+
+	notebookID := params.NotebookId
+	if query.Notebook != nil {
+		if notebookID != 0 {
+			return http.StatusBadRequest, fmt.Errorf("invalid query parameters: notebook_id can't be combined with a notebook filter in the query")
+		}
+		notebookID = query.Notebook.Id
+		if notebookID == 0 {
+			var code int
+			notebookID, code, err = uc.findNotebook(ctx, query.Notebook, req.UserId)
+			if err != nil {
+				return code, err
+			}
+		}
+	}
+	if notebookID != 0 {
+		req.NotebookId = &notebookID
+		req.IncludeSubNotebooks = params.Recursive == nil || *params.Recursive
+	}
+	return http.StatusOK, nil
+}
+
+// findNotebook returns the ID of the notebook, which the user can access and
+// whose name matches the filter ignoring case
+//
+// Returns:
+//   - int32: The ID of the notebook
+//   - int: HTTP status code (200 for success, 400 if no or several notebooks match)
+//   - error: Error message if the notebook can't be found
+func (uc *SearchNotesController) findNotebook(ctx context.Context, filter *searchquery.NotebookFilter, userID int32) (int32, int, error) {
+	response, err := (*uc.NoteService).GetNotebooks(ctx, &proto.GetNotebooksRequest{UserId: userID})
+	if err != nil {
+		return 0, HTTPStatusFromError(err), fmt.Errorf("failed to fetch notebooks via gRPC service: %w", err)
+	}
+
+	var found []int32
+	for _, notebook := range response.Notebooks {
+		if strings.EqualFold(notebook.Name, filter.Name) {
+			found = append(found, notebook.Id)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return 0, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", &searchquery.SyntaxError{
+			Position: filter.Position,
+			Message:  fmt.Sprintf("no notebook is named %q", filter.Name),
+		})
+	case 1:
+		return found[0], http.StatusOK, nil
+	default:
+		return 0, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", &searchquery.SyntaxError{
+			Position: filter.Position,
+			Message:  fmt.Sprintf("%d notebooks are named %q, use the ID instead", len(found), filter.Name),
+		})
+	}
+}
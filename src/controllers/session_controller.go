@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/sessionstore"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// SessionController lists and revokes the login sessions of a user
+type SessionController struct {
+	Sessions *sessionstore.Store
+}
+
+func NewSessionController(store *sessionstore.Store) *SessionController {
+	return &SessionController{Sessions: store}
+}
+
+type SessionReply struct {
+	Id string `json:"id" example:"3f1c9a0d5e7b2c4a8d6e0f1b"`
+	// whether this is the session of the request
+	Current    bool      `json:"current"`
+	Device     string    `json:"device" example:"Firefox on Linux"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip" example:"203.0.113.7"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// SessionReplyFromRecord converts a session record to a SessionReply struct.
+func SessionReplyFromRecord(record *sessionstore.Record, currentID string) SessionReply {
+	return SessionReply{
+		Id:         record.PublicID(),
+		Current:    record.ID == currentID,
+		Device:     describeUserAgent(record.UserAgent),
+		UserAgent:  record.UserAgent,
+		IP:         record.IP,
+		CreatedAt:  record.CreatedAt,
+		LastSeenAt: record.LastSeenAt,
+		ExpiresAt:  record.ExpiresAt,
+	}
+}
+
+// GetSessions godoc
+// @Summary List login sessions
+// @Description Lists the active login sessions of the logged in user, most recently used first. Requires a browser session.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} []SessionReply
+// @Failure 401 {object} ProblemDetails
+// @Router /auth/sessions [get]
+func (sc *SessionController) GetSessions(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	records, err := sc.Sessions.ListByUser(user.ID)
+	if err != nil {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to list sessions: %w", err))
+		return
+	}
+	slices.SortFunc(records, func(a, b *sessionstore.Record) int {
+		return b.LastSeenAt.Compare(a.LastSeenAt)
+	})
+
+	currentID := sessions.Default(c).ID()
+	reply := []SessionReply{}
+	for _, record := range records {
+		reply = append(reply, SessionReplyFromRecord(record, currentID))
+	}
+	c.JSON(http.StatusOK, reply)
+}
+
+// DeleteSession godoc
+// @Summary Revoke a login session
+// @Description Logs out the client using the session. Revoking the current session is the same as logging out.
+// @Description Requires a browser session.
+// @Tags auth
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 404 {object} ProblemDetails
+// @Router /auth/sessions/{id} [delete]
+func (sc *SessionController) DeleteSession(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	publicID := c.Params.ByName("id")
+
+	// only sessions of the user can be revoked
+	records, err := sc.Sessions.ListByUser(user.ID)
+	if err != nil {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to list sessions: %w", err))
+		return
+	}
+	index := slices.IndexFunc(records, func(record *sessionstore.Record) bool {
+		return record.PublicID() == publicID
+	})
+	if index < 0 {
+		SetGinError(c, http.StatusNotFound, fmt.Errorf("session %s not found", publicID))
+		return
+	}
+
+	if err := sc.Sessions.Revoke(records[index].ID); err != nil {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to revoke session: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// describeUserAgent returns a short, human readable description like
+// "Firefox on Linux". Unknown parts are left out.
+func describeUserAgent(userAgent string) string {
+	browser := firstMatch(userAgent, []string{"Edg/:Edge", "OPR/:Opera", "Firefox/:Firefox", "Chrome/:Chrome", "Safari/:Safari", "curl/:curl"})
+	system := firstMatch(userAgent, []string{"Android:Android", "iPhone:iOS", "iPad:iPadOS", "Windows:Windows", "Mac OS X:macOS", "Linux:Linux"})
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		return "Unknown device"
+	}
+}
+
+// firstMatch returns the name of the first "token:name" pair whose token is
+// contained in s
+func firstMatch(s string, pairs []string) string {
+	for _, pair := range pairs {
+		token, name, _ := strings.Cut(pair, ":")
+		if strings.Contains(s, token) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/KuramaSyu/WerSu-Rest/src/ratelimit"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ShareLinkController handles public read-only links to notes
+type ShareLinkController struct {
+	NoteService *proto.NoteServiceClient
+	// wrong passwords per share link and per client IP
+	LinkAttempts *ratelimit.Limiter
+	IPAttempts   *ratelimit.Limiter
+}
+
+// Wrong passwords allowed within SharePasswordWindow, before further attempts
+// are rejected without checking them
+const (
+	SharePasswordAttemptsPerLink = 10
+	SharePasswordAttemptsPerIP   = 20
+	SharePasswordWindow          = 15 * time.Minute
+)
+
+func NewShareLinkController(noteService *proto.NoteServiceClient) *ShareLinkController {
+	return &ShareLinkController{
+		NoteService:  noteService,
+		LinkAttempts: ratelimit.NewLimiter(SharePasswordAttemptsPerLink, SharePasswordWindow),
+		IPAttempts:   ratelimit.NewLimiter(SharePasswordAttemptsPerIP, SharePasswordWindow),
+	}
+}
+
+type PostShareLinkRequest struct {
+	// optional point in time when the link stops working
+	ExpiresAt *time.Time `json:"expires_at" binding:"omitempty" example:"2027-01-01T00:00:00Z"`
+
+	// optional password visitors have to enter
+	Password string `json:"password" binding:"omitempty,min=4,max=72" example:"correct horse"`
+}
+
+type ShareLinkReply struct {
+	Id             int32      `json:"id"`
+	NoteId         int32      `json:"note_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	HasPassword    bool       `json:"has_password"`
+	AccessCount    int64      `json:"access_count"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+}
+
+// PostShareLinkReply is returned when a link is created. It is the only time
+// the token itself is shown.
+type PostShareLinkReply struct {
+	ShareLinkReply
+	Token string `json:"token" example:"Xk2v..."`
+	URL   string `json:"url" example:"http://localhost:8080/api/public/notes/Xk2v..."`
+}
+
+// ShareLinkReplyFromProto converts a protobuf ShareLink message to a ShareLinkReply struct.
+func ShareLinkReplyFromProto(link *proto.ShareLink) ShareLinkReply {
+	reply := ShareLinkReply{
+		Id:          link.Id,
+		NoteId:      link.NoteId,
+		CreatedAt:   link.CreatedAt.AsTime(),
+		HasPassword: link.HasPassword,
+		AccessCount: link.AccessCount,
+	}
+	if link.ExpiresAt != nil {
+		expiresAt := link.ExpiresAt.AsTime()
+		reply.ExpiresAt = &expiresAt
+	}
+	if link.LastAccessedAt != nil {
+		lastAccessedAt := link.LastAccessedAt.AsTime()
+		reply.LastAccessedAt = &lastAccessedAt
+	}
+	return reply
+}
+
+// publicNoteURL returns the URL under which a shared note can be viewed
+func publicNoteURL(token string) string {
+	return fmt.Sprintf("%s/api/public/notes/%s", strings.TrimSuffix(config.AppConfig.BackendURL, "/"), token)
+}
+
+// PostShareLink godoc
+// @Summary Create a share link
+// @Description Creates an unguessable link, which shows the note to anyone without logging in.
+// @Description The token is only returned once. Only the author may do this.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param payload body PostShareLinkRequest true "Link to create"
+// @Success 201 {object} PostShareLinkReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 403 {object} ProblemDetails
+// @Router /notes/{id}/share-links [post]
+func (sc *ShareLinkController) PostShareLink(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// parse request body
+	var postShareLinkRequest PostShareLinkRequest
+	if err := c.ShouldBindJSON(&postShareLinkRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if postShareLinkRequest.ExpiresAt != nil && postShareLinkRequest.ExpiresAt.Before(time.Now()) {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: expires_at lies in the past"))
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, sc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	token, err := generateToken("")
+	if err != nil {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to generate token: %w", err))
+		return
+	}
+
+	// gRPC service call
+	grpcPostShareLinkRequest := proto.PostShareLinkRequest{
+		NoteId:    int32(id),
+		TokenHash: hashToken(token),
+		UserId:    user.ID,
+	}
+	if postShareLinkRequest.ExpiresAt != nil {
+		grpcPostShareLinkRequest.ExpiresAt = timestamppb.New(*postShareLinkRequest.ExpiresAt)
+	}
+	if postShareLinkRequest.Password != "" {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(postShareLinkRequest.Password), bcrypt.DefaultCost)
+		if err != nil {
+			SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to hash password: %w", err))
+			return
+		}
+		grpcPostShareLinkRequest.PasswordHash = passwordHash
+	}
+	link, err := (*sc.NoteService).PostShareLink(c, &grpcPostShareLinkRequest)
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to post share link via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, PostShareLinkReply{
+		ShareLinkReply: ShareLinkReplyFromProto(link),
+		Token:          token,
+		URL:            publicNoteURL(token),
+	})
+}
+
+// GetShareLinks godoc
+// @Summary List share links
+// @Description Lists the share links of a note with their access counts. Only the author may do this.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} []ShareLinkReply
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/share-links [get]
+func (sc *ShareLinkController) GetShareLinks(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, sc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	response, err := (*sc.NoteService).GetShareLinks(c, &proto.GetShareLinksRequest{
+		NoteId: int32(id),
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch share links via gRPC service: %w", err))
+		return
+	}
+
+	links := []ShareLinkReply{}
+	for _, link := range response.Links {
+		links = append(links, ShareLinkReplyFromProto(link))
+	}
+	c.JSON(http.StatusOK, links)
+}
+
+// DeleteShareLink godoc
+// @Summary Revoke a share link
+// @Description Revokes a share link, so the note can no longer be viewed with it. Only the author may do this.
+// @Tags notes
+// @Param id path int true "Note ID"
+// @Param link_id path int true "Share link ID"
+// @Success 204
+// @Failure 403 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /notes/{id}/share-links/{link_id} [delete]
+func (sc *ShareLinkController) DeleteShareLink(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+	linkId, err := strconv.Atoi(c.Params.ByName("link_id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid link ID format: %w", err))
+		return
+	}
+
+	// check permissions
+	if _, code, err := fetchNoteWithAccess(c, sc.NoteService, int32(id), user, models.AccessOwner); err != nil {
+		SetGinError(c, code, err)
+		return
+	}
+
+	// gRPC service call
+	_, err = (*sc.NoteService).DeleteShareLink(c, &proto.DeleteShareLinkRequest{
+		Id:     int32(linkId),
+		NoteId: int32(id),
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to delete share link via gRPC service: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
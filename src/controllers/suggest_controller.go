@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/KuramaSyu/WerSu-Rest/src/searchquery"
+	"github.com/KuramaSyu/WerSu-Rest/src/suggest"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultSuggestLimit is the number of suggestions of every kind if no limit
+// is given
+const DefaultSuggestLimit = 5
+
+// SuggestController completes what the user types into the search box
+type SuggestController struct {
+	NoteService *proto.NoteServiceClient
+	Recent      *suggest.RecentQueries
+	// only the latest request of a client is answered
+	Debouncer *suggest.Debouncer
+	// how long the gRPC service is waited for. Suggestions which don't
+	// arrive in time are left out
+	Timeout time.Duration
+}
+
+func NewSuggestController(noteService *proto.NoteServiceClient, recent *suggest.RecentQueries, debounce time.Duration, timeout time.Duration) *SuggestController {
+	return &SuggestController{
+		NoteService: noteService,
+		Recent:      recent,
+		Debouncer:   suggest.NewDebouncer(debounce),
+		Timeout:     timeout,
+	}
+}
+
+type GetSuggestionsRequest struct {
+	// what the user typed so far
+	Prefix string `form:"prefix" binding:"required,max=200" example:"spr"`
+	// maximum number of suggestions of every kind
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=20" example:"5"`
+}
+
+// TitleSuggestion is a note whose title matches the prefix
+type TitleSuggestion struct {
+	NoteId int32  `json:"note_id" example:"42"`
+	Title  string `json:"title" example:"Sprint review"`
+}
+
+// SuggestionsReply contains the completions of a prefix
+type SuggestionsReply struct {
+	Titles []TitleSuggestion `json:"titles"`
+	// tags starting with the prefix. A leading "tag:" is ignored
+	Tags []TagReply `json:"tags"`
+	// queries of the user's latest searches starting with the prefix, latest first
+	RecentQueries []string `json:"recent_queries"`
+	// whether some suggestions are missing, because they didn't arrive in time
+	Partial bool `json:"partial" example:"false"`
+}
+
+// GetSuggestions godoc
+// @Summary Suggest completions while typing
+// @Description Suggests note titles, tags and recent search queries starting with the prefix. It is meant to be
+// @Description called on every keystroke: the request waits a short moment, and is canceled with status 499
+// @Description as soon as a newer request of the same session or token arrives, so only the latest one is answered.
+// @Description Suggestions which don't arrive within the latency budget are left out and partial is set.
+// @Tags notes
+// @Produce json
+// @Param prefix query string true "What the user typed so far"
+// @Param limit query int false "Maximum suggestions of every kind" default(5) maximum(20)
+// @Success 200 {object} SuggestionsReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 499 {object} ProblemDetails "Superseded by a newer request"
+// @Router /notes/suggest [get]
+func (sc *SuggestController) GetSuggestions(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse query
+	var getSuggestionsRequest GetSuggestionsRequest
+	if err := c.ShouldBindQuery(&getSuggestionsRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
+		return
+	}
+	if getSuggestionsRequest.Limit == 0 {
+		getSuggestionsRequest.Limit = DefaultSuggestLimit
+	}
+	prefix := strings.TrimSpace(getSuggestionsRequest.Prefix)
+	limit := getSuggestionsRequest.Limit
+
+	reply := SuggestionsReply{
+		Titles:        []TitleSuggestion{},
+		Tags:          []TagReply{},
+		RecentQueries: sc.Recent.Matching(user.ID, prefix, int(limit)),
+	}
+	err = sc.Debouncer.Do(c.Request.Context(), ClientKey(c), func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, sc.Timeout)
+		defer cancel()
+
+		// gRPC service calls
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var firstErr error
+		fail := func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if ctx.Err() == context.DeadlineExceeded {
+				reply.Partial = true
+				return
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			response, err := (*sc.NoteService).SuggestNoteTitles(ctx, &proto.SuggestNoteTitlesRequest{
+				Prefix: prefix,
+				Limit:  limit,
+				UserId: user.ID,
+			})
+			if err != nil {
+				fail(fmt.Errorf("failed to suggest titles via gRPC service: %w", err))
+				return
+			}
+			for _, suggestion := range response.Suggestions {
+				reply.Titles = append(reply.Titles, TitleSuggestion{NoteId: suggestion.NoteId, Title: suggestion.Title})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			// tags are lower case
+			tagPrefix := strings.ToLower(strings.TrimPrefix(prefix, searchquery.KeyTag+":"))
+			response, err := (*sc.NoteService).GetTags(ctx, &proto.GetTagsRequest{
+				UserId: user.ID,
+				Prefix: &tagPrefix,
+				Limit:  &limit,
+			})
+			if err != nil {
+				fail(fmt.Errorf("failed to fetch tags via gRPC service: %w", err))
+				return
+			}
+			// tags are listed most used first, which is kept
+			for _, tag := range response.Tags {
+				reply.Tags = append(reply.Tags, TagReplyFromProto(tag))
+			}
+		}()
+		wg.Wait()
+		return firstErr
+	})
+	if errors.Is(err, suggest.ErrSuperseded) {
+		SetGinError(c, StatusClientClosedRequest, fmt.Errorf("suggestions for %q canceled: %w", prefix, err))
+		return
+	}
+	if err != nil {
+		SetGrpcError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reply)
+}
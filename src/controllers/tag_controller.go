@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// TagController lets users organise the tags of their notes
+type TagController struct {
+	NoteService *proto.NoteServiceClient
+}
+
+func NewTagController(noteService *proto.NoteServiceClient) *TagController {
+	return &TagController{NoteService: noteService}
+}
+
+type TagReply struct {
+	Name string `json:"name" example:"work"`
+	// number of notes using the tag
+	NoteCount int32 `json:"note_count" example:"12"`
+}
+
+type PatchTagRequest struct {
+	Name string `json:"name" binding:"required" example:"job"`
+}
+
+type MergeTagsRequest struct {
+	// tags which are replaced by target
+	Sources []string `json:"sources" binding:"required,min=1,max=20" example:"todo,to-do"`
+	Target  string   `json:"target" binding:"required" example:"todo"`
+}
+
+// TagReplyFromProto converts a protobuf Tag message to a TagReply struct.
+func TagReplyFromProto(tag *proto.Tag) TagReply {
+	return TagReply{
+		Name:      tag.Name,
+		NoteCount: tag.NoteCount,
+	}
+}
+
+// GetTags godoc
+// @Summary List tags
+// @Description Lists the tags of all notes of the logged in user with the number of notes using them, most used first.
+// @Tags tags
+// @Produce json
+// @Success 200 {object} []TagReply
+// @Failure 401 {object} ProblemDetails
+// @Router /tags [get]
+func (tc *TagController) GetTags(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*tc.NoteService).GetTags(c, &proto.GetTagsRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch tags via gRPC service: %w", err))
+		return
+	}
+
+	tags := []TagReply{}
+	for _, tag := range response.Tags {
+		tags = append(tags, TagReplyFromProto(tag))
+	}
+	c.JSON(http.StatusOK, tags)
+}
+
+// PatchTag godoc
+// @Summary Rename a tag
+// @Description Renames a tag on all notes of the logged in user. Fails if the new name is used already, merge the tags instead.
+// @Description Tags containing slashes can be given as they are, e.g. PATCH /tags/projects/wersu.
+// @Description The ETag of every note having the tag changes, so clients holding an older copy can't overwrite the new tags.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param name path string true "Tag to rename"
+// @Param payload body PatchTagRequest true "New name"
+// @Success 200 {object} TagReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Failure 409 {object} ProblemDetails
+// @Router /tags/{name} [patch]
+func (tc *TagController) PatchTag(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path. The catch-all parameter starts with a slash
+	name, err := models.NormalizeTag(strings.TrimPrefix(c.Params.ByName("name"), "/"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// parse request body
+	var patchTagRequest PatchTagRequest
+	if err := c.ShouldBindJSON(&patchTagRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	newName, err := models.NormalizeTag(patchTagRequest.Name)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*tc.NoteService).RenameTag(c, &proto.RenameTagRequest{
+		Name:    name,
+		NewName: newName,
+		UserId:  user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to rename tag via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, TagReplyFromProto(response.Tag))
+}
+
+// MergeTags godoc
+// @Summary Merge tags
+// @Description Replaces the source tags with the target tag on all notes of the logged in user.
+// @Description The target may be a new or an existing tag.
+// @Description The ETag of every note having one of the source tags changes.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param payload body MergeTagsRequest true "Tags to merge"
+// @Success 200 {object} TagReply
+// @Failure 400 {object} ProblemDetails
+// @Router /tags/merge [post]
+func (tc *TagController) MergeTags(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse request body
+	var mergeTagsRequest MergeTagsRequest
+	if err := c.ShouldBindJSON(&mergeTagsRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	sources, err := models.NormalizeTags(mergeTagsRequest.Sources)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	target, err := models.NormalizeTag(mergeTagsRequest.Target)
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*tc.NoteService).MergeTags(c, &proto.MergeTagsRequest{
+		Sources: sources,
+		Target:  target,
+		UserId:  user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to merge tags via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, TagReplyFromProto(response.Tag))
+}
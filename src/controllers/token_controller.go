@@ -0,0 +1,326 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ApiTokenPrefix is prepended to every personal access token, so leaked
+// tokens are easy to recognize
+const ApiTokenPrefix = "wersu_"
+
+// keys under which a token authenticated request stores its user, scopes
+// and token ID in the gin context
+const (
+	apiTokenUserKey   = "api_token_user"
+	apiTokenScopesKey = "api_token_scopes"
+	apiTokenIDKey     = "api_token_id"
+)
+
+// TokenController handles personal access tokens
+type TokenController struct {
+	UserService *proto.UserServiceClient
+}
+
+func NewTokenController(userService *proto.UserServiceClient) *TokenController {
+	return &TokenController{UserService: userService}
+}
+
+type PostApiTokenRequest struct {
+	// name to recognize the token
+	Name string `json:"name" binding:"required,max=100" example:"backup script"`
+
+	// what the token may be used for
+	Scopes []models.Scope `json:"scopes" binding:"required,min=1,dive,oneof=notes:read notes:write search" example:"notes:read,search"`
+
+	// optional point in time when the token stops working
+	ExpiresAt *time.Time `json:"expires_at" binding:"omitempty" example:"2027-01-01T00:00:00Z"`
+}
+
+type ApiTokenReply struct {
+	Id         int32      `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+// PostApiTokenReply is returned when a token is created. It is the only time
+// the token itself is shown.
+type PostApiTokenReply struct {
+	ApiTokenReply
+	Token string `json:"token" example:"wersu_Xk2v..."`
+}
+
+// ApiTokenReplyFromProto converts a protobuf ApiToken message to an ApiTokenReply struct.
+func ApiTokenReplyFromProto(token *proto.ApiToken) ApiTokenReply {
+	reply := ApiTokenReply{
+		Id:        token.Id,
+		Name:      token.Name,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt.AsTime(),
+	}
+	if token.ExpiresAt != nil {
+		expiresAt := token.ExpiresAt.AsTime()
+		reply.ExpiresAt = &expiresAt
+	}
+	if token.LastUsedAt != nil {
+		lastUsedAt := token.LastUsedAt.AsTime()
+		reply.LastUsedAt = &lastUsedAt
+	}
+	if reply.Scopes == nil {
+		reply.Scopes = []string{}
+	}
+	return reply
+}
+
+// hashToken returns the hash under which a token is stored
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// generateToken creates a new random token starting with prefix
+func generateToken(prefix string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// PostToken godoc
+// @Summary Create a personal access token
+// @Description Creates a token for scripts and CLI tools, which is sent as "Authorization: Bearer <token>".
+// @Description The token is only returned once. Requires a browser session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param payload body PostApiTokenRequest true "Token to create"
+// @Success 201 {object} PostApiTokenReply
+// @Failure 400 {object} ProblemDetails
+// @Router /auth/tokens [post]
+func (tc *TokenController) PostToken(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse request body
+	var postApiTokenRequest PostApiTokenRequest
+	if err := c.ShouldBindJSON(&postApiTokenRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if postApiTokenRequest.ExpiresAt != nil && postApiTokenRequest.ExpiresAt.Before(time.Now()) {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: expires_at lies in the past"))
+		return
+	}
+
+	token, err := generateToken(ApiTokenPrefix)
+	if err != nil {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to generate token: %w", err))
+		return
+	}
+
+	// gRPC service call
+	grpcPostApiTokenRequest := proto.PostApiTokenRequest{
+		UserId:    user.ID,
+		Name:      postApiTokenRequest.Name,
+		TokenHash: hashToken(token),
+	}
+	for _, scope := range postApiTokenRequest.Scopes {
+		grpcPostApiTokenRequest.Scopes = append(grpcPostApiTokenRequest.Scopes, string(scope))
+	}
+	if postApiTokenRequest.ExpiresAt != nil {
+		grpcPostApiTokenRequest.ExpiresAt = timestamppb.New(*postApiTokenRequest.ExpiresAt)
+	}
+	apiToken, err := (*tc.UserService).PostApiToken(c, &grpcPostApiTokenRequest)
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to post token via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, PostApiTokenReply{
+		ApiTokenReply: ApiTokenReplyFromProto(apiToken),
+		Token:         token,
+	})
+}
+
+// GetTokens godoc
+// @Summary List personal access tokens
+// @Description Lists the personal access tokens of the logged in user. Requires a browser session.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} []ApiTokenReply
+// @Failure 401 {object} ProblemDetails
+// @Router /auth/tokens [get]
+func (tc *TokenController) GetTokens(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*tc.UserService).GetApiTokens(c, &proto.GetApiTokensRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch tokens via gRPC service: %w", err))
+		return
+	}
+
+	tokens := []ApiTokenReply{}
+	for _, token := range response.Tokens {
+		tokens = append(tokens, ApiTokenReplyFromProto(token))
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// DeleteToken godoc
+// @Summary Revoke a personal access token
+// @Description Revokes a personal access token of the logged in user. Requires a browser session.
+// @Tags auth
+// @Param id path int true "Token ID"
+// @Success 204
+// @Failure 404 {object} ProblemDetails
+// @Router /auth/tokens/{id} [delete]
+func (tc *TokenController) DeleteToken(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// gRPC service call
+	_, err = (*tc.UserService).DeleteApiToken(c, &proto.DeleteApiTokenRequest{Id: int32(id), UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to delete token via gRPC service: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// BearerAuth returns a middleware which authenticates requests carrying an
+// "Authorization: Bearer <token>" header with a personal access token.
+// The user of the token is then returned by UserFromSession.
+// Requests without the header are passed on unchanged.
+func (tc *TokenController) BearerAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		scheme, token, _ := strings.Cut(header, " ")
+		if !strings.EqualFold(scheme, "Bearer") || !strings.HasPrefix(token, ApiTokenPrefix) {
+			setInvalidTokenError(c, fmt.Errorf("invalid authorization header"))
+			return
+		}
+
+		response, err := (*tc.UserService).GetUserByApiToken(c, &proto.GetUserByApiTokenRequest{
+			TokenHash: hashToken(token),
+		})
+		if status.Code(err) == codes.NotFound {
+			setInvalidTokenError(c, fmt.Errorf("unknown or revoked token"))
+			return
+		}
+		if err != nil {
+			SetGrpcError(c, fmt.Errorf("failed to resolve token via gRPC service: %w", err))
+			return
+		}
+		if response.Token.ExpiresAt != nil && response.Token.ExpiresAt.AsTime().Before(time.Now()) {
+			setInvalidTokenError(c, fmt.Errorf("token expired"))
+			return
+		}
+
+		scopes := make([]models.Scope, 0, len(response.Token.Scopes))
+		for _, scope := range response.Token.Scopes {
+			scopes = append(scopes, models.Scope(scope))
+		}
+		c.Set(apiTokenUserKey, response.User.ToModel())
+		c.Set(apiTokenScopesKey, scopes)
+		c.Set(apiTokenIDKey, response.Token.Id)
+		c.Next()
+	}
+}
+
+func setInvalidTokenError(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	SetGinError(c, http.StatusUnauthorized, err)
+}
+
+// IsTokenAuthenticated reports whether the request was authenticated with a
+// personal access token instead of a session
+func IsTokenAuthenticated(c *gin.Context) bool {
+	_, ok := c.Get(apiTokenUserKey)
+	return ok
+}
+
+// ClientKey identifies the client of a request: the session of browsers and
+// the token of requests authenticated with a personal access token
+func ClientKey(c *gin.Context) string {
+	if tokenID, ok := c.Get(apiTokenIDKey); ok {
+		return fmt.Sprintf("token:%d", tokenID)
+	}
+	return "session:" + sessions.Default(c).ID()
+}
+
+// RequireScope returns a middleware which rejects requests authenticated with
+// a personal access token lacking the given scope. Session authenticated
+// requests are not restricted.
+func RequireScope(scope models.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !IsTokenAuthenticated(c) {
+			c.Next()
+			return
+		}
+		scopes, _ := c.Get(apiTokenScopesKey)
+		if !slices.Contains(scopes.([]models.Scope), scope) {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, scope))
+			SetGinError(c, http.StatusForbidden, fmt.Errorf("token lacks scope %s", scope))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession returns a middleware which rejects requests authenticated
+// with a personal access token, e.g. so tokens can't create further tokens.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsTokenAuthenticated(c) {
+			SetGinError(c, http.StatusForbidden, fmt.Errorf("this route requires a browser session"))
+			return
+		}
+		c.Next()
+	}
+}
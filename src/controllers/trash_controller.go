@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// TrashController handles the notes users deleted, which can be restored
+// until they are purged
+type TrashController struct {
+	NoteService *proto.NoteServiceClient
+	// how long notes stay in the trash, 0 if they are never purged
+	Retention time.Duration
+}
+
+func NewTrashController(noteService *proto.NoteServiceClient, retention time.Duration) *TrashController {
+	return &TrashController{NoteService: noteService, Retention: retention}
+}
+
+// TrashedNote is a note in the trash
+type TrashedNote struct {
+	MinimalNote
+	DeletedAt time.Time `json:"deleted_at"`
+	// when the note is deleted permanently, null if it is kept until the trash is emptied
+	PurgeAt *time.Time `json:"purge_at"`
+}
+
+type GetTrashRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0" example:"0"`
+}
+
+type RestoreNotesRequest struct {
+	Ids []int32 `json:"ids" binding:"required,min=1,max=100,dive,min=1" example:"42,43"`
+}
+
+type EmptyTrashReply struct {
+	// number of notes which were deleted permanently
+	Purged int32 `json:"purged" example:"3"`
+}
+
+// TrashedNoteFromProto converts a protobuf MinimalNote of the trash to a TrashedNote struct.
+func (tc *TrashController) TrashedNoteFromProto(protoNote *proto.MinimalNote, userID int32) TrashedNote {
+	note := TrashedNote{
+		MinimalNote: ConvertProtoMinimalNoteToRest(protoNote, userID),
+		DeletedAt:   protoNote.DeletedAt.AsTime(),
+	}
+	if tc.Retention > 0 {
+		purgeAt := note.DeletedAt.Add(tc.Retention)
+		note.PurgeAt = &purgeAt
+	}
+	return note
+}
+
+// GetTrash godoc
+// @Summary List the trash
+// @Description Lists the notes the logged in user deleted, latest deleted first.
+// @Description Notes in the trash are excluded from all other routes, until they are restored.
+// @Tags trash
+// @Produce json
+// @Param limit query int false "Maximum results to return" default(20) maximum(100)
+// @Param offset query int false "Pagination offset"
+// @Success 200 {object} []TrashedNote
+// @Failure 400 {object} ProblemDetails
+// @Router /trash [get]
+func (tc *TrashController) GetTrash(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse query
+	var getTrashRequest GetTrashRequest
+	if err := c.ShouldBindQuery(&getTrashRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid query parameters: %w", err))
+		return
+	}
+	if getTrashRequest.Limit == 0 {
+		getTrashRequest.Limit = DefaultSearchLimit
+	}
+
+	// gRPC service call
+	stream, err := (*tc.NoteService).GetTrash(c, &proto.GetTrashRequest{
+		UserId: user.ID,
+		Limit:  getTrashRequest.Limit,
+		Offset: getTrashRequest.Offset,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch trash via gRPC service: %w", err))
+		return
+	}
+
+	notes := []TrashedNote{}
+	for {
+		note, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			SetGrpcError(c, fmt.Errorf("failed to receive trash via gRPC service: %w", err))
+			return
+		}
+		notes = append(notes, tc.TrashedNoteFromProto(note, user.ID))
+	}
+	c.JSON(http.StatusOK, notes)
+}
+
+// RestoreNote godoc
+// @Summary Restore a note from the trash
+// @Description Moves a note out of the trash. If its notebook was deleted in the meantime, it is restored to the top level.
+// @Tags trash
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} NoteReply
+// @Header 200 {string} ETag "Revision of the note"
+// @Failure 404 {object} ProblemDetails
+// @Router /trash/{id}/restore [post]
+func (tc *TrashController) RestoreNote(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*tc.NoteService).RestoreNotes(c, &proto.RestoreNotesRequest{
+		Ids:    []int32{int32(id)},
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to restore note via gRPC service: %w", err))
+		return
+	}
+	if len(response.Notes) != 1 {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("gRPC service restored %d notes instead of 1", len(response.Notes)))
+		return
+	}
+
+	note := response.Notes[0]
+	c.Header("ETag", NoteETag(note))
+	c.JSON(http.StatusOK, NoteReplyFromProto(note, user))
+}
+
+// RestoreNotes godoc
+// @Summary Restore several notes from the trash
+// @Description Moves the given notes out of the trash. Either all of them are restored, or none if one isn't in the trash.
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param payload body RestoreNotesRequest true "Notes to restore"
+// @Success 200 {object} []NoteReply
+// @Failure 400 {object} ProblemDetails
+// @Failure 404 {object} ProblemDetails
+// @Router /trash/restore [post]
+func (tc *TrashController) RestoreNotes(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse request body
+	var restoreNotesRequest RestoreNotesRequest
+	if err := c.ShouldBindJSON(&restoreNotesRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*tc.NoteService).RestoreNotes(c, &proto.RestoreNotesRequest{
+		Ids:    restoreNotesRequest.Ids,
+		UserId: user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to restore notes via gRPC service: %w", err))
+		return
+	}
+
+	notes := []NoteReply{}
+	for _, note := range response.Notes {
+		notes = append(notes, NoteReplyFromProto(note, user))
+	}
+	c.JSON(http.StatusOK, notes)
+}
+
+// DeleteNote godoc
+// @Summary Delete a note permanently
+// @Description Deletes a note in the trash, so it can't be restored anymore.
+// @Tags trash
+// @Param id path int true "Note ID"
+// @Success 204
+// @Failure 404 {object} ProblemDetails
+// @Router /trash/{id} [delete]
+func (tc *TrashController) DeleteNote(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// read path
+	id, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid ID format: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*tc.NoteService).PurgeNotes(c, &proto.PurgeNotesRequest{
+		Ids:    []int32{int32(id)},
+		UserId: &user.ID,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to purge note via gRPC service: %w", err))
+		return
+	}
+	if response.Purged == 0 {
+		SetGinError(c, http.StatusNotFound, fmt.Errorf("note %d is not in the trash", id))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// EmptyTrash godoc
+// @Summary Empty the trash
+// @Description Deletes all notes in the trash of the logged in user permanently.
+// @Tags trash
+// @Produce json
+// @Success 200 {object} EmptyTrashReply
+// @Failure 401 {object} ProblemDetails
+// @Router /trash [delete]
+func (tc *TrashController) EmptyTrash(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service call
+	response, err := (*tc.NoteService).PurgeNotes(c, &proto.PurgeNotesRequest{UserId: &user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to empty trash via gRPC service: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, EmptyTrashReply{Purged: response.Purged})
+}
@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/KuramaSyu/WerSu-Rest/src/sessionstore"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// UserController lets users manage their own account
+type UserController struct {
+	UserService *proto.UserServiceClient
+	NoteService *proto.NoteServiceClient
+	Sessions    *sessionstore.Store
+}
+
+func NewUserController(
+	userService *proto.UserServiceClient,
+	noteService *proto.NoteServiceClient,
+	store *sessionstore.Store,
+) *UserController {
+	return &UserController{
+		UserService: userService,
+		NoteService: noteService,
+		Sessions:    store,
+	}
+}
+
+// PatchUserRequest contains the profile fields which should be changed.
+// Fields which are omitted are left untouched, an empty string removes the
+// override, so the value of the login provider is used again.
+type PatchUserRequest struct {
+	DisplayName  *string `json:"display_name" binding:"omitempty,max=64" example:"Alice"`
+	ContactEmail *string `json:"contact_email" binding:"omitempty,len=0|email" example:"alice@example.com"`
+}
+
+// UserExport is the user record contained in a data export
+type UserExport struct {
+	User       *models.JsUser  `json:"user"`
+	Identities []IdentityReply `json:"identities"`
+	ExportedAt time.Time       `json:"exported_at"`
+}
+
+// PatchMe godoc
+// @Summary Edit own profile
+// @Description Sets the display name and contact email, which take precedence over the data of the login provider.
+// @Description Requires a browser session.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param payload body PatchUserRequest true "Fields to change"
+// @Success 200 {object} models.JsUser
+// @Failure 400 {object} ProblemDetails
+// @Router /users/me [patch]
+func (uc *UserController) PatchMe(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// parse request body
+	var patchUserRequest PatchUserRequest
+	if err := c.ShouldBindJSON(&patchUserRequest); err != nil {
+		SetGinError(c, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// gRPC service call
+	alteredUser, err := (*uc.UserService).AlterUser(c, &proto.AlterUserRequest{
+		Id:           user.ID,
+		DisplayName:  patchUserRequest.DisplayName,
+		ContactEmail: patchUserRequest.ContactEmail,
+	})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to alter user via gRPC service: %w", err))
+		return
+	}
+
+	// keep the user of the session up to date
+	session := sessions.Default(c)
+	session.Set("user", alteredUser.ToModel())
+	if err := session.Save(); err != nil {
+		SetGinError(c, http.StatusInternalServerError, fmt.Errorf("failed to save session: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, alteredUser.ParseJS())
+}
+
+// DeleteMe godoc
+// @Summary Delete own account
+// @Description Deletes the logged in user with all of their notes, login identities and tokens and logs out all sessions.
+// @Description This can't be undone. Requires a browser session.
+// @Tags users
+// @Success 204
+// @Failure 401 {object} ProblemDetails
+// @Router /users/me [delete]
+func (uc *UserController) DeleteMe(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service calls. Notes are deleted first, so a failure leaves an
+	// account which can still be deleted again
+	if _, err := (*uc.NoteService).DeleteUserNotes(c, &proto.DeleteUserNotesRequest{UserId: user.ID}); err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to delete notes via gRPC service: %w", err))
+		return
+	}
+	if _, err := (*uc.UserService).DeleteUser(c, &proto.DeleteUserRequest{Id: user.ID}); err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to delete user via gRPC service: %w", err))
+		return
+	}
+
+	// log out everywhere. The account is gone already, so a failure is only
+	// logged and the current session is cleared anyway
+	if err := uc.Sessions.RevokeUser(user.ID); err != nil {
+		log.Printf("Failed to revoke sessions of deleted user %v: %v", user.ID, err)
+	}
+	session := sessions.Default(c)
+	session.Clear()
+	session.Options(sessions.Options{Path: "/", MaxAge: -1})
+	if err := session.Save(); err != nil {
+		log.Printf("Failed to clear session of deleted user %v: %v", user.ID, err)
+	}
+
+	log.Printf("User %v deleted their account", user.ID)
+	c.Status(http.StatusNoContent)
+}
+
+// ExportMe godoc
+// @Summary Export own data
+// @Description Streams a ZIP archive containing the user record (user.json) and all notes of the logged in user,
+// @Description each as JSON and Markdown (notes/{id}-{title}.json, notes/{id}-{title}.md). Requires a browser session.
+// @Tags users
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 401 {object} ProblemDetails
+// @Router /users/me/export [get]
+func (uc *UserController) ExportMe(c *gin.Context) {
+	// get user from session
+	user, code, err := UserFromSession(c)
+	if err != nil {
+		SetGinError(c, code, fmt.Errorf("not logged in: %w", err))
+		return
+	}
+
+	// gRPC service calls
+	grpcUser, err := (*uc.UserService).GetUser(c, &proto.GetUserRequest{Id: &user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch user via gRPC service: %w", err))
+		return
+	}
+	identities, err := (*uc.UserService).GetIdentities(c, &proto.GetIdentitiesRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch identities via gRPC service: %w", err))
+		return
+	}
+	stream, err := (*uc.NoteService).GetUserNotes(c, &proto.GetUserNotesRequest{UserId: user.ID})
+	if err != nil {
+		SetGrpcError(c, fmt.Errorf("failed to fetch notes via gRPC service: %w", err))
+		return
+	}
+
+	// errors of the stream are only reported on the first receive. Wait for
+	// it, so they can still be sent as error response
+	first, err := stream.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		SetGrpcError(c, fmt.Errorf("failed to fetch notes via gRPC service: %w", err))
+		return
+	}
+
+	export := UserExport{
+		User:       grpcUser.ParseJS(),
+		Identities: []IdentityReply{},
+		ExportedAt: time.Now().UTC(),
+	}
+	for _, identity := range identities.Identities {
+		export.Identities = append(export.Identities, IdentityReplyFromProto(identity))
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="wersu-export-%d.zip"`, user.ID))
+	c.Status(http.StatusOK)
+
+	archive := zip.NewWriter(c.Writer)
+	if err := writeZipJSON(archive, "user.json", export.ExportedAt, export); err != nil {
+		abortExport(c, user, err)
+		return
+	}
+	note := first
+	for note != nil {
+		if err := writeNoteExport(archive, note, user); err != nil {
+			abortExport(c, user, err)
+			return
+		}
+		note, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			abortExport(c, user, err)
+			return
+		}
+	}
+	if err := archive.Close(); err != nil {
+		abortExport(c, user, err)
+	}
+}
+
+// abortExport is called when an export fails after the response started.
+// The archive is left without its central directory, so clients recognize
+// the download as broken.
+func abortExport(c *gin.Context, user *models.User, err error) {
+	log.Printf("Export of user %v failed: %v", user.ID, err)
+	c.Error(err)
+	c.Abort()
+}
+
+// writeNoteExport adds a note as JSON and Markdown to the archive
+func writeNoteExport(archive *zip.Writer, note *proto.Note, user *models.User) error {
+	name := fmt.Sprintf("notes/%d", note.Id)
+	if slug := slugify(note.Title); slug != "" {
+		name += "-" + slug
+	}
+	updatedAt := note.UpdatedAt.AsTime()
+	if err := writeZipJSON(archive, name+".json", updatedAt, NoteReplyFromProto(note, user)); err != nil {
+		return err
+	}
+	file, err := createZipFile(archive, name+".md", updatedAt)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(file, "# %s\n\n%s\n", note.Title, note.Content)
+	return err
+}
+
+func writeZipJSON(archive *zip.Writer, name string, modified time.Time, value any) error {
+	file, err := createZipFile(archive, name, modified)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func createZipFile(archive *zip.Writer, name string, modified time.Time) (io.Writer, error) {
+	return archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+}
+
+var nonSlugChars = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// slugify turns a title into a file name friendly string like "my-note"
+func slugify(title string) string {
+	slug := []rune(strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(title), "-"), "-"))
+	if len(slug) > 50 {
+		slug = slug[:50]
+	}
+	return strings.TrimRight(string(slug), "-")
+}
@@ -0,0 +1,158 @@
+package diff
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Op is the kind of a change
+type Op string
+
+const (
+	Equal  Op = "equal"
+	Insert Op = "insert"
+	Delete Op = "delete"
+)
+
+// edit is a single step turning a into b. A and B are the positions in a and
+// b before the step.
+type edit struct {
+	Op   Op
+	A, B int
+}
+
+// compute returns the shortest edit script turning a into b, using the
+// algorithm of Myers
+func compute[T comparable](a, b []T) []edit {
+	// common prefix and suffix don't need to be searched
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, edit{Equal, i, i})
+	}
+	for _, e := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		edits = append(edits, edit{e.Op, e.A + prefix, e.B + prefix})
+	}
+	for i := suffix; i > 0; i-- {
+		edits = append(edits, edit{Equal, len(a) - i, len(b) - i})
+	}
+	return edits
+}
+
+// maxCost limits the number of changes myers searches for, which bounds its
+// memory use. Texts which differ more are replaced as a whole.
+const maxCost = 2000
+
+func myers[T comparable](a, b []T) []edit {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+
+	// furthest reaching x of the diagonals -d..d before each round d
+	var trace [][]int
+	for d := 0; d <= min(n+m, maxCost); d++ {
+		trace = append(trace, slices.Clone(v[offset-d:offset+d+1]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m)
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+	for x := 0; x < n; x++ {
+		edits = append(edits, edit{Delete, x, 0})
+	}
+	for y := 0; y < m; y++ {
+		edits = append(edits, edit{Insert, n, y})
+	}
+	return edits
+}
+
+// backtrack walks the trace of myers back from the end to the start
+func backtrack(trace [][]int, x, y int) []edit {
+	var edits []edit
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d] // diagonal k is at k+d
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[k-1+d] < v[k+1+d]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = v[prevK+d]
+		}
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{Equal, x, y})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{Insert, prevX, prevY})
+			} else {
+				edits = append(edits, edit{Delete, prevX, prevY})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	slices.Reverse(edits)
+	return edits
+}
+
+// Segment is a piece of text which is equal in both texts, or was inserted
+// or deleted
+type Segment struct {
+	Op   Op     `json:"op" example:"insert"`
+	Text string `json:"text" example:"new words"`
+}
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}_]+|\s+|[^\p{L}\p{N}_\s]`)
+
+// Words returns the word level changes between two texts. Joining the text of
+// all segments except insertions gives from, except deletions gives to.
+func Words(from, to string) []Segment {
+	a := wordPattern.FindAllString(from, -1)
+	b := wordPattern.FindAllString(to, -1)
+
+	segments := []Segment{}
+	for _, e := range compute(a, b) {
+		var text string
+		if e.Op == Insert {
+			text = b[e.B]
+		} else {
+			text = a[e.A]
+		}
+		if len(segments) > 0 && segments[len(segments)-1].Op == e.Op {
+			segments[len(segments)-1].Text += text
+			continue
+		}
+		segments = append(segments, Segment{Op: e.Op, Text: text})
+	}
+	return segments
+}
@@ -0,0 +1,195 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// apply rebuilds both sequences from an edit script and counts its changes
+func apply(edits []edit, a, b []string) (gotA, gotB []string, changes int) {
+	for _, e := range edits {
+		switch e.Op {
+		case Equal:
+			if a[e.A] != b[e.B] {
+				return nil, nil, -1
+			}
+			gotA = append(gotA, a[e.A])
+			gotB = append(gotB, b[e.B])
+		case Delete:
+			gotA = append(gotA, a[e.A])
+			changes++
+		case Insert:
+			gotB = append(gotB, b[e.B])
+			changes++
+		}
+	}
+	return gotA, gotB, changes
+}
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		wantChanges int
+	}{
+		{name: "both empty", a: "", b: "", wantChanges: 0},
+		{name: "equal", a: "abc", b: "abc", wantChanges: 0},
+		{name: "insert all", a: "", b: "abc", wantChanges: 3},
+		{name: "delete all", a: "abc", b: "", wantChanges: 3},
+		{name: "insert in the middle", a: "ac", b: "abc", wantChanges: 1},
+		{name: "replace", a: "abc", b: "axc", wantChanges: 2},
+		// the example of Myers' paper has an edit distance of 5
+		{name: "paper example", a: "abcabba", b: "cbabac", wantChanges: 5},
+		{name: "repeated items", a: "aaaa", b: "aa", wantChanges: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := strings.Split(tt.a, "")
+			b := strings.Split(tt.b, "")
+			gotA, gotB, changes := apply(compute(a, b), a, b)
+			if changes < 0 {
+				t.Fatal("compute() matched unequal items")
+			}
+			if strings.Join(gotA, "") != tt.a || strings.Join(gotB, "") != tt.b {
+				t.Errorf("compute() rebuilds %q and %q, want %q and %q", strings.Join(gotA, ""), strings.Join(gotB, ""), tt.a, tt.b)
+			}
+			if changes != tt.wantChanges {
+				t.Errorf("compute() has %d changes, want %d", changes, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestComputeBeyondMaxCost(t *testing.T) {
+	a := strings.Split(strings.Repeat("a", maxCost+1), "")
+	b := strings.Split(strings.Repeat("b", maxCost+1), "")
+	gotA, gotB, changes := apply(compute(a, b), a, b)
+	if len(gotA) != len(a) || len(gotB) != len(b) || changes != len(a)+len(b) {
+		t.Errorf("compute() doesn't replace the texts as a whole: %d changes", changes)
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     []Segment
+	}{
+		{
+			name: "equal",
+			from: "same text",
+			to:   "same text",
+			want: []Segment{{Equal, "same text"}},
+		},
+		{
+			name: "both empty",
+			want: []Segment{},
+		},
+		{
+			name: "replaced word",
+			from: "the quick fox",
+			to:   "the slow fox",
+			want: []Segment{{Equal, "the "}, {Delete, "quick"}, {Insert, "slow"}, {Equal, " fox"}},
+		},
+		{
+			name: "inserted words are merged",
+			from: "hello world",
+			to:   "hello big wide world",
+			want: []Segment{{Equal, "hello "}, {Insert, "big wide "}, {Equal, "world"}},
+		},
+		{
+			name: "punctuation is a word of its own",
+			from: "done.",
+			to:   "done!",
+			want: []Segment{{Equal, "done"}, {Delete, "."}, {Insert, "!"}},
+		},
+		{
+			name: "unicode words",
+			from: "grüße an alle",
+			to:   "grüße an dich",
+			want: []Segment{{Equal, "grüße an "}, {Delete, "alle"}, {Insert, "dich"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Words(tt.from, tt.to)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Words(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+
+			var from, to strings.Builder
+			for _, segment := range got {
+				if segment.Op != Insert {
+					from.WriteString(segment.Text)
+				}
+				if segment.Op != Delete {
+					to.WriteString(segment.Text)
+				}
+			}
+			if from.String() != tt.from || to.String() != tt.to {
+				t.Errorf("segments rebuild %q and %q", from.String(), to.String())
+			}
+		})
+	}
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     string
+	}{
+		{
+			name: "equal",
+			from: "a\nb\n",
+			to:   "a\nb\n",
+			want: "",
+		},
+		{
+			name: "changed line",
+			from: "a\nb\nc\n",
+			to:   "a\nx\nc\n",
+			want: "--- v1\n+++ v2\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			name: "from empty",
+			from: "",
+			to:   "a\n",
+			want: "--- v1\n+++ v2\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name: "to empty",
+			from: "a\nb\n",
+			to:   "",
+			want: "--- v1\n+++ v2\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name: "missing newline at end",
+			from: "a\nb",
+			to:   "a\nb\n",
+			want: "--- v1\n+++ v2\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name: "distant changes get their own hunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			to:   "x\n2\n3\n4\n5\n6\n7\n8\n9\ny\n",
+			want: "--- v1\n+++ v2\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+y\n",
+		},
+		{
+			name: "close changes share a hunk",
+			from: "1\n2\n3\n4\n5\n6\n7\n",
+			to:   "x\n2\n3\n4\n5\n6\ny\n",
+			want: "--- v1\n+++ v2\n@@ -1,7 +1,7 @@\n-1\n+x\n 2\n 3\n 4\n 5\n 6\n-7\n+y\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("v1", "v2", tt.from, tt.to); got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
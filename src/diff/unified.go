@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContextLines is the number of unchanged lines shown around every change
+const ContextLines = 3
+
+// Unified returns the line level changes between two texts in the unified
+// format of diff -u. It is empty if the texts are equal.
+func Unified(fromName, toName, from, to string) string {
+	a := splitLines(from)
+	b := splitLines(to)
+	edits := compute(a, b)
+
+	var out strings.Builder
+	for _, hunk := range hunks(edits) {
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&out, edits[hunk[0]:hunk[1]], a, b)
+	}
+	return out.String()
+}
+
+// splitLines splits a text into lines, keeping the line breaks
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// hunks returns the start and end of every group of changes in edits, with
+// the surrounding context. Changes with less than twice the context between
+// them are grouped together.
+func hunks(edits []edit) [][2]int {
+	var result [][2]int
+	for i, e := range edits {
+		if e.Op == Equal {
+			continue
+		}
+		start := max(0, i-ContextLines)
+		end := min(len(edits), i+ContextLines+1)
+		if len(result) > 0 && start <= result[len(result)-1][1] {
+			result[len(result)-1][1] = end
+			continue
+		}
+		result = append(result, [2]int{start, end})
+	}
+	return result
+}
+
+func writeHunk(out *strings.Builder, edits []edit, a, b []string) {
+	var aLen, bLen int
+	for _, e := range edits {
+		if e.Op != Insert {
+			aLen++
+		}
+		if e.Op != Delete {
+			bLen++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(edits[0].A, aLen), hunkRange(edits[0].B, bLen))
+
+	for _, e := range edits {
+		switch e.Op {
+		case Equal:
+			writeLine(out, ' ', a[e.A])
+		case Delete:
+			writeLine(out, '-', a[e.A])
+		case Insert:
+			writeLine(out, '+', b[e.B])
+		}
+	}
+}
+
+// hunkRange formats the range of a hunk. Lines count from 1, an empty range
+// points at the line before it.
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+func writeLine(out *strings.Builder, prefix byte, line string) {
+	out.WriteByte(prefix)
+	out.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		out.WriteString("\n\\ No newline at end of file\n")
+	}
+}
@@ -15,9 +15,220 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/notes": {
+        "/auth/identities": {
+            "get": {
+                "description": "Lists the login identities linked to the logged in user. Each of them can be used to log in.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List linked login identities",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.IdentityReply"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/identities/{provider}/link": {
+            "get": {
+                "description": "Starts the OAuth flow of a provider. Afterwards the identity is linked to the logged in user\nand the browser is redirected to the frontend with the query parameter linked={provider}.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Link a login identity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "github",
+                        "description": "Login provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "307": {
+                        "description": "Temporary Redirect"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/identities/{provider}/{subject}": {
+            "delete": {
+                "description": "Unlinks a login identity from the logged in user. The last identity can't be unlinked.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Unlink a login identity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "github",
+                        "description": "Login provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "583231",
+                        "description": "ID of the user at the provider",
+                        "name": "subject",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "The identity is the last one of the user",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/providers": {
+            "get": {
+                "description": "Lists the configured login providers. A login is started by navigating to login_url.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List login providers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.ProviderReply"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "description": "Lists the active login sessions of the logged in user, most recently used first. Requires a browser session.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List login sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.SessionReply"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions/{id}": {
+            "delete": {
+                "description": "Logs out the client using the session. Revoking the current session is the same as logging out.\nRequires a browser session.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke a login session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/tokens": {
+            "get": {
+                "description": "Lists the personal access tokens of the logged in user. Requires a browser session.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List personal access tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.ApiTokenReply"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Creates a new Note via gRPC service",
+                "description": "Creates a token for scripts and CLI tools, which is sent as \"Authorization: Bearer \u003ctoken\u003e\".\nThe token is only returned once. Requires a browser session.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,42 +236,95 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "auth"
                 ],
-                "summary": "Post a Note",
+                "summary": "Create a personal access token",
                 "parameters": [
                     {
-                        "description": "Note ID",
+                        "description": "Token to create",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.PostNoteRequest"
+                            "$ref": "#/definitions/controllers.PostApiTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/controllers.NoteReply"
+                            "$ref": "#/definitions/controllers.PostApiTokenReply"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/controllers.ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/notes/search": {
+        "/auth/tokens/{id}": {
+            "delete": {
+                "description": "Revokes a personal access token of the logged in user. Requires a browser session.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke a personal access token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notebooks": {
             "get": {
-                "description": "Search notes via gRPC service",
+                "description": "Lists all notebooks the logged in user has access to, nested below their parents.\nShared notebooks whose parent isn't accessible appear at the top level.\nPinned saved searches appear as nodes of kind smart inside the notebook they are restricted to,\nor at the top level.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notebooks"
+                ],
+                "summary": "List notebooks as tree",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.NotebookTreeReply"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a notebook, optionally inside another one. Requires write access on the parent.",
                 "consumes": [
                     "application/json"
                 ],
@@ -68,59 +332,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "notebooks"
                 ],
-                "summary": "Get notes by search criteria",
+                "summary": "Create a notebook",
                 "parameters": [
                     {
-                        "description": "Search Notes Request",
+                        "description": "Notebook to create",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.GetSearchNotesRequest"
+                            "$ref": "#/definitions/controllers.PostNotebookRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/controllers.MinimalNote"
-                            }
+                            "$ref": "#/definitions/controllers.NotebookReply"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/notes/{id}": {
+        "/notebooks/{id}": {
             "get": {
-                "description": "Fetch note via gRPC service",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "notebooks"
                 ],
-                "summary": "Get note by ID",
+                "summary": "Get notebook by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Note ID",
+                        "description": "Notebook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -130,112 +390,3651 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controllers.NoteReply"
+                            "$ref": "#/definitions/controllers.NotebookReply"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/controllers.ProblemDetails"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "controllers.GetSearchNotesRequest": {
-            "type": "object",
-            "required": [
-                "limit",
-                "offset",
-                "query",
-                "search_type"
-            ],
-            "properties": {
-                "limit": {
-                    "description": "maximum number of results to return",
-                    "type": "integer",
-                    "example": 10
-                },
-                "offset": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "query": {
-                    "description": "the query string to search for",
-                    "type": "string",
-                    "example": "Python programming"
-                },
-                "search_type": {
-                    "description": "the algorithm used to perform the search",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/controllers.SearchType"
+            },
+            "delete": {
+                "description": "Deletes a notebook. The notes and notebooks inside are moved to its parent, so nothing is lost.\nOnly the owner may do this.",
+                "tags": [
+                    "notebooks"
+                ],
+                "summary": "Delete a notebook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notebook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
                         }
-                    ],
-                    "example": "context"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
                 }
-            }
-        },
-        "controllers.MinimalNote": {
-            "type": "object",
-            "properties": {
-                "author_id": {
-                    "type": "integer"
-                },
+            },
+            "patch": {
+                "description": "Renaming requires write access. Moving requires ownership of the notebook and write access on the new parent.\nA notebook can't be moved into itself or one of its sub notebooks.\nThe REST API only checks this up front to give a clear error. The gRPC service enforces it atomically, so a conflicting concurrent move is rejected the same way.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notebooks"
+                ],
+                "summary": "Rename or move a notebook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notebook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PatchNotebookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NotebookReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or the new parent is the notebook itself or inside it",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notebooks/{id}/permissions": {
+            "get": {
+                "description": "Lists the users and roles a notebook is shared with. Only the owner may do this.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notebooks"
+                ],
+                "summary": "List who has access to a notebook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notebook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.NotePermissionReply"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notebooks/{id}/permissions/{subject_type}/{subject_id}": {
+            "put": {
+                "description": "Grants a user or all users with a role read, comment or write access on a notebook.\nThe access is inherited by all notes and notebooks inside, unless they set their own permission for the user or role.\nAn existing permission of the user or role is replaced. Only the owner may do this.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notebooks"
+                ],
+                "summary": "Share a notebook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notebook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "users",
+                            "roles"
+                        ],
+                        "type": "string",
+                        "description": "Whom to grant access",
+                        "name": "subject_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User or role ID",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Access to grant",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PutNotePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NotePermissionReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revokes the access of a user or role on a notebook. Only the owner may do this.",
+                "tags": [
+                    "notebooks"
+                ],
+                "summary": "Stop sharing a notebook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notebook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "users",
+                            "roles"
+                        ],
+                        "type": "string",
+                        "description": "Whose access to revoke",
+                        "name": "subject_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User or role ID",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes": {
+            "post": {
+                "description": "Creates a new Note via gRPC service\n[[Note Title]] and [[#42]] links in the content are stored, see /notes/{id}/links.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Post a Note",
+                "parameters": [
+                    {
+                        "description": "Note ID",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PostNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NoteReply"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Revision of the note"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/graph": {
+            "get": {
+                "description": "Returns the notes the logged in user can read as nodes, latest updated first, and the links between them as edges.\nOnly links between the returned notes are included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Get the link graph of notes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only include notes inside this notebook",
+                        "name": "notebook_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include notes in sub notebooks of notebook_id",
+                        "name": "recursive",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 2000,
+                        "type": "integer",
+                        "default": 500,
+                        "description": "Maximum number of notes",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NoteGraphReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/search": {
+            "get": {
+                "description": "Search notes via gRPC service. Results are paginated with cursors: pass next_cursor of a page\nas cursor to get the next page. The next page is also linked in the Link header.\nClients which send \"Accept: text/event-stream\" get the results as Server-Sent Events, see /notes/search/stream.\nA hybrid search runs the context, keyword and typo_tolerant searches concurrently and fuses their results\nwith weighted reciprocal rank fusion. Every result reports which searches found it, with rank and score.\nWith highlight set, every result contains the fragments of its content which matched the query.\nThe query may contain filters, which are removed from the text passed to the search algorithm:\ntitle:\"phrase\", tag:name, author:me or author:\u003cuser id\u003e, notebook:\u003cid\u003e or notebook:\"name\" and\nupdated:2026-01-31, updated:\u003e2026-01-01 (also \u003e=, \u003c, \u003c=) or updated:2026-01-01..2026-01-31 with dates in UTC.\nA leading \"-\" excludes a tag, word or \"phrase\". Syntax errors report their position in invalid_params.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get notes by search criteria",
+                "parameters": [
+                    {
+                        "enum": [
+                            "context",
+                            "keyword",
+                            "typo_tolerant",
+                            "latest",
+                            "hybrid"
+                        ],
+                        "type": "string",
+                        "description": "Search algorithm",
+                        "name": "search_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query with optional filters, e.g. sprint tag:infra updated:\u003e2026-01-01 author:me -draft",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only notes having all of these tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only notes inside this notebook",
+                        "name": "notebook_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include notes of sub notebooks",
+                        "name": "recursive",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Weights of hybrid searches as search_type:weight",
+                        "name": "weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return fragments with the matched terms highlighted",
+                        "name": "highlight",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 20,
+                        "type": "integer",
+                        "default": 150,
+                        "description": "Characters per fragment",
+                        "name": "fragment_size",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Maximum fragments per note",
+                        "name": "fragment_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\u003cmark\u003e",
+                        "description": "Inserted before matched terms",
+                        "name": "pre_tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\u003c/mark\u003e",
+                        "description": "Inserted after matched terms",
+                        "name": "post_tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "html",
+                            "none"
+                        ],
+                        "type": "string",
+                        "default": "html",
+                        "description": "html escapes the text around the tags",
+                        "name": "encoder",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum results to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to return",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination offset, superseded by cursor",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SearchNotesPage"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "link to the next page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/search/stream": {
+            "get": {
+                "description": "Search notes via gRPC service and send every result as soon as it arrives, using Server-Sent Events.\nEach result is sent as a \"note\" event containing a MinimalNote. The stream is terminated by\nan \"end\" event containing a SearchStreamEnd, which reports whether the search completed or failed\nand the cursor of the next page.\nThe query supports the same filters as /notes/search.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Stream notes by search criteria",
+                "parameters": [
+                    {
+                        "enum": [
+                            "context",
+                            "keyword",
+                            "typo_tolerant",
+                            "latest",
+                            "hybrid"
+                        ],
+                        "type": "string",
+                        "description": "Search algorithm",
+                        "name": "search_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query with optional filters, e.g. sprint tag:infra updated:\u003e2026-01-01 author:me -draft",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only notes having all of these tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only notes inside this notebook",
+                        "name": "notebook_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include notes of sub notebooks",
+                        "name": "recursive",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Weights of hybrid searches as search_type:weight",
+                        "name": "weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return fragments with the matched terms highlighted",
+                        "name": "highlight",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 20,
+                        "type": "integer",
+                        "default": 150,
+                        "description": "Characters per fragment",
+                        "name": "fragment_size",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Maximum fragments per note",
+                        "name": "fragment_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\u003cmark\u003e",
+                        "description": "Inserted before matched terms",
+                        "name": "pre_tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\u003c/mark\u003e",
+                        "description": "Inserted after matched terms",
+                        "name": "post_tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "html",
+                            "none"
+                        ],
+                        "type": "string",
+                        "default": "html",
+                        "description": "html escapes the text around the tags",
+                        "name": "encoder",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum results to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to return",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination offset, superseded by cursor",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MinimalNote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/shared": {
+            "get": {
+                "description": "Lists notes of other users which the logged in user has access to, latest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List notes shared with me",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum results to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.MinimalNote"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/suggest": {
+            "get": {
+                "description": "Suggests note titles, tags and recent search queries starting with the prefix. It is meant to be\ncalled on every keystroke: the request waits a short moment, and is canceled with status 499\nas soon as a newer request of the same session or token arrives, so only the latest one is answered.\nSuggestions which don't arrive within the latency budget are left out and partial is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Suggest completions while typing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "What the user typed so far",
+                        "name": "prefix",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 20,
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Maximum suggestions of every kind",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SuggestionsReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "499": {
+                        "description": "Superseded by a newer request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}": {
+            "get": {
+                "description": "Fetch note via gRPC service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get note by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy of the note",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NoteReply"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Revision of the note"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached copy is still current"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Moves a Note to the trash via gRPC service, from where it can be restored until it is purged. Only the author may do this.\nThe If-Match header has to contain the ETag of the note, so changes made in the meantime aren't lost.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a Note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the note, or * to delete any revision",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "412": {
+                        "description": "The note was changed in the meantime",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Alters title, content and/or tags of a Note via gRPC service. Requires write access on the note.\nMoving the note into another notebook is restricted to the author and requires write access on the notebook.\nThe If-Match header has to contain the ETag of the note, so changes made in the meantime aren't overwritten. Every change, including one of only the tags or the notebook, changes the ETag.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a Note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PatchNoteRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the note, or * to overwrite any revision",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NoteReply"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New revision of the note"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "412": {
+                        "description": "The note was changed in the meantime",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/backlinks": {
+            "get": {
+                "description": "Lists the notes the logged in user can read, which link to the note by its title or ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List the notes linking to a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.MinimalNote"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/links": {
+            "get": {
+                "description": "Lists the [[Note Title]] and [[#42]] links in the content of a note in order of their appearance,\nwith the notes they point to. Links to notes the user can't read are reported as dangling.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List the links of a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.NoteLinkReply"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/permissions": {
+            "get": {
+                "description": "Lists the users and roles a note is shared with. Only the author may do this.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List who has access to a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.NotePermissionReply"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/permissions/{subject_type}/{subject_id}": {
+            "put": {
+                "description": "Grants a user or all users with a role read, comment or write access on a note.\nAn existing permission of the user or role is replaced. Only the author may do this.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Share a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "users",
+                            "roles"
+                        ],
+                        "type": "string",
+                        "description": "Whom to grant access",
+                        "name": "subject_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User or role ID",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Access to grant",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PutNotePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NotePermissionReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revokes the access of a user or role on a note. Only the author may do this.",
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Stop sharing a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "users",
+                            "roles"
+                        ],
+                        "type": "string",
+                        "description": "Whose access to revoke",
+                        "name": "subject_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User or role ID",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/related": {
+            "get": {
+                "description": "Lists the notes most similar to a note, most similar first. Notes are compared by their embeddings,\nwhich are also used by the context search. Only notes the logged in user can read are returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List notes related to a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 50,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum results to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum similarity between 0 and 1",
+                        "name": "min_score",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.RelatedNote"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "The embedding of the note wasn't computed yet",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/share-links": {
+            "get": {
+                "description": "Lists the share links of a note with their access counts. Only the author may do this.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List share links",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.ShareLinkReply"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an unguessable link, which shows the note to anyone without logging in.\nThe token is only returned once. Only the author may do this.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Create a share link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Link to create",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PostShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PostShareLinkReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/share-links/{link_id}": {
+            "delete": {
+                "description": "Revokes a share link, so the note can no longer be viewed with it. Only the author may do this.",
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Revoke a share link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Share link ID",
+                        "name": "link_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/versions": {
+            "get": {
+                "description": "Lists the versions of a note, latest first. A version is stored with every change of the title or content.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List versions of a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of versions to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of versions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.NoteVersionReply"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/versions/diff": {
+            "get": {
+                "description": "Computes the changes of the content between two versions, as unified diff (default) or as word level segments.\nThe titles of both versions are returned as they are.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Compare two versions of a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Older version",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Newer version, defaults to the current one",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "unified or words",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NoteDiffReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/versions/{version}": {
+            "get": {
+                "description": "Fetches the title and content of a note as they were in the given version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Get a version of a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NoteVersionContentReply"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/notes/{id}/versions/{version}/restore": {
+            "post": {
+                "description": "Sets title and content of a note back to an older version. This is stored as a new version, so no change is lost.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Restore a version of a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version to restore",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NoteReply"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New revision of the note"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/notes/{token}": {
+            "get": {
+                "description": "Shows a note via a share link without logging in. Returns JSON or, if the client prefers it, rendered HTML.\nPassword protected links expect the password in the X-Share-Password header.",
+                "produces": [
+                    "application/json",
+                    "text/html"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "View a shared note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password of the share link",
+                        "name": "X-Share-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PublicNoteReply"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many wrong passwords",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Same as GET, but reads the password from a form field. Used by the password form of the HTML view.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/html"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Unlock a shared note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password of the share link",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PublicNoteReply"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many wrong passwords",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/searches": {
+            "get": {
+                "description": "Lists the searches the logged in user saved.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "List saved searches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.SavedSearchReply"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves a search to run it again via /searches/{id}/results. The fields are the same as the\nparameters of /notes/search and are validated the same way. Pinned searches are shown as\nsmart notebooks in the notebook tree.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "Save a search",
+                "parameters": [
+                    {
+                        "description": "Search to save",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PostSavedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SavedSearchReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/searches/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "Get saved search by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SavedSearchReply"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "searches"
+                ],
+                "summary": "Delete a saved search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the given fields of a saved search. The resulting search is validated like a new one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "Update a saved search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PatchSavedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SavedSearchReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/searches/{id}/results": {
+            "get": {
+                "description": "Runs a saved search and returns a page of its results, exactly like /notes/search with the\nsaved parameters. Clients which send \"Accept: text/event-stream\" get the results as Server-Sent Events.\nCursors become invalid when the saved search is changed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "Run a saved search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return fragments with the matched terms highlighted",
+                        "name": "highlight",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 20,
+                        "type": "integer",
+                        "default": 150,
+                        "description": "Characters per fragment",
+                        "name": "fragment_size",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Maximum fragments per note",
+                        "name": "fragment_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\u003cmark\u003e",
+                        "description": "Inserted before matched terms",
+                        "name": "pre_tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\u003c/mark\u003e",
+                        "description": "Inserted after matched terms",
+                        "name": "post_tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "html",
+                            "none"
+                        ],
+                        "type": "string",
+                        "default": "html",
+                        "description": "html escapes the text around the tags",
+                        "name": "encoder",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum results to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to return",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination offset, superseded by cursor",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SearchNotesPage"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "link to the next page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Lists the tags of all notes of the logged in user with the number of notes using them, most used first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.TagReply"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/merge": {
+            "post": {
+                "description": "Replaces the source tags with the target tag on all notes of the logged in user.\nThe target may be a new or an existing tag.\nThe ETag of every note having one of the source tags changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Merge tags",
+                "parameters": [
+                    {
+                        "description": "Tags to merge",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MergeTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TagReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{name}": {
+            "patch": {
+                "description": "Renames a tag on all notes of the logged in user. Fails if the new name is used already, merge the tags instead.\nTags containing slashes can be given as they are, e.g. PATCH /tags/projects/wersu.\nThe ETag of every note having the tag changes, so clients holding an older copy can't overwrite the new tags.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Rename a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag to rename",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New name",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PatchTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TagReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "description": "Lists the notes the logged in user deleted, latest deleted first.\nNotes in the trash are excluded from all other routes, until they are restored.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "List the trash",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum results to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.TrashedNote"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes all notes in the trash of the logged in user permanently.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Empty the trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.EmptyTrashReply"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/restore": {
+            "post": {
+                "description": "Moves the given notes out of the trash. Either all of them are restored, or none if one isn't in the trash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore several notes from the trash",
+                "parameters": [
+                    {
+                        "description": "Notes to restore",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RestoreNotesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.NoteReply"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{id}": {
+            "delete": {
+                "description": "Deletes a note in the trash, so it can't be restored anymore.",
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Delete a note permanently",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{id}/restore": {
+            "post": {
+                "description": "Moves a note out of the trash. If its notebook was deleted in the meantime, it is restored to the top level.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a note from the trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.NoteReply"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Revision of the note"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "delete": {
+                "description": "Deletes the logged in user with all of their notes, login identities and tokens and logs out all sessions.\nThis can't be undone. Requires a browser session.",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete own account",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Sets the display name and contact email, which take precedence over the data of the login provider.\nRequires a browser session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Edit own profile",
+                "parameters": [
+                    {
+                        "description": "Fields to change",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PatchUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "description": "Streams a ZIP archive containing the user record (user.json) and all notes of the logged in user,\neach as JSON and Markdown (notes/{id}-{title}.json, notes/{id}-{title}.md). Requires a browser session.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export own data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.ApiTokenReply": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "controllers.EmptyTrashReply": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "description": "number of notes which were deleted permanently",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "controllers.Fragment": {
+            "type": "object",
+            "properties": {
+                "offset": {
+                    "description": "position in the content in characters",
+                    "type": "integer",
+                    "example": 120
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.74
+                },
+                "text": {
+                    "type": "string",
+                    "example": "… started with \u003cmark\u003ePython\u003c/mark\u003e programming last year …"
+                }
+            }
+        },
+        "controllers.IdentityReply": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "octocat@github.com"
+                },
+                "linked_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "github"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "583231"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "octocat"
+                }
+            }
+        },
+        "controllers.InvalidParam": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "title"
+                },
+                "position": {
+                    "description": "character offset of the problem in the value, starting at 0. Only set\nfor values with a syntax, like search queries",
+                    "type": "integer",
+                    "example": 12
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "controllers.MergeTagsRequest": {
+            "type": "object",
+            "required": [
+                "sources",
+                "target"
+            ],
+            "properties": {
+                "sources": {
+                    "description": "tags which are replaced by target",
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "todo",
+                        "to-do"
+                    ]
+                },
+                "target": {
+                    "type": "string",
+                    "example": "todo"
+                }
+            }
+        },
+        "controllers.MinimalNote": {
+            "type": "object",
+            "properties": {
+                "access_level": {
+                    "description": "access of the requesting user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AccessLevel"
+                        }
+                    ],
+                    "example": "read"
+                },
+                "author_id": {
+                    "type": "integer"
+                },
+                "fragments": {
+                    "description": "parts of the content which matched the query, best first. Only set if highlighting was requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.Fragment"
+                    }
+                },
+                "highlighted_title": {
+                    "description": "title with the matched terms between the tags, only set if highlighting was requested",
+                    "type": "string",
+                    "example": "Learning \u003cmark\u003ePython\u003c/mark\u003e"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "description": "how the fused searches ranked the note, only set by hybrid searches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.SearchMatch"
+                    }
+                },
+                "notebook_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "score": {
+                    "description": "relevance for the query, higher is better. Only set by ranked searches",
+                    "type": "number",
+                    "example": 0.032
+                },
+                "stripped_content": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work",
+                        "ideas"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "ISO 8601 format",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.NoteDiffReply": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "unified"
+                },
+                "note_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "title_from": {
+                    "type": "string",
+                    "example": "My Note"
+                },
+                "title_to": {
+                    "type": "string",
+                    "example": "My renamed Note"
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "unified": {
+                    "type": "string",
+                    "example": "--- version 1\n+++ version 3\n@@ -1 +1 @@\n-old\n+new\n"
+                },
+                "words": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/diff.Segment"
+                    }
+                }
+            }
+        },
+        "controllers.NoteGraphEdge": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "target": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "controllers.NoteGraphNode": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "notebook_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work",
+                        "ideas"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "My Note"
+                }
+            }
+        },
+        "controllers.NoteGraphReply": {
+            "type": "object",
+            "properties": {
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.NoteGraphEdge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.NoteGraphNode"
+                    }
+                }
+            }
+        },
+        "controllers.NoteLinkReply": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "the linked note, null for dangling links. For ambiguous links the\nlatest updated note",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controllers.MinimalNote"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "resolved, dangling if no note matches, or ambiguous if several\nnotes have the title",
+                    "type": "string",
+                    "example": "resolved"
+                },
+                "target": {
+                    "description": "the link as written, without brackets: a title or #id",
+                    "type": "string",
+                    "example": "My other Note"
+                }
+            }
+        },
+        "controllers.NotePermissionReply": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AccessLevel"
+                        }
+                    ],
+                    "example": "read"
+                },
+                "role_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "controllers.NoteReply": {
+            "type": "object",
+            "properties": {
+                "access_level": {
+                    "description": "access of the requesting user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AccessLevel"
+                        }
+                    ],
+                    "example": "owner"
+                },
+                "author_id": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notebook_id": {
+                    "description": "null if the note isn't in a notebook",
+                    "type": "integer",
+                    "example": 3
+                },
+                "permissions": {
+                    "description": "who the note is shared with. Other users than the author only see\nthe permissions which grant them access",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.NotePermissionReply"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work",
+                        "ideas"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "number of the current version",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "controllers.NoteVersionContentReply": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "content": {
+                    "type": "string",
+                    "example": "This is the content of my note."
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "restored_from": {
+                    "description": "version which was restored by this one",
+                    "type": "integer",
+                    "example": 1
+                },
+                "title": {
+                    "type": "string",
+                    "example": "My Note"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "controllers.NoteVersionReply": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "restored_from": {
+                    "description": "version which was restored by this one",
+                    "type": "integer",
+                    "example": 1
+                },
+                "title": {
+                    "type": "string",
+                    "example": "My Note"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "controllers.NotebookReply": {
+            "type": "object",
+            "properties": {
+                "access_level": {
+                    "description": "access of the requesting user, including access inherited from parent notebooks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AccessLevel"
+                        }
+                    ],
+                    "example": "owner"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Meetings"
+                },
+                "note_count": {
+                    "description": "number of notes directly inside the notebook",
+                    "type": "integer",
+                    "example": 12
+                },
+                "owner_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "permissions": {
+                    "description": "who the notebook is shared with. Other users than the owner only see\nthe permissions which grant them access",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.NotePermissionReply"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.NotebookTreeReply": {
+            "type": "object",
+            "properties": {
+                "access_level": {
+                    "description": "access of the requesting user, including access inherited from parent notebooks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AccessLevel"
+                        }
+                    ],
+                    "example": "owner"
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.NotebookTreeReply"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "kind": {
+                    "description": "notebook, or smart for a pinned saved search",
+                    "type": "string",
+                    "example": "notebook"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Meetings"
+                },
+                "note_count": {
+                    "description": "number of notes directly inside the notebook",
+                    "type": "integer",
+                    "example": 12
+                },
+                "owner_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "permissions": {
+                    "description": "who the notebook is shared with. Other users than the owner only see\nthe permissions which grant them access",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.NotePermissionReply"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.PatchNoteRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "This is the new content of my note."
+                },
+                "notebook_id": {
+                    "description": "moves the note into another notebook, 0 moves it out of its notebook",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "tags": {
+                    "description": "replaces all tags, an empty list removes them",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work",
+                        "ideas"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "My new Note Title"
+                }
+            }
+        },
+        "controllers.PatchNotebookRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Team Meetings"
+                },
+                "parent_id": {
+                    "description": "moves the notebook, 0 moves it to the top level",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                }
+            }
+        },
+        "controllers.PatchSavedSearchRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Open infra notes"
+                },
+                "notebook_id": {
+                    "description": "0 removes the notebook filter",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "pinned": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "tag:infra -draft"
+                },
+                "recursive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "search_type": {
+                    "enum": [
+                        "context",
+                        "keyword",
+                        "typo_tolerant",
+                        "latest",
+                        "hybrid"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controllers.SearchType"
+                        }
+                    ],
+                    "example": "hybrid"
+                },
+                "tags": {
+                    "description": "replaces all tags",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work"
+                    ]
+                },
+                "weights": {
+                    "description": "replaces all weights",
+                    "type": "array",
+                    "maxItems": 3,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "context:2"
+                    ]
+                }
+            }
+        },
+        "controllers.PatchTagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "job"
+                }
+            }
+        },
+        "controllers.PatchUserRequest": {
+            "type": "object",
+            "properties": {
+                "contact_email": {
+                    "type": "string",
+                    "example": "alice@example.com"
+                },
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Alice"
+                }
+            }
+        },
+        "controllers.PostApiTokenReply": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "stripped_content": {
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string",
+                    "example": "wersu_Xk2v..."
+                }
+            }
+        },
+        "controllers.PostApiTokenRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "optional point in time when the token stops working",
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
+                },
+                "name": {
+                    "description": "name to recognize the token",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "backup script"
+                },
+                "scopes": {
+                    "description": "what the token may be used for",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.Scope"
+                    },
+                    "example": [
+                        "notes:read",
+                        "search"
+                    ]
+                }
+            }
+        },
+        "controllers.PostNoteRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "This is the content of my note."
+                },
+                "notebook_id": {
+                    "description": "notebook to create the note in. Requires write access on it",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work",
+                        "ideas"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "My Note Title"
+                }
+            }
+        },
+        "controllers.PostNotebookRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Meetings"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "controllers.PostSavedSearchRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "search_type"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Open infra notes"
+                },
+                "notebook_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "pinned": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "tag:infra -draft"
+                },
+                "recursive": {
+                    "description": "whether notes in sub notebooks of notebook_id are included, defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "search_type": {
+                    "enum": [
+                        "context",
+                        "keyword",
+                        "typo_tolerant",
+                        "latest",
+                        "hybrid"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controllers.SearchType"
+                        }
+                    ],
+                    "example": "keyword"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work"
+                    ]
+                },
+                "weights": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "context:2"
+                    ]
+                }
+            }
+        },
+        "controllers.PostShareLinkReply": {
+            "type": "object",
+            "properties": {
+                "access_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "has_password": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_accessed_at": {
                     "type": "string"
                 },
+                "note_id": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "Xk2v..."
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/public/notes/Xk2v..."
+                }
+            }
+        },
+        "controllers.PostShareLinkRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "optional point in time when the link stops working",
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
+                },
+                "password": {
+                    "description": "optional password visitors have to enter",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 4,
+                    "example": "correct horse"
+                }
+            }
+        },
+        "controllers.ProblemDetails": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "machine readable error code",
+                    "type": "string",
+                    "example": "NOT_FOUND"
+                },
+                "current_version": {
+                    "description": "current version of a note which was changed in the meantime",
+                    "type": "integer",
+                    "example": 3
+                },
+                "detail": {
+                    "description": "explanation specific to this occurrence of the problem",
+                    "type": "string",
+                    "example": "failed to fetch note via gRPC service: note not found"
+                },
+                "instance": {
+                    "description": "URI reference which identifies this occurrence of the problem",
+                    "type": "string",
+                    "example": "/api/notes/42"
+                },
+                "invalid_params": {
+                    "description": "fields of the request which failed validation",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.InvalidParam"
+                    }
+                },
+                "request_id": {
+                    "description": "ID of the request, also sent in the X-Request-ID header",
+                    "type": "string",
+                    "example": "4f6c3d0e8b1a4c2e9f7d5b3a1c0e8f6d"
+                },
+                "status": {
+                    "description": "HTTP status code",
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "description": "short summary of the problem type",
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "description": "URI reference which identifies the problem type",
+                    "type": "string",
+                    "example": "urn:wersu:problem:not-found"
+                }
+            }
+        },
+        "controllers.ProviderReply": {
+            "type": "object",
+            "properties": {
+                "login_url": {
+                    "type": "string",
+                    "example": "/api/auth/github"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "github"
+                }
+            }
+        },
+        "controllers.PublicNoteReply": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "This is the content of my note."
+                },
                 "title": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "My Note"
                 },
                 "updated_at": {
-                    "description": "ISO 8601 format",
                     "type": "string"
                 }
             }
         },
-        "controllers.NoteReply": {
+        "controllers.PutNotePermissionRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "enum": [
+                        "read",
+                        "comment",
+                        "write"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AccessLevel"
+                        }
+                    ],
+                    "example": "comment"
+                }
+            }
+        },
+        "controllers.RelatedNote": {
             "type": "object",
             "properties": {
+                "access_level": {
+                    "description": "access of the requesting user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AccessLevel"
+                        }
+                    ],
+                    "example": "read"
+                },
                 "author_id": {
                     "type": "integer"
                 },
-                "content": {
-                    "type": "string"
+                "fragments": {
+                    "description": "parts of the content which matched the query, best first. Only set if highlighting was requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.Fragment"
+                    }
+                },
+                "highlighted_title": {
+                    "description": "title with the matched terms between the tags, only set if highlighting was requested",
+                    "type": "string",
+                    "example": "Learning \u003cmark\u003ePython\u003c/mark\u003e"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "matches": {
+                    "description": "how the fused searches ranked the note, only set by hybrid searches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.SearchMatch"
+                    }
+                },
+                "notebook_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "score": {
+                    "description": "cosine similarity of the embeddings, 1 for equal ones",
+                    "type": "number",
+                    "example": 0.83
+                },
+                "stripped_content": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work",
+                        "ideas"
+                    ]
+                },
                 "title": {
                     "type": "string"
                 },
                 "updated_at": {
+                    "description": "ISO 8601 format",
                     "type": "string"
                 }
             }
         },
-        "controllers.PostNoteRequest": {
+        "controllers.RestoreNotesRequest": {
             "type": "object",
             "required": [
-                "content",
-                "title"
+                "ids"
             ],
             "properties": {
-                "content": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        42,
+                        43
+                    ]
+                }
+            }
+        },
+        "controllers.SavedSearchReply": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "name": {
                     "type": "string",
-                    "example": "This is the content of my note."
+                    "example": "Open infra notes"
                 },
-                "title": {
+                "notebook_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "pinned": {
+                    "description": "whether the search is shown as smart notebook in the notebook tree",
+                    "type": "boolean",
+                    "example": true
+                },
+                "query": {
+                    "description": "the query as saved, including filters like tag:infra",
                     "type": "string",
-                    "example": "My Note Title"
+                    "example": "tag:infra -draft"
+                },
+                "recursive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "search_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controllers.SearchType"
+                        }
+                    ],
+                    "example": "keyword"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weights": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "context:2"
+                    ]
+                }
+            }
+        },
+        "controllers.SearchMatch": {
+            "type": "object",
+            "properties": {
+                "rank": {
+                    "description": "1 for the best result",
+                    "type": "integer",
+                    "example": 1
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.87
+                },
+                "search_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controllers.SearchType"
+                        }
+                    ],
+                    "example": "context"
+                }
+            }
+        },
+        "controllers.SearchNotesPage": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.MinimalNote"
+                    }
+                },
+                "next_cursor": {
+                    "description": "cursor of the next page. Empty if there are no more results",
+                    "type": "string",
+                    "example": "eyJ0IjoxLCJxIjoiNDdERVFwajhIQlMiLCJ1IjoxNzY3MjI1NjAwMDAwMDAwMDAwLCJpIjo0Mn0.c2lnbmF0dXJl"
                 }
             }
         },
@@ -245,17 +4044,306 @@ const docTemplate = `{
                 "context",
                 "keyword",
                 "typo_tolerant",
-                "latest"
+                "latest",
+                "hybrid"
             ],
             "x-enum-varnames": [
                 "SearchByContext",
                 "SearchByKeyword",
                 "SearchByTypoTolerant",
-                "SearchByLatest"
+                "SearchByLatest",
+                "SearchByHybrid"
+            ]
+        },
+        "controllers.SessionReply": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "whether this is the session of the request",
+                    "type": "boolean"
+                },
+                "device": {
+                    "type": "string",
+                    "example": "Firefox on Linux"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f1c9a0d5e7b2c4a8d6e0f1b"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ShareLinkReply": {
+            "type": "object",
+            "properties": {
+                "access_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "has_password": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_accessed_at": {
+                    "type": "string"
+                },
+                "note_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.SuggestionsReply": {
+            "type": "object",
+            "properties": {
+                "partial": {
+                    "description": "whether some suggestions are missing, because they didn't arrive in time",
+                    "type": "boolean",
+                    "example": false
+                },
+                "recent_queries": {
+                    "description": "queries of the user's latest searches starting with the prefix, latest first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "description": "tags starting with the prefix. A leading \"tag:\" is ignored",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.TagReply"
+                    }
+                },
+                "titles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.TitleSuggestion"
+                    }
+                }
+            }
+        },
+        "controllers.TagReply": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "work"
+                },
+                "note_count": {
+                    "description": "number of notes using the tag",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "controllers.TitleSuggestion": {
+            "type": "object",
+            "properties": {
+                "note_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Sprint review"
+                }
+            }
+        },
+        "controllers.TrashedNote": {
+            "type": "object",
+            "properties": {
+                "access_level": {
+                    "description": "access of the requesting user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AccessLevel"
+                        }
+                    ],
+                    "example": "read"
+                },
+                "author_id": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "fragments": {
+                    "description": "parts of the content which matched the query, best first. Only set if highlighting was requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.Fragment"
+                    }
+                },
+                "highlighted_title": {
+                    "description": "title with the matched terms between the tags, only set if highlighting was requested",
+                    "type": "string",
+                    "example": "Learning \u003cmark\u003ePython\u003c/mark\u003e"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "description": "how the fused searches ranked the note, only set by hybrid searches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.SearchMatch"
+                    }
+                },
+                "notebook_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "purge_at": {
+                    "description": "when the note is deleted permanently, null if it is kept until the trash is emptied",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "relevance for the query, higher is better. Only set by ranked searches",
+                    "type": "number",
+                    "example": 0.032
+                },
+                "stripped_content": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work",
+                        "ideas"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "ISO 8601 format",
+                    "type": "string"
+                }
+            }
+        },
+        "diff.Op": {
+            "type": "string",
+            "enum": [
+                "equal",
+                "insert",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "Equal",
+                "Insert",
+                "Delete"
+            ]
+        },
+        "diff.Segment": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/diff.Op"
+                        }
+                    ],
+                    "example": "insert"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "new words"
+                }
+            }
+        },
+        "models.AccessLevel": {
+            "type": "string",
+            "enum": [
+                "none",
+                "read",
+                "comment",
+                "write",
+                "owner"
+            ],
+            "x-enum-varnames": [
+                "AccessNone",
+                "AccessRead",
+                "AccessComment",
+                "AccessWrite",
+                "AccessOwner"
+            ]
+        },
+        "models.JsUser": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "contact_email": {
+                    "type": "string"
+                },
+                "discord_id": {
+                    "type": "string"
+                },
+                "discriminator": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "description": "overrides set by the user, empty if not set",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Scope": {
+            "type": "string",
+            "enum": [
+                "notes:read",
+                "notes:write",
+                "search"
+            ],
+            "x-enum-varnames": [
+                "ScopeNotesRead",
+                "ScopeNotesWrite",
+                "ScopeSearch"
             ]
         }
     },
     "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Personal access token, sent as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
         "CookieAuth": {
             "type": "apiKey",
             "name": "discord_auth",
@@ -0,0 +1,56 @@
+package fusion
+
+import "sort"
+
+// K dampens the advantage of the top ranks. 60 is the value proposed with
+// reciprocal rank fusion by Cormack et al. and works well without tuning.
+const K = 60
+
+// Ranking is the result list of a single search, best first
+type Ranking[T comparable] struct {
+	Items []T
+	// factor of the contribution of this ranking, 0 ignores it
+	Weight float64
+}
+
+// Result is an item of the fused ranking
+type Result[T comparable] struct {
+	Item  T
+	Score float64
+	// rank of the item in every ranking, starting at 1, or 0 if the ranking
+	// doesn't contain it
+	Ranks []int
+}
+
+// Reciprocal fuses rankings with weighted reciprocal rank fusion: an item
+// gets weight / (K + rank) from every ranking containing it. Items are only
+// returned once, best first. Ties keep the order in which the items first
+// appear in the rankings.
+func Reciprocal[T comparable](rankings []Ranking[T]) []Result[T] {
+	results := []Result[T]{}
+	index := map[T]int{}
+	for r, ranking := range rankings {
+		if ranking.Weight == 0 {
+			continue
+		}
+		for i, item := range ranking.Items {
+			position, ok := index[item]
+			if !ok {
+				position = len(results)
+				index[item] = position
+				results = append(results, Result[T]{Item: item, Ranks: make([]int, len(rankings))})
+			}
+			result := &results[position]
+			if result.Ranks[r] != 0 {
+				// duplicate within the ranking
+				continue
+			}
+			result.Ranks[r] = i + 1
+			result.Score += ranking.Weight / float64(K+i+1)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
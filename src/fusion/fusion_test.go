@@ -0,0 +1,100 @@
+package fusion
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestReciprocal(t *testing.T) {
+	tests := []struct {
+		name      string
+		rankings  []Ranking[string]
+		wantItems []string
+		wantRanks map[string][]int
+	}{
+		{
+			name:      "no rankings",
+			rankings:  nil,
+			wantItems: []string{},
+		},
+		{
+			name:      "single ranking keeps its order",
+			rankings:  []Ranking[string]{{Items: []string{"a", "b", "c"}, Weight: 1}},
+			wantItems: []string{"a", "b", "c"},
+		},
+		{
+			name: "items in several rankings rise",
+			rankings: []Ranking[string]{
+				{Items: []string{"a", "b", "c"}, Weight: 1},
+				{Items: []string{"c", "d"}, Weight: 1},
+			},
+			wantItems: []string{"c", "a", "b", "d"},
+			wantRanks: map[string][]int{"a": {1, 0}, "c": {3, 1}, "d": {0, 2}},
+		},
+		{
+			name: "ties keep the order of first appearance",
+			rankings: []Ranking[string]{
+				{Items: []string{"a", "b"}, Weight: 1},
+				{Items: []string{"b", "a"}, Weight: 1},
+			},
+			wantItems: []string{"a", "b"},
+		},
+		{
+			name: "weights favor a ranking",
+			rankings: []Ranking[string]{
+				{Items: []string{"a", "b"}, Weight: 1},
+				{Items: []string{"b", "a"}, Weight: 2},
+			},
+			wantItems: []string{"b", "a"},
+		},
+		{
+			name: "zero weight ignores a ranking",
+			rankings: []Ranking[string]{
+				{Items: []string{"a"}, Weight: 1},
+				{Items: []string{"x", "a"}, Weight: 0},
+			},
+			wantItems: []string{"a"},
+			wantRanks: map[string][]int{"a": {1, 0}},
+		},
+		{
+			name: "duplicates within a ranking count once",
+			rankings: []Ranking[string]{
+				{Items: []string{"a", "a", "b"}, Weight: 1},
+			},
+			wantItems: []string{"a", "b"},
+			wantRanks: map[string][]int{"a": {1}, "b": {3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Reciprocal(tt.rankings)
+			items := []string{}
+			for _, result := range results {
+				items = append(items, result.Item)
+				if want, ok := tt.wantRanks[result.Item]; ok && !reflect.DeepEqual(result.Ranks, want) {
+					t.Errorf("ranks of %s = %v, want %v", result.Item, result.Ranks, want)
+				}
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("Reciprocal() = %v, want %v", items, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestReciprocalScore(t *testing.T) {
+	results := Reciprocal([]Ranking[int]{
+		{Items: []int{1, 2}, Weight: 1},
+		{Items: []int{2}, Weight: 0.5},
+	})
+	want := map[int]float64{
+		1: 1.0 / (K + 1),
+		2: 1.0/(K+2) + 0.5/(K+1),
+	}
+	for _, result := range results {
+		if math.Abs(result.Score-want[result.Item]) > 1e-12 {
+			t.Errorf("score of %d = %v, want %v", result.Item, result.Score, want[result.Item])
+		}
+	}
+}
@@ -4,22 +4,33 @@
 // @securityDefinitions.apikey CookieAuth
 // @in cookie
 // @name discord_auth
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Personal access token, sent as "Bearer <token>"
 
 package main
 
 import (
+	"context"
 	"encoding/gob"
 	"log"
+	"time"
 
 	"github.com/KuramaSyu/WerSu-Rest/src/config"
 	"github.com/KuramaSyu/WerSu-Rest/src/controllers"
+	"github.com/KuramaSyu/WerSu-Rest/src/middleware"
 	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/oauth"
+	"github.com/KuramaSyu/WerSu-Rest/src/profile"
 	"github.com/KuramaSyu/WerSu-Rest/src/proto"
 	"github.com/KuramaSyu/WerSu-Rest/src/routes"
+	"github.com/KuramaSyu/WerSu-Rest/src/sessionstore"
+	"github.com/KuramaSyu/WerSu-Rest/src/suggest"
+	"github.com/KuramaSyu/WerSu-Rest/src/trash"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/sessions"
-	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -28,6 +39,7 @@ import (
 func init() {
 	// Register types for session storage
 	gob.Register(models.User{})
+	gob.Register(models.LoginToken{})
 }
 
 func main() {
@@ -36,18 +48,31 @@ func main() {
 
 	// Create router
 	r := gin.Default()
+	if err := r.SetTrustedProxies(appConfig.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	// Configure CORS
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{appConfig.FrontendURL},
 		AllowMethods:     []string{"GET", "POST", "DELETE", "PUT", "PATCH"},
-		AllowHeaders:     []string{"Origin", "Content-Type"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "If-Match", "If-None-Match", middleware.RequestIDHeader},
+		ExposeHeaders:    []string{"ETag", middleware.RequestIDHeader},
 		AllowCredentials: true,
 	}))
 
-	// Setup sessions
-	store := cookie.NewStore([]byte(appConfig.SessionSecret))
-	r.Use(sessions.Sessions("discord_auth", store))
+	// Assign every request an ID, which is reported in error responses
+	r.Use(middleware.RequestID())
+
+	// Setup server side sessions
+	sessionBackend, err := sessionstore.NewBackend(appConfig.SessionStore)
+	if err != nil {
+		log.Fatalf("Failed to create session store: %v", err)
+	}
+	store := sessionstore.NewStore(sessionBackend, appConfig.SessionStore.MaxAge, []byte(appConfig.SessionSecret))
+	go store.Cleanup(context.Background(), time.Hour)
+	r.Use(sessionstore.ClientIP())
+	r.Use(sessions.Sessions(sessionstore.CookieName, store))
 
 	// Setup gRPC connection
 	grpcConn, err := grpc.NewClient(
@@ -63,10 +88,53 @@ func main() {
 	userGrpcClient := proto.NewUserServiceClient(grpcConn)
 	noteGrpcClient := proto.NewNoteServiceClient(grpcConn)
 
+	// Initialize login providers
+	providers := oauth.NewRegistry()
+	for _, providerConfig := range appConfig.OAuthProviders {
+		provider, err := oauth.NewProvider(providerConfig)
+		if err != nil {
+			log.Fatalf("Failed to create login provider %s: %v", providerConfig.Name, err)
+		}
+		if err := providers.Register(provider); err != nil {
+			log.Fatalf("Failed to register login provider: %v", err)
+		}
+	}
+
+	// Refresh profiles of logged in users in the background
+	if appConfig.ProfileRefreshInterval > 0 {
+		refresher := profile.NewRefresher(providers, &userGrpcClient, store)
+		go refresher.Run(context.Background(), appConfig.ProfileRefreshInterval)
+	}
+
+	// Purge notes from the trash once their retention period is over
+	if appConfig.TrashRetention > 0 {
+		purger := trash.NewPurger(&noteGrpcClient, appConfig.TrashRetention)
+		go purger.Run(context.Background(), time.Hour)
+	}
+
 	// Initialize RSET controllers
-	authController := controllers.NewAuthController(appConfig.DiscordOAuthConfig, &userGrpcClient)
+	authController := controllers.NewAuthController(providers, &userGrpcClient, store)
 	noteController := controllers.NewNoteController(&noteGrpcClient)
-	noteSearchController := controllers.NewSearchNoteController(&noteGrpcClient)
+	recentQueries := suggest.NewRecentQueries()
+	noteSearchController := controllers.NewSearchNoteController(
+		&noteGrpcClient,
+		controllers.NewCursorCodec([]byte(appConfig.CursorSecret)),
+		recentQueries,
+	)
+	tokenController := controllers.NewTokenController(&userGrpcClient)
+	sessionController := controllers.NewSessionController(store)
+	userController := controllers.NewUserController(&userGrpcClient, &noteGrpcClient, store)
+	shareLinkController := controllers.NewShareLinkController(&noteGrpcClient)
+	tagController := controllers.NewTagController(&noteGrpcClient)
+	notebookController := controllers.NewNotebookController(&noteGrpcClient)
+	trashController := controllers.NewTrashController(&noteGrpcClient, appConfig.TrashRetention)
+	savedSearchController := controllers.NewSavedSearchController(&noteGrpcClient, noteSearchController)
+	suggestController := controllers.NewSuggestController(
+		&noteGrpcClient,
+		recentQueries,
+		appConfig.SuggestDebounce,
+		appConfig.SuggestTimeout,
+	)
 
 	// Setup routes
 	routes.SetupRouter(
@@ -74,6 +142,15 @@ func main() {
 		authController,
 		noteController,
 		noteSearchController,
+		tokenController,
+		sessionController,
+		userController,
+		shareLinkController,
+		tagController,
+		notebookController,
+		trashController,
+		savedSearchController,
+		suggestController,
 	)
 
 	// Start the server
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader is the header which carries the request ID
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the key under which the request ID is stored in the gin context
+const RequestIDKey = "request_id"
+
+// RequestID assigns every request an ID. An ID sent by the client via the
+// X-Request-ID header is reused, otherwise a random one is generated.
+// The ID is stored in the gin context and echoed in the response header.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = newRequestID()
+		}
+		c.Set(RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// GetRequestID returns the ID of the current request or an empty string
+// if the RequestID middleware is not installed.
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(RequestIDKey)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
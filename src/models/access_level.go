@@ -0,0 +1,27 @@
+package models
+
+// AccessLevel is the access a user has on a note. Every level includes the
+// ones before it.
+type AccessLevel string
+
+const (
+	AccessNone    AccessLevel = "none"
+	AccessRead    AccessLevel = "read"
+	AccessComment AccessLevel = "comment"
+	AccessWrite   AccessLevel = "write"
+	// the author, who may additionally delete and share the note
+	AccessOwner AccessLevel = "owner"
+)
+
+var accessLevelRanks = map[AccessLevel]int{
+	AccessNone:    0,
+	AccessRead:    1,
+	AccessComment: 2,
+	AccessWrite:   3,
+	AccessOwner:   4,
+}
+
+// Includes reports whether the level grants at least the other level
+func (l AccessLevel) Includes(other AccessLevel) bool {
+	return accessLevelRanks[l] >= accessLevelRanks[other]
+}
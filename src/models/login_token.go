@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// LoginToken is the OAuth token of the provider a session was logged in with.
+// It is kept in the server side session to refresh the user profile later on.
+type LoginToken struct {
+	Provider     string
+	Subject      string
+	AccessToken  string
+	TokenType    string
+	RefreshToken string
+	Expiry       time.Time
+}
@@ -0,0 +1,10 @@
+package models
+
+// Scope restricts what a personal access token may be used for
+type Scope string
+
+const (
+	ScopeNotesRead  Scope = "notes:read"
+	ScopeNotesWrite Scope = "notes:write"
+	ScopeSearch     Scope = "search"
+)
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxTags is the maximum number of tags of a note
+const MaxTags = 20
+
+// tags are lower case words, which may contain "-", "_" and "/" for
+// hierarchies like "project/wersu"
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}_/-]{0,31}$`)
+
+// NormalizeTag trims and lower cases a tag and checks that it is valid
+func NormalizeTag(tag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(tag))
+	if !tagPattern.MatchString(normalized) {
+		return "", fmt.Errorf("invalid tag %q: must be at most 32 letters, digits, '-', '_' or '/'", tag)
+	}
+	return normalized, nil
+}
+
+// NormalizeTags normalizes every tag and removes duplicates. The order is kept.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxTags {
+		return nil, fmt.Errorf("too many tags: at most %d are allowed", MaxTags)
+	}
+	return normalized, nil
+}
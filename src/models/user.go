@@ -21,6 +21,8 @@ type User struct {
 	Discriminator string    `json:"discriminator"`
 	Avatar        string    `json:"avatar"`
 	Email         string    `json:"email"`
+	DisplayName   string    `json:"display_name"`
+	ContactEmail  string    `json:"contact_email"`
 }
 
 // GetAvatarURL returns the user's Discord avatar URL
@@ -35,6 +37,8 @@ func (s *User) ParseJS() JsUser {
 		Discriminator: s.Discriminator,
 		Avatar:        s.Avatar,
 		Email:         s.Email,
+		DisplayName:   s.DisplayName,
+		ContactEmail:  s.ContactEmail,
 	}
 }
 
@@ -45,6 +49,9 @@ type JsUser struct {
 	Discriminator string `json:"discriminator"`
 	Avatar        string `json:"avatar"`
 	Email         string `json:"email"`
+	// overrides set by the user, empty if not set
+	DisplayName  string `json:"display_name"`
+	ContactEmail string `json:"contact_email"`
 }
 
 // Parse the return value from disccord
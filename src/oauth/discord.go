@@ -0,0 +1,43 @@
+package oauth
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+// NewDiscordProvider creates a login provider for Discord
+func NewDiscordProvider(cfg config.OAuthProviderConfig) *OAuth2Provider {
+	return &OAuth2Provider{
+		name: cfg.Name,
+		Config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       []string{"identify", "email"},
+			Endpoint:     endpoints.Discord,
+		},
+		UserInfoURL: "https://discord.com/api/users/@me",
+		ParseIdentity: func(body []byte) (*Identity, error) {
+			var d_user models.DiscordUser
+			if err := json.Unmarshal(body, &d_user); err != nil {
+				return nil, err
+			}
+			subject := ""
+			if d_user.DiscordId != 0 {
+				subject = fmt.Sprint(uint64(d_user.DiscordId))
+			}
+			return &Identity{
+				Subject:       subject,
+				Username:      d_user.Username,
+				Discriminator: d_user.Discriminator,
+				Avatar:        d_user.Avatar,
+				Email:         d_user.Email,
+			}, nil
+		},
+	}
+}
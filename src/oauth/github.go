@@ -0,0 +1,88 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+// GitHubProvider is the login provider for GitHub. Since GitHub omits private
+// email addresses from the user info, they are fetched separately.
+type GitHubProvider struct {
+	*OAuth2Provider
+	EmailsURL string
+}
+
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	AvatarURL string `json:"avatar_url"`
+	Email     string `json:"email"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// NewGitHubProvider creates a login provider for GitHub
+func NewGitHubProvider(cfg config.OAuthProviderConfig) *GitHubProvider {
+	return &GitHubProvider{
+		OAuth2Provider: &OAuth2Provider{
+			name: cfg.Name,
+			Config: &oauth2.Config{
+				ClientID:     cfg.ClientID,
+				ClientSecret: cfg.ClientSecret,
+				RedirectURL:  cfg.RedirectURL,
+				Scopes:       []string{"read:user", "user:email"},
+				Endpoint:     endpoints.GitHub,
+			},
+			UserInfoURL: "https://api.github.com/user",
+			ParseIdentity: func(body []byte) (*Identity, error) {
+				var user githubUser
+				if err := json.Unmarshal(body, &user); err != nil {
+					return nil, err
+				}
+				subject := ""
+				if user.ID != 0 {
+					subject = fmt.Sprint(user.ID)
+				}
+				return &Identity{
+					Subject:  subject,
+					Username: user.Login,
+					Avatar:   user.AvatarURL,
+					Email:    user.Email,
+				}, nil
+			},
+		},
+		EmailsURL: "https://api.github.com/user/emails",
+	}
+}
+
+func (p *GitHubProvider) FetchIdentity(ctx context.Context, token *oauth2.Token) (*Identity, error) {
+	identity, err := p.OAuth2Provider.FetchIdentity(ctx, token)
+	if err != nil || identity.Email != "" {
+		return identity, err
+	}
+
+	// the email is private -> use the primary one
+	body, err := getJSON(ctx, p.Config.Client(ctx, token), p.EmailsURL)
+	if err != nil {
+		return nil, err
+	}
+	var emails []githubEmail
+	if err := json.Unmarshal(body, &emails); err != nil {
+		return nil, fmt.Errorf("failed to parse emails: %w", err)
+	}
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			identity.Email = email.Email
+		}
+	}
+	return identity, nil
+}
@@ -0,0 +1,95 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"golang.org/x/oauth2"
+)
+
+func TestGitHubProviderEmailFallback(t *testing.T) {
+	tests := []struct {
+		name        string
+		publicEmail string
+		emails      []githubEmail
+		wantEmail   string
+		wantFetched bool // whether /user/emails is fetched
+	}{
+		{
+			name:        "public email",
+			publicEmail: "octocat@example.com",
+			wantEmail:   "octocat@example.com",
+		},
+		{
+			name: "private email uses the primary verified one",
+			emails: []githubEmail{
+				{Email: "old@example.com", Verified: true},
+				{Email: "octocat@example.com", Primary: true, Verified: true},
+			},
+			wantEmail:   "octocat@example.com",
+			wantFetched: true,
+		},
+		{
+			name: "unverified primary email is ignored",
+			emails: []githubEmail{
+				{Email: "octocat@example.com", Primary: true},
+				{Email: "other@example.com", Verified: true},
+			},
+			wantEmail:   "",
+			wantFetched: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetched := false
+			server := newTestServer(t, map[string]http.HandlerFunc{
+				"/user": requireToken(t, "access", githubUser{
+					ID:        583231,
+					Login:     "octocat",
+					AvatarURL: "https://avatars.example.com/u/583231",
+					Email:     tt.publicEmail,
+				}),
+				"/user/emails": func(w http.ResponseWriter, r *http.Request) {
+					fetched = true
+					requireToken(t, "access", tt.emails)(w, r)
+				},
+			})
+			provider := NewGitHubProvider(config.OAuthProviderConfig{Name: "github"})
+			provider.UserInfoURL = server.URL + "/user"
+			provider.EmailsURL = server.URL + "/user/emails"
+
+			identity, err := provider.FetchIdentity(context.Background(), &oauth2.Token{AccessToken: "access"})
+			if err != nil {
+				t.Fatalf("FetchIdentity() error = %v", err)
+			}
+			if identity.Subject != "583231" || identity.Username != "octocat" || identity.Provider != "github" {
+				t.Errorf("FetchIdentity() = %+v, want octocat with ID 583231", identity)
+			}
+			if identity.Email != tt.wantEmail {
+				t.Errorf("Email = %q, want %q", identity.Email, tt.wantEmail)
+			}
+			if fetched != tt.wantFetched {
+				t.Errorf("emails fetched = %v, want %v", fetched, tt.wantFetched)
+			}
+		})
+	}
+}
+
+func TestGitHubProviderEmailsError(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/user": requireToken(t, "access", githubUser{ID: 583231, Login: "octocat"}),
+		"/user/emails": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		},
+	})
+	provider := NewGitHubProvider(config.OAuthProviderConfig{Name: "github"})
+	provider.UserInfoURL = server.URL + "/user"
+	provider.EmailsURL = server.URL + "/user/emails"
+
+	identity, err := provider.FetchIdentity(context.Background(), &oauth2.Token{AccessToken: "access"})
+	if err == nil {
+		t.Errorf("FetchIdentity() = %+v, want error", identity)
+	}
+}
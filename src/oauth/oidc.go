@@ -0,0 +1,160 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+// oidcUserInfo contains the standard claims of an OpenID Connect user info response
+type oidcUserInfo struct {
+	Subject           string `json:"sub"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Picture           string `json:"picture"`
+	Email             string `json:"email"`
+}
+
+// oidcDiscovery contains the used fields of an OpenID Connect discovery document
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// parseOIDCUserInfo converts an OpenID Connect user info response into an Identity
+func parseOIDCUserInfo(body []byte) (*Identity, error) {
+	var info oidcUserInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, err
+	}
+	username := info.PreferredUsername
+	if username == "" {
+		username = info.Name
+	}
+	return &Identity{
+		Subject:  info.Subject,
+		Username: username,
+		Avatar:   info.Picture,
+		Email:    info.Email,
+	}, nil
+}
+
+// NewGoogleProvider creates a login provider for Google
+func NewGoogleProvider(cfg config.OAuthProviderConfig) *OAuth2Provider {
+	return &OAuth2Provider{
+		name: cfg.Name,
+		Config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       []string{"openid", "profile", "email"},
+			Endpoint:     endpoints.Google,
+		},
+		UserInfoURL:   "https://openidconnect.googleapis.com/v1/userinfo",
+		ParseIdentity: parseOIDCUserInfo,
+	}
+}
+
+// OIDCProvider is a login provider for any OpenID Connect issuer. Its endpoints
+// are discovered on first use, so an unreachable issuer doesn't prevent startup.
+type OIDCProvider struct {
+	cfg      config.OAuthProviderConfig
+	mu       sync.Mutex
+	provider *OAuth2Provider // nil until discovered
+}
+
+// NewOIDCProvider creates a login provider for the issuer cfg.IssuerURL
+func NewOIDCProvider(cfg config.OAuthProviderConfig) *OIDCProvider {
+	return &OIDCProvider{cfg: cfg}
+}
+
+func (p *OIDCProvider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *OIDCProvider) AuthCodeURL(state string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return provider.AuthCodeURL(state)
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Exchange(ctx, code)
+}
+
+func (p *OIDCProvider) FetchIdentity(ctx context.Context, token *oauth2.Token) (*Identity, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.FetchIdentity(ctx, token)
+}
+
+func (p *OIDCProvider) Refresh(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Refresh(ctx, token)
+}
+
+// discover fetches the discovery document of the issuer once it succeeded
+func (p *OIDCProvider) discover(ctx context.Context) (*OAuth2Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.provider != nil {
+		return p.provider, nil
+	}
+
+	issuer := strings.TrimSuffix(p.cfg.IssuerURL, "/")
+	body, err := getJSON(ctx, http.DefaultClient, issuer+"/.well-known/openid-configuration")
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OpenID Connect issuer: %w", err)
+	}
+	var discovery oidcDiscovery
+	if err := json.Unmarshal(body, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenID Connect discovery document: %w", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("issuer mismatch: expected %s, got %s", issuer, discovery.Issuer)
+	}
+	if discovery.UserInfoEndpoint == "" {
+		return nil, fmt.Errorf("issuer %s has no userinfo endpoint", issuer)
+	}
+
+	p.provider = &OAuth2Provider{
+		name: p.cfg.Name,
+		Config: &oauth2.Config{
+			ClientID:     p.cfg.ClientID,
+			ClientSecret: p.cfg.ClientSecret,
+			RedirectURL:  p.cfg.RedirectURL,
+			Scopes:       []string{"openid", "profile", "email"},
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  discovery.AuthorizationEndpoint,
+				TokenURL: discovery.TokenEndpoint,
+			},
+		},
+		UserInfoURL:   discovery.UserInfoEndpoint,
+		ParseIdentity: parseOIDCUserInfo,
+	}
+	return p.provider, nil
+}
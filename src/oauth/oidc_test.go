@@ -0,0 +1,102 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"golang.org/x/oauth2"
+)
+
+// newOIDCServer serves an OpenID Connect issuer. The issuer in the discovery
+// document is the URL of the server followed by issuerPath.
+func newOIDCServer(t *testing.T, issuerPath string, discoveries *int) *httptest.Server {
+	var server *httptest.Server
+	server = newTestServer(t, map[string]http.HandlerFunc{
+		"/.well-known/openid-configuration": func(w http.ResponseWriter, r *http.Request) {
+			*discoveries++
+			writeJSON(t, w, oidcDiscovery{
+				Issuer:                server.URL + issuerPath,
+				AuthorizationEndpoint: server.URL + "/authorize",
+				TokenEndpoint:         server.URL + "/token",
+				UserInfoEndpoint:      server.URL + "/userinfo",
+			})
+		},
+		"/userinfo": requireToken(t, "access", oidcUserInfo{
+			Subject: "f3a1c1d0",
+			Name:    "Nelly Example",
+			Picture: "https://sso.example.com/avatar.png",
+			Email:   "nelly@example.com",
+		}),
+	})
+	return server
+}
+
+func TestOIDCProviderDiscovery(t *testing.T) {
+	discoveries := 0
+	server := newOIDCServer(t, "/", &discoveries)
+	provider := NewOIDCProvider(config.OAuthProviderConfig{
+		Name:        "keycloak",
+		ClientID:    "id",
+		RedirectURL: "http://localhost:8080/api/auth/keycloak/callback",
+		// a trailing slash on either side doesn't matter
+		IssuerURL: server.URL,
+	})
+
+	authURL, err := provider.AuthCodeURL("the-state")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed.Scheme + "://" + parsed.Host + parsed.Path; got != server.URL+"/authorize" {
+		t.Errorf("AuthCodeURL() points to %s, want %s/authorize", got, server.URL)
+	}
+	if got := parsed.Query().Get("state"); got != "the-state" {
+		t.Errorf("state = %q, want the-state", got)
+	}
+
+	identity, err := provider.FetchIdentity(context.Background(), &oauth2.Token{AccessToken: "access"})
+	if err != nil {
+		t.Fatalf("FetchIdentity() error = %v", err)
+	}
+	want := Identity{
+		Provider: "keycloak",
+		Subject:  "f3a1c1d0",
+		Username: "Nelly Example",
+		Avatar:   "https://sso.example.com/avatar.png",
+		Email:    "nelly@example.com",
+	}
+	if *identity != want {
+		t.Errorf("FetchIdentity() = %+v, want %+v", *identity, want)
+	}
+	if discoveries != 1 {
+		t.Errorf("discovery document fetched %d times, want once", discoveries)
+	}
+}
+
+func TestOIDCProviderIssuerMismatch(t *testing.T) {
+	discoveries := 0
+	server := newOIDCServer(t, "/realms/other", &discoveries)
+	provider := NewOIDCProvider(config.OAuthProviderConfig{Name: "keycloak", IssuerURL: server.URL})
+
+	_, err := provider.AuthCodeURL("the-state")
+	if err == nil || !strings.Contains(err.Error(), "issuer mismatch") {
+		t.Fatalf("AuthCodeURL() error = %v, want issuer mismatch", err)
+	}
+
+	// a failed discovery is retried on the next use
+	_, err = provider.FetchIdentity(context.Background(), &oauth2.Token{AccessToken: "access"})
+	if err == nil {
+		t.Fatal("FetchIdentity() error = nil, want issuer mismatch")
+	}
+	if discoveries != 2 {
+		t.Errorf("discovery document fetched %d times, want twice", discoveries)
+	}
+}
@@ -0,0 +1,111 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// Identity is a user as reported by a login provider
+type Identity struct {
+	// name of the provider, e.g. discord
+	Provider string
+	// stable ID of the user at the provider
+	Subject       string
+	Username      string
+	Discriminator string // only used by Discord
+	Avatar        string // Discord avatar hash or avatar URL
+	Email         string
+}
+
+// Provider is a login provider which implements the OAuth2 authorization code flow
+type Provider interface {
+	// Name identifies the provider in routes, e.g. /api/auth/{name}
+	Name() string
+
+	// AuthCodeURL returns the URL of the consent page of the provider
+	AuthCodeURL(state string) (string, error)
+
+	// Exchange converts an authorization code into a token
+	Exchange(ctx context.Context, code string) (*oauth2.Token, error)
+
+	// FetchIdentity fetches the identity of the user the token belongs to
+	FetchIdentity(ctx context.Context, token *oauth2.Token) (*Identity, error)
+
+	// Refresh returns the token if it is still valid, otherwise a new one
+	// obtained with its refresh token
+	Refresh(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error)
+}
+
+// OAuth2Provider is a Provider which fetches the identity from a user info
+// endpoint and converts it using ParseIdentity.
+// Config and UserInfoURL may be pointed to a local server for testing.
+type OAuth2Provider struct {
+	name          string
+	Config        *oauth2.Config
+	UserInfoURL   string
+	ParseIdentity func(body []byte) (*Identity, error)
+}
+
+func (p *OAuth2Provider) Name() string {
+	return p.name
+}
+
+func (p *OAuth2Provider) AuthCodeURL(state string) (string, error) {
+	return p.Config.AuthCodeURL(state), nil
+}
+
+func (p *OAuth2Provider) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	return p.Config.Exchange(ctx, code)
+}
+
+func (p *OAuth2Provider) Refresh(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	return p.Config.TokenSource(ctx, token).Token()
+}
+
+func (p *OAuth2Provider) FetchIdentity(ctx context.Context, token *oauth2.Token) (*Identity, error) {
+	body, err := getJSON(ctx, p.Config.Client(ctx, token), p.UserInfoURL)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := p.ParseIdentity(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse user info: %w", err)
+	}
+	identity.Provider = p.name
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("user info of %s contains no user ID", p.name)
+	}
+	return identity, nil
+}
+
+// getJSON fetches a JSON document and returns its raw body
+func getJSON(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s: status %d", url, resp.StatusCode)
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("failed to get %s: response is no JSON", url)
+	}
+	return body, nil
+}
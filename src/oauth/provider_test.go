@@ -0,0 +1,144 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"golang.org/x/oauth2"
+)
+
+// newTestServer serves the handlers and fails the test for unknown paths
+func newTestServer(t *testing.T, handlers map[string]http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// writeJSON responds with value as JSON
+func writeJSON(t *testing.T, w http.ResponseWriter, value any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		t.Errorf("failed to write response: %v", err)
+	}
+}
+
+// requireToken returns a handler which fails the test unless the request
+// carries the access token
+func requireToken(t *testing.T, token string, body any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer "+token {
+			t.Errorf("Authorization = %q, want Bearer %s", got, token)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(t, w, body)
+	}
+}
+
+func TestOAuth2ProviderExchangeAndFetchIdentity(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/token": func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			if got := r.PostForm.Get("code"); got != "the-code" {
+				t.Errorf("code = %q, want the-code", got)
+			}
+			writeJSON(t, w, map[string]any{
+				"access_token":  "access",
+				"refresh_token": "refresh",
+				"token_type":    "Bearer",
+				"expires_in":    3600,
+			})
+		},
+		"/users/@me": requireToken(t, "access", map[string]any{
+			"id":            "80351110224678912",
+			"username":      "nelly",
+			"discriminator": "1337",
+			"avatar":        "8342729096ea3675442027381ff50dfe",
+			"email":         "nelly@example.com",
+		}),
+	})
+
+	provider := NewDiscordProvider(config.OAuthProviderConfig{Name: "discord", ClientID: "id", ClientSecret: "secret"})
+	provider.Config.Endpoint = oauth2.Endpoint{AuthURL: server.URL + "/authorize", TokenURL: server.URL + "/token"}
+	provider.UserInfoURL = server.URL + "/users/@me"
+
+	ctx := context.Background()
+	token, err := provider.Exchange(ctx, "the-code")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" {
+		t.Fatalf("Exchange() = %+v, want access and refresh token", token)
+	}
+
+	identity, err := provider.FetchIdentity(ctx, token)
+	if err != nil {
+		t.Fatalf("FetchIdentity() error = %v", err)
+	}
+	want := Identity{
+		Provider:      "discord",
+		Subject:       "80351110224678912",
+		Username:      "nelly",
+		Discriminator: "1337",
+		Avatar:        "8342729096ea3675442027381ff50dfe",
+		Email:         "nelly@example.com",
+	}
+	if *identity != want {
+		t.Errorf("FetchIdentity() = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestOAuth2ProviderFetchIdentityErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "missing user ID",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, map[string]any{"username": "nelly"})
+			},
+		},
+		{
+			name: "error status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				writeJSON(t, w, map[string]any{"message": "401: Unauthorized"})
+			},
+		},
+		{
+			name: "no JSON",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("<html></html>"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, map[string]http.HandlerFunc{"/users/@me": tt.handler})
+			provider := NewDiscordProvider(config.OAuthProviderConfig{Name: "discord"})
+			provider.UserInfoURL = server.URL + "/users/@me"
+
+			identity, err := provider.FetchIdentity(context.Background(), &oauth2.Token{AccessToken: "access"})
+			if err == nil {
+				t.Errorf("FetchIdentity() = %+v, want error", identity)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package oauth
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+)
+
+// Registry holds all configured login providers by name
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry() *Registry {
+	return &Registry{providers: map[string]Provider{}}
+}
+
+// Register adds a provider. It fails if a provider with the same name is
+// registered already.
+func (r *Registry) Register(provider Provider) error {
+	if _, ok := r.providers[provider.Name()]; ok {
+		return fmt.Errorf("login provider %q is registered already", provider.Name())
+	}
+	r.providers[provider.Name()] = provider
+	return nil
+}
+
+// Get returns the provider with the given name
+func (r *Registry) Get(name string) (Provider, bool) {
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+// Names returns the names of all registered providers in alphabetical order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewProvider creates the provider described by the configuration
+func NewProvider(cfg config.OAuthProviderConfig) (Provider, error) {
+	switch cfg.Kind {
+	case config.ProviderDiscord:
+		return NewDiscordProvider(cfg), nil
+	case config.ProviderGitHub:
+		return NewGitHubProvider(cfg), nil
+	case config.ProviderGoogle:
+		return NewGoogleProvider(cfg), nil
+	case config.ProviderOIDC:
+		return NewOIDCProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown provider kind %q", cfg.Kind)
+	}
+}
@@ -0,0 +1,26 @@
+package oauth
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+)
+
+func TestRegistryRejectsDuplicateNames(t *testing.T) {
+	registry := NewRegistry()
+	github := NewGitHubProvider(config.OAuthProviderConfig{Kind: config.ProviderGitHub, Name: "github"})
+	if err := registry.Register(github); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	oidc := NewOIDCProvider(config.OAuthProviderConfig{Kind: config.ProviderOIDC, Name: "github", IssuerURL: "https://sso.example.com"})
+	if err := registry.Register(oidc); err == nil {
+		t.Error("Register() of a second provider named github error = nil, want an error")
+	}
+	if provider, _ := registry.Get("github"); provider != github {
+		t.Error("Register() replaced the registered provider")
+	}
+	if names := registry.Names(); !reflect.DeepEqual(names, []string{"github"}) {
+		t.Errorf("Names() = %v, want [github]", names)
+	}
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/oauth"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"golang.org/x/oauth2"
+)
+
+// LoginTokenKey is the session key of the models.LoginToken of the provider
+// the session was logged in with
+const LoginTokenKey = "login_token"
+
+// Changes returns the request which updates the user to the profile reported
+// by the login provider, or nil if the user is up to date. Fields the provider
+// doesn't report, e.g. private email addresses, are left unchanged. Only the
+// profile identity of the user is synced, so users with several linked
+// identities don't get a different profile with every login.
+func Changes(user *proto.User, identity *oauth.Identity) *proto.AlterUserRequest {
+	primary := user.ProfileIdentity
+	if primary == nil || primary.Provider != identity.Provider || primary.Subject != identity.Subject {
+		return nil
+	}
+	request := &proto.AlterUserRequest{Id: user.Id}
+	changed := false
+	update := func(current string, reported string, field **string) {
+		if reported != "" && reported != current {
+			*field = &reported
+			changed = true
+		}
+	}
+	update(user.Username, identity.Username, &request.Username)
+	update(user.Avatar, identity.Avatar, &request.Avatar)
+	update(user.Email, identity.Email, &request.Email)
+	update(user.Discriminator, identity.Discriminator, &request.Discriminator)
+	if !changed {
+		return nil
+	}
+	return request
+}
+
+// Sync pushes changes of the provider profile to the gRPC service and returns
+// the updated user. The user is returned unchanged if it is up to date or the
+// identity isn't its profile identity.
+func Sync(ctx context.Context, userService *proto.UserServiceClient, user *proto.User, identity *oauth.Identity) (*proto.User, error) {
+	request := Changes(user, identity)
+	if request == nil {
+		return user, nil
+	}
+	updated, err := (*userService).AlterUser(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to alter user via gRPC service: %w", err)
+	}
+	return updated, nil
+}
+
+// NewLoginToken creates the login token stored in the session
+func NewLoginToken(identity *oauth.Identity, token *oauth2.Token) models.LoginToken {
+	return models.LoginToken{
+		Provider:     identity.Provider,
+		Subject:      identity.Subject,
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	}
+}
+
+// oauth2Token converts a login token back into an OAuth token
+func oauth2Token(token models.LoginToken) *oauth2.Token {
+	return &oauth2.Token{
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	}
+}
@@ -0,0 +1,101 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/oauth"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+)
+
+func TestChanges(t *testing.T) {
+	user := func() *proto.User {
+		return &proto.User{
+			Id:              1,
+			Username:        "alice",
+			Avatar:          "avatar",
+			Email:           "alice@example.com",
+			ProfileIdentity: &proto.Identity{Provider: "discord", Subject: "42"},
+		}
+	}
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name     string
+		user     *proto.User
+		identity oauth.Identity
+		want     *proto.AlterUserRequest
+	}{
+		{
+			name:     "up to date",
+			user:     user(),
+			identity: oauth.Identity{Provider: "discord", Subject: "42", Username: "alice", Avatar: "avatar", Email: "alice@example.com"},
+			want:     nil,
+		},
+		{
+			name:     "changed fields",
+			user:     user(),
+			identity: oauth.Identity{Provider: "discord", Subject: "42", Username: "alicia", Avatar: "new", Email: "alice@example.com", Discriminator: "0001"},
+			want:     &proto.AlterUserRequest{Id: 1, Username: str("alicia"), Avatar: str("new"), Discriminator: str("0001")},
+		},
+		{
+			name:     "unreported fields are kept",
+			user:     user(),
+			identity: oauth.Identity{Provider: "discord", Subject: "42", Username: "alicia"},
+			want:     &proto.AlterUserRequest{Id: 1, Username: str("alicia")},
+		},
+		{
+			name:     "other provider",
+			user:     user(),
+			identity: oauth.Identity{Provider: "github", Subject: "42", Username: "alice-gh", Email: "gh@example.com"},
+			want:     nil,
+		},
+		{
+			name:     "other account of the provider",
+			user:     user(),
+			identity: oauth.Identity{Provider: "discord", Subject: "43", Username: "bob"},
+			want:     nil,
+		},
+		{
+			name:     "no profile identity",
+			user:     &proto.User{Id: 1, Username: "alice"},
+			identity: oauth.Identity{Provider: "discord", Subject: "42", Username: "alicia"},
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Changes(tt.user, &tt.identity)
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("Changes() = %v, want %v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if got.Id != tt.want.Id {
+				t.Errorf("Id = %d, want %d", got.Id, tt.want.Id)
+			}
+			fields := []struct {
+				name      string
+				got, want *string
+			}{
+				{"Username", got.Username, tt.want.Username},
+				{"Avatar", got.Avatar, tt.want.Avatar},
+				{"Email", got.Email, tt.want.Email},
+				{"Discriminator", got.Discriminator, tt.want.Discriminator},
+			}
+			for _, field := range fields {
+				if (field.got == nil) != (field.want == nil) || (field.got != nil && *field.got != *field.want) {
+					t.Errorf("%s = %v, want %v", field.name, deref(field.got), deref(field.want))
+				}
+			}
+		})
+	}
+}
+
+// deref returns the value of an optional field for error messages
+func deref(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
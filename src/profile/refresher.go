@@ -0,0 +1,176 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	"github.com/KuramaSyu/WerSu-Rest/src/oauth"
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"github.com/KuramaSyu/WerSu-Rest/src/sessionstore"
+	"golang.org/x/oauth2"
+)
+
+// Refresher periodically refreshes the profiles of logged in users, using the
+// login tokens stored in their sessions
+type Refresher struct {
+	providers   *oauth.Registry
+	userService *proto.UserServiceClient
+	sessions    *sessionstore.Store
+}
+
+func NewRefresher(providers *oauth.Registry, userService *proto.UserServiceClient, sessions *sessionstore.Store) *Refresher {
+	return &Refresher{
+		providers:   providers,
+		userService: userService,
+		sessions:    sessions,
+	}
+}
+
+// Run refreshes all profiles every interval until ctx is done
+func (r *Refresher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.RefreshAll(ctx)
+		}
+	}
+}
+
+// RefreshAll refreshes the profile of every session holding a login token.
+// Every provider identity is only fetched once, even if it is used by
+// several sessions.
+func (r *Refresher) RefreshAll(ctx context.Context) {
+	records, err := r.sessions.List()
+	if err != nil {
+		log.Printf("Failed to list sessions for profile refresh: %v", err)
+		return
+	}
+
+	refreshed := map[string]bool{} // provider/subject of refreshed identities
+	for _, record := range records {
+		if record.UserID == 0 {
+			continue
+		}
+		values, err := record.Values()
+		if err != nil {
+			continue
+		}
+		token, ok := values[LoginTokenKey].(models.LoginToken)
+		if !ok {
+			continue
+		}
+		identityKey := token.Provider + "/" + token.Subject
+		if refreshed[identityKey] {
+			continue
+		}
+		if err := r.refresh(ctx, record, token); err != nil {
+			log.Printf("Failed to refresh profile of user %d via %s: %v", record.UserID, token.Provider, err)
+			continue
+		}
+		refreshed[identityKey] = true
+	}
+}
+
+// refresh fetches the provider profile of a session and updates the user and
+// all of its sessions if it changed
+func (r *Refresher) refresh(ctx context.Context, record *sessionstore.Record, token models.LoginToken) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	provider, ok := r.providers.Get(token.Provider)
+	if !ok {
+		// provider was removed from the configuration
+		return r.dropLoginToken(record.ID, token)
+	}
+
+	// renew the access token if it expired
+	current, err := provider.Refresh(ctx, oauth2Token(token))
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		// the refresh token was revoked or expired, so don't try again
+		if err := r.dropLoginToken(record.ID, token); err != nil {
+			return err
+		}
+		return retrieveErr
+	}
+	if err != nil {
+		return err
+	}
+	if current.AccessToken != token.AccessToken {
+		renewed := NewLoginToken(&oauth.Identity{Provider: token.Provider, Subject: token.Subject}, current)
+		if renewed.RefreshToken == "" {
+			renewed.RefreshToken = token.RefreshToken
+		}
+		err := r.sessions.Update(record.ID, func(values map[interface{}]interface{}) bool {
+			if !hasLoginToken(values, token) {
+				return false
+			}
+			values[LoginTokenKey] = renewed
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	identity, err := provider.FetchIdentity(ctx, current)
+	if err != nil {
+		return err
+	}
+	user, err := (*r.userService).GetUser(ctx, &proto.GetUserRequest{Id: &record.UserID})
+	if err != nil {
+		return err
+	}
+	updated, err := Sync(ctx, r.userService, user, identity)
+	if err != nil || updated == user {
+		return err
+	}
+
+	// update the user of the sessions logged in with this identity. Other
+	// sessions get the new profile on their next refresh or login
+	sessions, err := r.sessions.ListByUser(updated.Id)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		err := r.sessions.Update(session.ID, func(values map[interface{}]interface{}) bool {
+			if !hasLoginToken(values, token) {
+				return false
+			}
+			values["user"] = updated.ToModel()
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("Refreshed profile of user %d via %s", updated.Id, token.Provider)
+	return nil
+}
+
+// dropLoginToken removes the login token from a session, so it is no longer
+// refreshed. A token of another identity, e.g. after the session logged in
+// again, is kept.
+func (r *Refresher) dropLoginToken(id string, token models.LoginToken) error {
+	return r.sessions.Update(id, func(values map[interface{}]interface{}) bool {
+		if !hasLoginToken(values, token) {
+			return false
+		}
+		delete(values, LoginTokenKey)
+		return true
+	})
+}
+
+// hasLoginToken reports whether the session values hold a login token of the
+// same provider identity as token
+func hasLoginToken(values map[interface{}]interface{}, token models.LoginToken) bool {
+	current, ok := values[LoginTokenKey].(models.LoginToken)
+	return ok && current.Provider == token.Provider && current.Subject == token.Subject
+}
@@ -31,6 +31,10 @@ const (
 	GetSearchNotesRequest_FullTextTitle GetSearchNotesRequest_SearchType = 2 // exact match search
 	GetSearchNotesRequest_Fuzzy         GetSearchNotesRequest_SearchType = 3 // typo tolerant search
 	GetSearchNotesRequest_Context       GetSearchNotesRequest_SearchType = 4 // semantic search using embeddings
+	// fuses the rankings of FullTextTitle, Fuzzy and Context with weighted
+	// reciprocal rank fusion. The REST service computes it out of the
+	// single searches
+	GetSearchNotesRequest_Hybrid GetSearchNotesRequest_SearchType = 5
 )
 
 // Enum value maps for GetSearchNotesRequest_SearchType.
@@ -41,6 +45,7 @@ var (
 		2: "FullTextTitle",
 		3: "Fuzzy",
 		4: "Context",
+		5: "Hybrid",
 	}
 	GetSearchNotesRequest_SearchType_value = map[string]int32{
 		"Undefined":     0,
@@ -48,6 +53,7 @@ var (
 		"FullTextTitle": 2,
 		"Fuzzy":         3,
 		"Context":       4,
+		"Hybrid":        5,
 	}
 )
 
@@ -78,6 +84,108 @@ func (GetSearchNotesRequest_SearchType) EnumDescriptor() ([]byte, []int) {
 	return file_src_proto_note_proto_rawDescGZIP(), []int{1, 0}
 }
 
+// every level includes the ones before it
+type NotePermission_Level int32
+
+const (
+	NotePermission_None    NotePermission_Level = 0
+	NotePermission_Read    NotePermission_Level = 1
+	NotePermission_Comment NotePermission_Level = 2
+	NotePermission_Write   NotePermission_Level = 3 // alter title and content
+)
+
+// Enum value maps for NotePermission_Level.
+var (
+	NotePermission_Level_name = map[int32]string{
+		0: "None",
+		1: "Read",
+		2: "Comment",
+		3: "Write",
+	}
+	NotePermission_Level_value = map[string]int32{
+		"None":    0,
+		"Read":    1,
+		"Comment": 2,
+		"Write":   3,
+	}
+)
+
+func (x NotePermission_Level) Enum() *NotePermission_Level {
+	p := new(NotePermission_Level)
+	*p = x
+	return p
+}
+
+func (x NotePermission_Level) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotePermission_Level) Descriptor() protoreflect.EnumDescriptor {
+	return file_src_proto_note_proto_enumTypes[1].Descriptor()
+}
+
+func (NotePermission_Level) Type() protoreflect.EnumType {
+	return &file_src_proto_note_proto_enumTypes[1]
+}
+
+func (x NotePermission_Level) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotePermission_Level.Descriptor instead.
+func (NotePermission_Level) EnumDescriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{10, 0}
+}
+
+type ResolvedNoteLink_Status int32
+
+const (
+	ResolvedNoteLink_Dangling  ResolvedNoteLink_Status = 0 // no note the user can read matches the link
+	ResolvedNoteLink_Resolved  ResolvedNoteLink_Status = 1
+	ResolvedNoteLink_Ambiguous ResolvedNoteLink_Status = 2 // several notes have the title, note is the latest updated
+)
+
+// Enum value maps for ResolvedNoteLink_Status.
+var (
+	ResolvedNoteLink_Status_name = map[int32]string{
+		0: "Dangling",
+		1: "Resolved",
+		2: "Ambiguous",
+	}
+	ResolvedNoteLink_Status_value = map[string]int32{
+		"Dangling":  0,
+		"Resolved":  1,
+		"Ambiguous": 2,
+	}
+)
+
+func (x ResolvedNoteLink_Status) Enum() *ResolvedNoteLink_Status {
+	p := new(ResolvedNoteLink_Status)
+	*p = x
+	return p
+}
+
+func (x ResolvedNoteLink_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResolvedNoteLink_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_src_proto_note_proto_enumTypes[2].Descriptor()
+}
+
+func (ResolvedNoteLink_Status) Type() protoreflect.EnumType {
+	return &file_src_proto_note_proto_enumTypes[2]
+}
+
+func (x ResolvedNoteLink_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResolvedNoteLink_Status.Descriptor instead.
+func (ResolvedNoteLink_Status) EnumDescriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{56, 0}
+}
+
 // Request for getting a note by id
 type GetNoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -140,7 +248,34 @@ type GetSearchNotesRequest struct {
 	Limit  int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	Offset int32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
 	// authentication
-	UserId        int32 `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserId int32 `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Keyset pagination: only return notes positioned after this cursor.
+	// Takes precedence over offset
+	After *SearchCursor `protobuf:"bytes,6,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// only return notes having all of these tags. Applies to every search type
+	Tags []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// only return notes inside this notebook, and its sub notebooks if
+	// include_sub_notebooks is set
+	NotebookId          *int32 `protobuf:"varint,8,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"`
+	IncludeSubNotebooks bool   `protobuf:"varint,9,opt,name=include_sub_notebooks,json=includeSubNotebooks,proto3" json:"include_sub_notebooks,omitempty"`
+	// weights of the searches fused by a Hybrid search. Search types which
+	// aren't listed get weight 1, weight 0 leaves them out
+	Weights []*SearchWeight `protobuf:"bytes,10,rep,name=weights,proto3" json:"weights,omitempty"`
+	// highlight the matched terms in the results, unset disables it
+	Highlight *HighlightOptions `protobuf:"bytes,11,opt,name=highlight,proto3,oneof" json:"highlight,omitempty"`
+	// only return notes updated in this range. updated_after is inclusive,
+	// updated_before exclusive
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_after,json=updatedAfter,proto3,oneof" json:"updated_after,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_before,json=updatedBefore,proto3,oneof" json:"updated_before,omitempty"`
+	// only return notes written by one of these users, empty for any author
+	AuthorIds []int32 `protobuf:"varint,14,rep,packed,name=author_ids,json=authorIds,proto3" json:"author_ids,omitempty"`
+	// only return notes having none of these tags
+	ExcludedTags []string `protobuf:"bytes,15,rep,name=excluded_tags,json=excludedTags,proto3" json:"excluded_tags,omitempty"`
+	// only return notes whose title contains all of these phrases, ignoring case
+	TitlePhrases []string `protobuf:"bytes,16,rep,name=title_phrases,json=titlePhrases,proto3" json:"title_phrases,omitempty"`
+	// only return notes whose title and content contain none of these words
+	// or phrases, ignoring case
+	ExcludedTerms []string `protobuf:"bytes,17,rep,name=excluded_terms,json=excludedTerms,proto3" json:"excluded_terms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -210,112 +345,119 @@ func (x *GetSearchNotesRequest) GetUserId() int32 {
 	return 0
 }
 
-// Response: represents a minimal Note for search results
-type MinimalNote struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // Note ID (eg 42)
-	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	AuthorId        int32                  `protobuf:"varint,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	StrippedContent string                 `protobuf:"bytes,5,opt,name=stripped_content,json=strippedContent,proto3" json:"stripped_content,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *GetSearchNotesRequest) GetAfter() *SearchCursor {
+	if x != nil {
+		return x.After
+	}
+	return nil
 }
 
-func (x *MinimalNote) Reset() {
-	*x = MinimalNote{}
-	mi := &file_src_proto_note_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *GetSearchNotesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
 }
 
-func (x *MinimalNote) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetSearchNotesRequest) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
 }
 
-func (*MinimalNote) ProtoMessage() {}
+func (x *GetSearchNotesRequest) GetIncludeSubNotebooks() bool {
+	if x != nil {
+		return x.IncludeSubNotebooks
+	}
+	return false
+}
 
-func (x *MinimalNote) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_note_proto_msgTypes[2]
+func (x *GetSearchNotesRequest) GetWeights() []*SearchWeight {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Weights
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use MinimalNote.ProtoReflect.Descriptor instead.
-func (*MinimalNote) Descriptor() ([]byte, []int) {
-	return file_src_proto_note_proto_rawDescGZIP(), []int{2}
+func (x *GetSearchNotesRequest) GetHighlight() *HighlightOptions {
+	if x != nil {
+		return x.Highlight
+	}
+	return nil
 }
 
-func (x *MinimalNote) GetId() int32 {
+func (x *GetSearchNotesRequest) GetUpdatedAfter() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.UpdatedAfter
 	}
-	return 0
+	return nil
 }
 
-func (x *MinimalNote) GetTitle() string {
+func (x *GetSearchNotesRequest) GetUpdatedBefore() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Title
+		return x.UpdatedBefore
 	}
-	return ""
+	return nil
 }
 
-func (x *MinimalNote) GetAuthorId() int32 {
+func (x *GetSearchNotesRequest) GetAuthorIds() []int32 {
 	if x != nil {
-		return x.AuthorId
+		return x.AuthorIds
 	}
-	return 0
+	return nil
 }
 
-func (x *MinimalNote) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *GetSearchNotesRequest) GetExcludedTags() []string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.ExcludedTags
 	}
 	return nil
 }
 
-func (x *MinimalNote) GetStrippedContent() string {
+func (x *GetSearchNotesRequest) GetTitlePhrases() []string {
 	if x != nil {
-		return x.StrippedContent
+		return x.TitlePhrases
 	}
-	return ""
+	return nil
 }
 
-// Response: represents a Note
-type Note struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title     string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content   string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	AuthorId  int32                  `protobuf:"varint,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	// repeated NoteEmbedding embeddings = 6;
-	Permissions   []*NotePermission `protobuf:"bytes,7,rep,name=permissions,proto3" json:"permissions,omitempty"`
+func (x *GetSearchNotesRequest) GetExcludedTerms() []string {
+	if x != nil {
+		return x.ExcludedTerms
+	}
+	return nil
+}
+
+// How the matched terms of search results are highlighted
+type HighlightOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FragmentSize  int32                  `protobuf:"varint,1,opt,name=fragment_size,json=fragmentSize,proto3" json:"fragment_size,omitempty"`    // characters of a fragment, words aren't split
+	FragmentCount int32                  `protobuf:"varint,2,opt,name=fragment_count,json=fragmentCount,proto3" json:"fragment_count,omitempty"` // maximum number of fragments of a note
+	// inserted around every matched term
+	PreTag  string `protobuf:"bytes,3,opt,name=pre_tag,json=preTag,proto3" json:"pre_tag,omitempty"`
+	PostTag string `protobuf:"bytes,4,opt,name=post_tag,json=postTag,proto3" json:"post_tag,omitempty"`
+	// HTML escape the text of the note, but not the tags
+	EscapeHtml    bool `protobuf:"varint,5,opt,name=escape_html,json=escapeHtml,proto3" json:"escape_html,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Note) Reset() {
-	*x = Note{}
-	mi := &file_src_proto_note_proto_msgTypes[3]
+func (x *HighlightOptions) Reset() {
+	*x = HighlightOptions{}
+	mi := &file_src_proto_note_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Note) String() string {
+func (x *HighlightOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Note) ProtoMessage() {}
+func (*HighlightOptions) ProtoMessage() {}
 
-func (x *Note) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_note_proto_msgTypes[3]
+func (x *HighlightOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,76 +468,74 @@ func (x *Note) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Note.ProtoReflect.Descriptor instead.
-func (*Note) Descriptor() ([]byte, []int) {
-	return file_src_proto_note_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use HighlightOptions.ProtoReflect.Descriptor instead.
+func (*HighlightOptions) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Note) GetId() int32 {
+func (x *HighlightOptions) GetFragmentSize() int32 {
 	if x != nil {
-		return x.Id
+		return x.FragmentSize
 	}
 	return 0
 }
 
-func (x *Note) GetTitle() string {
+func (x *HighlightOptions) GetFragmentCount() int32 {
 	if x != nil {
-		return x.Title
+		return x.FragmentCount
 	}
-	return ""
+	return 0
 }
 
-func (x *Note) GetContent() string {
+func (x *HighlightOptions) GetPreTag() string {
 	if x != nil {
-		return x.Content
+		return x.PreTag
 	}
 	return ""
 }
 
-func (x *Note) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-func (x *Note) GetAuthorId() int32 {
+func (x *HighlightOptions) GetPostTag() string {
 	if x != nil {
-		return x.AuthorId
+		return x.PostTag
 	}
-	return 0
+	return ""
 }
 
-func (x *Note) GetPermissions() []*NotePermission {
+func (x *HighlightOptions) GetEscapeHtml() bool {
 	if x != nil {
-		return x.Permissions
+		return x.EscapeHtml
 	}
-	return nil
+	return false
 }
 
-type NoteEmbedding struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
-	Embedding     []float32              `protobuf:"fixed32,2,rep,packed,name=embedding,proto3" json:"embedding,omitempty"`
+// Part of the content of a note which matched the query
+type Fragment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// text with the matched terms between the tags. Fuzzy and Context
+	// searches return the passage most similar to the query, which may not
+	// contain any exact match
+	Text          string  `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Offset        int32   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // position in the content in characters
+	Score         float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`  // relevance of the fragment, higher is better
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NoteEmbedding) Reset() {
-	*x = NoteEmbedding{}
-	mi := &file_src_proto_note_proto_msgTypes[4]
+func (x *Fragment) Reset() {
+	*x = Fragment{}
+	mi := &file_src_proto_note_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NoteEmbedding) String() string {
+func (x *Fragment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NoteEmbedding) ProtoMessage() {}
+func (*Fragment) ProtoMessage() {}
 
-func (x *NoteEmbedding) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_note_proto_msgTypes[4]
+func (x *Fragment) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,47 +546,55 @@ func (x *NoteEmbedding) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NoteEmbedding.ProtoReflect.Descriptor instead.
-func (*NoteEmbedding) Descriptor() ([]byte, []int) {
-	return file_src_proto_note_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Fragment.ProtoReflect.Descriptor instead.
+func (*Fragment) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *NoteEmbedding) GetModel() string {
+func (x *Fragment) GetText() string {
 	if x != nil {
-		return x.Model
+		return x.Text
 	}
 	return ""
 }
 
-func (x *NoteEmbedding) GetEmbedding() []float32 {
+func (x *Fragment) GetOffset() int32 {
 	if x != nil {
-		return x.Embedding
+		return x.Offset
 	}
-	return nil
+	return 0
 }
 
-type NotePermission struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoleId        int32                  `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+func (x *Fragment) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SearchWeight struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	SearchType    GetSearchNotesRequest_SearchType `protobuf:"varint,1,opt,name=search_type,json=searchType,proto3,enum=proto.GetSearchNotesRequest_SearchType" json:"search_type,omitempty"`
+	Weight        float64                          `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotePermission) Reset() {
-	*x = NotePermission{}
-	mi := &file_src_proto_note_proto_msgTypes[5]
+func (x *SearchWeight) Reset() {
+	*x = SearchWeight{}
+	mi := &file_src_proto_note_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotePermission) String() string {
+func (x *SearchWeight) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotePermission) ProtoMessage() {}
+func (*SearchWeight) ProtoMessage() {}
 
-func (x *NotePermission) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_note_proto_msgTypes[5]
+func (x *SearchWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,43 +605,50 @@ func (x *NotePermission) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotePermission.ProtoReflect.Descriptor instead.
-func (*NotePermission) Descriptor() ([]byte, []int) {
-	return file_src_proto_note_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SearchWeight.ProtoReflect.Descriptor instead.
+func (*SearchWeight) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *NotePermission) GetRoleId() int32 {
+func (x *SearchWeight) GetSearchType() GetSearchNotesRequest_SearchType {
 	if x != nil {
-		return x.RoleId
+		return x.SearchType
+	}
+	return GetSearchNotesRequest_Undefined
+}
+
+func (x *SearchWeight) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
 	}
 	return 0
 }
 
-// Request to add/Update a note
-type PostNoteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Content       *string                `protobuf:"bytes,2,opt,name=content,proto3,oneof" json:"content,omitempty"`
-	AuthorId      int32                  `protobuf:"varint,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+// How a single search ranked a note, reported for results of a Hybrid search
+type SearchMatch struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	SearchType    GetSearchNotesRequest_SearchType `protobuf:"varint,1,opt,name=search_type,json=searchType,proto3,enum=proto.GetSearchNotesRequest_SearchType" json:"search_type,omitempty"`
+	Rank          int32                            `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"` // 1 for the best result
+	Score         float64                          `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostNoteRequest) Reset() {
-	*x = PostNoteRequest{}
-	mi := &file_src_proto_note_proto_msgTypes[6]
+func (x *SearchMatch) Reset() {
+	*x = SearchMatch{}
+	mi := &file_src_proto_note_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostNoteRequest) String() string {
+func (x *SearchMatch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostNoteRequest) ProtoMessage() {}
+func (*SearchMatch) ProtoMessage() {}
 
-func (x *PostNoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_note_proto_msgTypes[6]
+func (x *SearchMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -504,56 +659,133 @@ func (x *PostNoteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostNoteRequest.ProtoReflect.Descriptor instead.
-func (*PostNoteRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_note_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SearchMatch.ProtoReflect.Descriptor instead.
+func (*SearchMatch) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PostNoteRequest) GetTitle() string {
+func (x *SearchMatch) GetSearchType() GetSearchNotesRequest_SearchType {
 	if x != nil {
-		return x.Title
+		return x.SearchType
 	}
-	return ""
+	return GetSearchNotesRequest_Undefined
 }
 
-func (x *PostNoteRequest) GetContent() string {
-	if x != nil && x.Content != nil {
-		return *x.Content
+func (x *SearchMatch) GetRank() int32 {
+	if x != nil {
+		return x.Rank
 	}
-	return ""
+	return 0
 }
 
-func (x *PostNoteRequest) GetAuthorId() int32 {
+func (x *SearchMatch) GetScore() float64 {
 	if x != nil {
-		return x.AuthorId
+		return x.Score
 	}
 	return 0
 }
 
-type AlterNoteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Content       *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
-	AuthorId      *int32                 `protobuf:"varint,4,opt,name=author_id,json=authorId,proto3,oneof" json:"author_id,omitempty"`
+// Position of a note within the results of a search
+type SearchCursor struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sort key of NoSearch (latest first)
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// sort key of ranked searches (highest first)
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// tie breaker
+	Id            int32 `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AlterNoteRequest) Reset() {
-	*x = AlterNoteRequest{}
-	mi := &file_src_proto_note_proto_msgTypes[7]
+func (x *SearchCursor) Reset() {
+	*x = SearchCursor{}
+	mi := &file_src_proto_note_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AlterNoteRequest) String() string {
+func (x *SearchCursor) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AlterNoteRequest) ProtoMessage() {}
+func (*SearchCursor) ProtoMessage() {}
 
-func (x *AlterNoteRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchCursor) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCursor.ProtoReflect.Descriptor instead.
+func (*SearchCursor) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchCursor) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *SearchCursor) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchCursor) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Response: represents a minimal Note for search results
+type MinimalNote struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // Note ID (eg 42)
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	AuthorId        int32                  `protobuf:"varint,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StrippedContent string                 `protobuf:"bytes,5,opt,name=stripped_content,json=strippedContent,proto3" json:"stripped_content,omitempty"`
+	Cursor          *SearchCursor          `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`                                                               // position within the search results
+	AccessLevel     NotePermission_Level   `protobuf:"varint,7,opt,name=access_level,json=accessLevel,proto3,enum=proto.NotePermission_Level" json:"access_level,omitempty"` // access of the requesting user, Write for the author
+	Tags            []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	NotebookId      *int32                 `protobuf:"varint,9,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"`
+	DeletedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3,oneof" json:"deleted_at,omitempty"` // only set for notes in the trash
+	// relevance for the query of ranked searches, higher is better
+	Score   float64        `protobuf:"fixed64,11,opt,name=score,proto3" json:"score,omitempty"`
+	Matches []*SearchMatch `protobuf:"bytes,12,rep,name=matches,proto3" json:"matches,omitempty"` // searches which found the note, for Hybrid searches
+	// only set if highlighting was requested
+	HighlightedTitle *string     `protobuf:"bytes,13,opt,name=highlighted_title,json=highlightedTitle,proto3,oneof" json:"highlighted_title,omitempty"` // title with the matched terms between the tags
+	Fragments        []*Fragment `protobuf:"bytes,14,rep,name=fragments,proto3" json:"fragments,omitempty"`                                             // best first
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MinimalNote) Reset() {
+	*x = MinimalNote{}
+	mi := &file_src_proto_note_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinimalNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinimalNote) ProtoMessage() {}
+
+func (x *MinimalNote) ProtoReflect() protoreflect.Message {
 	mi := &file_src_proto_note_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -565,35 +797,4793 @@ func (x *AlterNoteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AlterNoteRequest.ProtoReflect.Descriptor instead.
-func (*AlterNoteRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_note_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use MinimalNote.ProtoReflect.Descriptor instead.
+func (*MinimalNote) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MinimalNote) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MinimalNote) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MinimalNote) GetAuthorId() int32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *MinimalNote) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *MinimalNote) GetStrippedContent() string {
+	if x != nil {
+		return x.StrippedContent
+	}
+	return ""
+}
+
+func (x *MinimalNote) GetCursor() *SearchCursor {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *MinimalNote) GetAccessLevel() NotePermission_Level {
+	if x != nil {
+		return x.AccessLevel
+	}
+	return NotePermission_None
+}
+
+func (x *MinimalNote) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *MinimalNote) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
+}
+
+func (x *MinimalNote) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *MinimalNote) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *MinimalNote) GetMatches() []*SearchMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *MinimalNote) GetHighlightedTitle() string {
+	if x != nil && x.HighlightedTitle != nil {
+		return *x.HighlightedTitle
+	}
+	return ""
+}
+
+func (x *MinimalNote) GetFragments() []*Fragment {
+	if x != nil {
+		return x.Fragments
+	}
+	return nil
+}
+
+// Response: represents a Note
+type Note struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title     string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content   string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AuthorId  int32                  `protobuf:"varint,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// repeated NoteEmbedding embeddings = 6;
+	// permissions of the note. The author gets all of them, other users only
+	// those which grant them access
+	Permissions []*NotePermission `protobuf:"bytes,7,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// access of the requesting user, Write for the author. Includes the access
+	// inherited from notebooks, unless the note sets its own permission
+	AccessLevel NotePermission_Level `protobuf:"varint,8,opt,name=access_level,json=accessLevel,proto3,enum=proto.NotePermission_Level" json:"access_level,omitempty"`
+	Version     int32                `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"` // number of the current version
+	Tags        []string             `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	NotebookId  *int32               `protobuf:"varint,11,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"` // unset if the note isn't in a notebook
+	// increased with every change of the note: title, content, tags,
+	// notebook and permissions. Unlike version, which only counts changes of
+	// the title and content, it tells whether anything of the note changed
+	Revision      int64 `protobuf:"varint,12,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_src_proto_note_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Note) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Note) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Note) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Note) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Note) GetAuthorId() int32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *Note) GetPermissions() []*NotePermission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *Note) GetAccessLevel() NotePermission_Level {
+	if x != nil {
+		return x.AccessLevel
+	}
+	return NotePermission_None
+}
+
+func (x *Note) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Note) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Note) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
+}
+
+func (x *Note) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type NoteEmbedding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Embedding     []float32              `protobuf:"fixed32,2,rep,packed,name=embedding,proto3" json:"embedding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteEmbedding) Reset() {
+	*x = NoteEmbedding{}
+	mi := &file_src_proto_note_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteEmbedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteEmbedding) ProtoMessage() {}
+
+func (x *NoteEmbedding) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteEmbedding.ProtoReflect.Descriptor instead.
+func (*NoteEmbedding) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NoteEmbedding) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *NoteEmbedding) GetEmbedding() []float32 {
+	if x != nil {
+		return x.Embedding
+	}
+	return nil
+}
+
+// Access to a note granted to a user or to all users with a role
+type NotePermission struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleId        int32                  `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"` // set if granted to a role
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // set if granted to a single user
+	Level         NotePermission_Level   `protobuf:"varint,3,opt,name=level,proto3,enum=proto.NotePermission_Level" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotePermission) Reset() {
+	*x = NotePermission{}
+	mi := &file_src_proto_note_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotePermission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotePermission) ProtoMessage() {}
+
+func (x *NotePermission) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotePermission.ProtoReflect.Descriptor instead.
+func (*NotePermission) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NotePermission) GetRoleId() int32 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
+func (x *NotePermission) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *NotePermission) GetLevel() NotePermission_Level {
+	if x != nil {
+		return x.Level
+	}
+	return NotePermission_None
+}
+
+// Request to add/Update a note
+type PostNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Content       *string                `protobuf:"bytes,2,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	AuthorId      int32                  `protobuf:"varint,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	NotebookId    *int32                 `protobuf:"varint,5,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"`
+	Links         []*NoteLink            `protobuf:"bytes,6,rep,name=links,proto3" json:"links,omitempty"` // links in the content
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostNoteRequest) Reset() {
+	*x = PostNoteRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostNoteRequest) ProtoMessage() {}
+
+func (x *PostNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostNoteRequest.ProtoReflect.Descriptor instead.
+func (*PostNoteRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PostNoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PostNoteRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *PostNoteRequest) GetAuthorId() int32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *PostNoteRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PostNoteRequest) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
+}
+
+func (x *PostNoteRequest) GetLinks() []*NoteLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// Tags of a note. Wrapped, so an empty list can be told apart from no change
+type TagList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagList) Reset() {
+	*x = TagList{}
+	mi := &file_src_proto_note_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagList) ProtoMessage() {}
+
+func (x *TagList) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagList.ProtoReflect.Descriptor instead.
+func (*TagList) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TagList) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Reference to another note in the content of a note, written as
+// [[Note Title]] or [[#42]]. Either title or note_id is set. Titles are
+// resolved when the links are requested, so a dangling link resolves as soon
+// as a note with the title exists
+type NoteLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"` // compared case insensitively
+	NoteId        int32                  `protobuf:"varint,2,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteLink) Reset() {
+	*x = NoteLink{}
+	mi := &file_src_proto_note_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteLink) ProtoMessage() {}
+
+func (x *NoteLink) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteLink.ProtoReflect.Descriptor instead.
+func (*NoteLink) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NoteLink) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NoteLink) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+// Links of a note. Wrapped, so an empty list can be told apart from no change
+type NoteLinkList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*NoteLink            `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteLinkList) Reset() {
+	*x = NoteLinkList{}
+	mi := &file_src_proto_note_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteLinkList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteLinkList) ProtoMessage() {}
+
+func (x *NoteLinkList) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteLinkList.ProtoReflect.Descriptor instead.
+func (*NoteLinkList) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NoteLinkList) GetLinks() []*NoteLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type AlterNoteRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title    *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Content  *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	AuthorId *int32                 `protobuf:"varint,4,opt,name=author_id,json=authorId,proto3,oneof" json:"author_id,omitempty"`
+	// authentication
+	UserId int32 `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// replaced by expected_revision, since the version doesn't count
+	// changes of tags, notebook and permissions
+	//
+	// Deprecated: Marked as deprecated in src/proto/note.proto.
+	ExpectedVersion *int32 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	// replaces all tags of the note. Like every other change, it increases
+	// the revision, even if nothing else is changed
+	Tags *TagList `protobuf:"bytes,7,opt,name=tags,proto3,oneof" json:"tags,omitempty"`
+	// moves the note into another notebook, 0 moves it out of its notebook
+	NotebookId *int32 `protobuf:"varint,8,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"`
+	// replaces all links of the note. Set whenever the content changes
+	Links *NoteLinkList `protobuf:"bytes,9,opt,name=links,proto3,oneof" json:"links,omitempty"`
+	// only alter the note if it still has this revision, otherwise fail with
+	// ABORTED. The check and the change have to be atomic
+	ExpectedRevision *int64 `protobuf:"varint,10,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AlterNoteRequest) Reset() {
+	*x = AlterNoteRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlterNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlterNoteRequest) ProtoMessage() {}
+
+func (x *AlterNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlterNoteRequest.ProtoReflect.Descriptor instead.
+func (*AlterNoteRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AlterNoteRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlterNoteRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *AlterNoteRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *AlterNoteRequest) GetAuthorId() int32 {
+	if x != nil && x.AuthorId != nil {
+		return *x.AuthorId
+	}
+	return 0
+}
+
+func (x *AlterNoteRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in src/proto/note.proto.
+func (x *AlterNoteRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *AlterNoteRequest) GetTags() *TagList {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AlterNoteRequest) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
+}
+
+func (x *AlterNoteRequest) GetLinks() *NoteLinkList {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *AlterNoteRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+// Request to move a note to the trash. Notes in the trash are treated as if
+// they don't exist by all other requests, until they are restored
+type DeleteNoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// authentication
+	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// replaced by expected_revision
+	//
+	// Deprecated: Marked as deprecated in src/proto/note.proto.
+	ExpectedVersion *int32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	// only delete the note if it still has this revision, otherwise fail
+	// with ABORTED
+	ExpectedRevision *int64 `protobuf:"varint,4,opt,name=expected_revision,json=expectedRevision,proto3,oneof" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteNoteRequest) Reset() {
+	*x = DeleteNoteRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNoteRequest) ProtoMessage() {}
+
+func (x *DeleteNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNoteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNoteRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteNoteRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteNoteRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in src/proto/note.proto.
+func (x *DeleteNoteRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *DeleteNoteRequest) GetExpectedRevision() int64 {
+	if x != nil && x.ExpectedRevision != nil {
+		return *x.ExpectedRevision
+	}
+	return 0
+}
+
+type DeleteNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNoteResponse) Reset() {
+	*x = DeleteNoteResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNoteResponse) ProtoMessage() {}
+
+func (x *DeleteNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNoteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNoteResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteNoteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Request for the permissions of a note. Only the author may list them
+type GetNotePermissionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotePermissionsRequest) Reset() {
+	*x = GetNotePermissionsRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotePermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotePermissionsRequest) ProtoMessage() {}
+
+func (x *GetNotePermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotePermissionsRequest.ProtoReflect.Descriptor instead.
+func (*GetNotePermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetNotePermissionsRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GetNotePermissionsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetNotePermissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permissions   []*NotePermission      `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotePermissionsResponse) Reset() {
+	*x = GetNotePermissionsResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotePermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotePermissionsResponse) ProtoMessage() {}
+
+func (x *GetNotePermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotePermissionsResponse.ProtoReflect.Descriptor instead.
+func (*GetNotePermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetNotePermissionsResponse) GetPermissions() []*NotePermission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+// Request to grant a permission. An existing permission of the same user or
+// role is replaced. Only the author may grant permissions
+type GrantNotePermissionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	NoteId     int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Permission *NotePermission        `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantNotePermissionRequest) Reset() {
+	*x = GrantNotePermissionRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantNotePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantNotePermissionRequest) ProtoMessage() {}
+
+func (x *GrantNotePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantNotePermissionRequest.ProtoReflect.Descriptor instead.
+func (*GrantNotePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GrantNotePermissionRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GrantNotePermissionRequest) GetPermission() *NotePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return nil
+}
+
+func (x *GrantNotePermissionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to revoke the permission of the user or role set in permission.
+// Only the author may revoke permissions
+type RevokeNotePermissionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	NoteId     int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Permission *NotePermission        `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"` // level is ignored
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeNotePermissionRequest) Reset() {
+	*x = RevokeNotePermissionRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeNotePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeNotePermissionRequest) ProtoMessage() {}
+
+func (x *RevokeNotePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeNotePermissionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeNotePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RevokeNotePermissionRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *RevokeNotePermissionRequest) GetPermission() *NotePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return nil
+}
+
+func (x *RevokeNotePermissionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type RevokeNotePermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeNotePermissionResponse) Reset() {
+	*x = RevokeNotePermissionResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeNotePermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeNotePermissionResponse) ProtoMessage() {}
+
+func (x *RevokeNotePermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeNotePermissionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeNotePermissionResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RevokeNotePermissionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Request for notes of other users which the user has access to, latest first
+type GetSharedNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedNotesRequest) Reset() {
+	*x = GetSharedNotesRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedNotesRequest) ProtoMessage() {}
+
+func (x *GetSharedNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedNotesRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedNotesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetSharedNotesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetSharedNotesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetSharedNotesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Public read-only link to a note. Only the hash of the token is stored
+type ShareLink struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NoteId         int32                  `protobuf:"varint,2,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	HasPassword    bool                   `protobuf:"varint,5,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	AccessCount    int64                  `protobuf:"varint,6,opt,name=access_count,json=accessCount,proto3" json:"access_count,omitempty"`
+	LastAccessedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_accessed_at,json=lastAccessedAt,proto3,oneof" json:"last_accessed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_src_proto_note_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ShareLink) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShareLink) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *ShareLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *ShareLink) GetAccessCount() int64 {
+	if x != nil {
+		return x.AccessCount
+	}
+	return 0
+}
+
+func (x *ShareLink) GetLastAccessedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAccessedAt
+	}
+	return nil
+}
+
+// Request to create a share link. Only the author may create them
+type PostShareLinkRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	NoteId       int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	TokenHash    []byte                 `protobuf:"bytes,2,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"`
+	ExpiresAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	PasswordHash []byte                 `protobuf:"bytes,4,opt,name=password_hash,json=passwordHash,proto3,oneof" json:"password_hash,omitempty"` // bcrypt hash
+	// authentication
+	UserId        int32 `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostShareLinkRequest) Reset() {
+	*x = PostShareLinkRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostShareLinkRequest) ProtoMessage() {}
+
+func (x *PostShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*PostShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PostShareLinkRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *PostShareLinkRequest) GetTokenHash() []byte {
+	if x != nil {
+		return x.TokenHash
+	}
+	return nil
+}
+
+func (x *PostShareLinkRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PostShareLinkRequest) GetPasswordHash() []byte {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return nil
+}
+
+func (x *PostShareLinkRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetShareLinksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShareLinksRequest) Reset() {
+	*x = GetShareLinksRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShareLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShareLinksRequest) ProtoMessage() {}
+
+func (x *GetShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShareLinksRequest.ProtoReflect.Descriptor instead.
+func (*GetShareLinksRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetShareLinksRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GetShareLinksRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetShareLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*ShareLink           `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShareLinksResponse) Reset() {
+	*x = GetShareLinksResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShareLinksResponse) ProtoMessage() {}
+
+func (x *GetShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*GetShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetShareLinksResponse) GetLinks() []*ShareLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type DeleteShareLinkRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NoteId int32                  `protobuf:"varint,2,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShareLinkRequest) Reset() {
+	*x = DeleteShareLinkRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShareLinkRequest) ProtoMessage() {}
+
+func (x *DeleteShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteShareLinkRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteShareLinkRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *DeleteShareLinkRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShareLinkResponse) Reset() {
+	*x = DeleteShareLinkResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShareLinkResponse) ProtoMessage() {}
+
+func (x *DeleteShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*DeleteShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteShareLinkResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Request for a shared note. Needs no user, the token is the authentication
+type GetNoteByShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenHash     []byte                 `protobuf:"bytes,1,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteByShareLinkRequest) Reset() {
+	*x = GetNoteByShareLinkRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteByShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteByShareLinkRequest) ProtoMessage() {}
+
+func (x *GetNoteByShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteByShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteByShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetNoteByShareLinkRequest) GetTokenHash() []byte {
+	if x != nil {
+		return x.TokenHash
+	}
+	return nil
+}
+
+type GetNoteByShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	Note          *Note                  `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	PasswordHash  []byte                 `protobuf:"bytes,3,opt,name=password_hash,json=passwordHash,proto3,oneof" json:"password_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteByShareLinkResponse) Reset() {
+	*x = GetNoteByShareLinkResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteByShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteByShareLinkResponse) ProtoMessage() {}
+
+func (x *GetNoteByShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteByShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*GetNoteByShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetNoteByShareLinkResponse) GetLink() *ShareLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *GetNoteByShareLinkResponse) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *GetNoteByShareLinkResponse) GetPasswordHash() []byte {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return nil
+}
+
+// Request to count an access of a share link
+type RecordShareLinkAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordShareLinkAccessRequest) Reset() {
+	*x = RecordShareLinkAccessRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordShareLinkAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordShareLinkAccessRequest) ProtoMessage() {}
+
+func (x *RecordShareLinkAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordShareLinkAccessRequest.ProtoReflect.Descriptor instead.
+func (*RecordShareLinkAccessRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RecordShareLinkAccessRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RecordShareLinkAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessCount   int64                  `protobuf:"varint,1,opt,name=access_count,json=accessCount,proto3" json:"access_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordShareLinkAccessResponse) Reset() {
+	*x = RecordShareLinkAccessResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordShareLinkAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordShareLinkAccessResponse) ProtoMessage() {}
+
+func (x *RecordShareLinkAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordShareLinkAccessResponse.ProtoReflect.Descriptor instead.
+func (*RecordShareLinkAccessResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RecordShareLinkAccessResponse) GetAccessCount() int64 {
+	if x != nil {
+		return x.AccessCount
+	}
+	return 0
+}
+
+// Revision of a note. Every change of the title or content stores one
+type NoteVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // counts up from 1 for every note
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`                    // left empty when versions are listed
+	AuthorId      int32                  `protobuf:"varint,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // user who made the change
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RestoredFrom  *int32                 `protobuf:"varint,7,opt,name=restored_from,json=restoredFrom,proto3,oneof" json:"restored_from,omitempty"` // set if the version restored an older one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteVersion) Reset() {
+	*x = NoteVersion{}
+	mi := &file_src_proto_note_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteVersion) ProtoMessage() {}
+
+func (x *NoteVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteVersion.ProtoReflect.Descriptor instead.
+func (*NoteVersion) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *NoteVersion) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *NoteVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *NoteVersion) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NoteVersion) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *NoteVersion) GetAuthorId() int32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *NoteVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *NoteVersion) GetRestoredFrom() int32 {
+	if x != nil && x.RestoredFrom != nil {
+		return *x.RestoredFrom
+	}
+	return 0
+}
+
+// Request for the versions of a note, latest first
+type GetNoteVersionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteVersionsRequest) Reset() {
+	*x = GetNoteVersionsRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteVersionsRequest) ProtoMessage() {}
+
+func (x *GetNoteVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteVersionsRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetNoteVersionsRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GetNoteVersionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetNoteVersionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetNoteVersionsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetNoteVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*NoteVersion         `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteVersionsResponse) Reset() {
+	*x = GetNoteVersionsResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteVersionsResponse) ProtoMessage() {}
+
+func (x *GetNoteVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteVersionsResponse.ProtoReflect.Descriptor instead.
+func (*GetNoteVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetNoteVersionsResponse) GetVersions() []*NoteVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type GetNoteVersionRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	NoteId  int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Version int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteVersionRequest) Reset() {
+	*x = GetNoteVersionRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteVersionRequest) ProtoMessage() {}
+
+func (x *GetNoteVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteVersionRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetNoteVersionRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GetNoteVersionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetNoteVersionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to restore an old version. Its title and content are stored as
+// new version, so nothing is lost
+type RestoreNoteVersionRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	NoteId  int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Version int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// authentication
+	UserId int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// links in the content of the restored version, replacing all links
+	Links         []*NoteLink `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreNoteVersionRequest) Reset() {
+	*x = RestoreNoteVersionRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreNoteVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreNoteVersionRequest) ProtoMessage() {}
+
+func (x *RestoreNoteVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreNoteVersionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreNoteVersionRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RestoreNoteVersionRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *RestoreNoteVersionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RestoreNoteVersionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RestoreNoteVersionRequest) GetLinks() []*NoteLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// Tag with the number of notes using it
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NoteCount     int32                  `protobuf:"varint,2,opt,name=note_count,json=noteCount,proto3" json:"note_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_src_proto_note_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetNoteCount() int32 {
+	if x != nil {
+		return x.NoteCount
+	}
+	return 0
+}
+
+// Request for the tags of all notes authored by a user, most used first
+type GetTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Prefix        *string                `protobuf:"bytes,2,opt,name=prefix,proto3,oneof" json:"prefix,omitempty"` // only tags starting with it
+	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`  // all tags if unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTagsRequest) Reset() {
+	*x = GetTagsRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagsRequest) ProtoMessage() {}
+
+func (x *GetTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagsRequest.ProtoReflect.Descriptor instead.
+func (*GetTagsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetTagsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetTagsRequest) GetPrefix() string {
+	if x != nil && x.Prefix != nil {
+		return *x.Prefix
+	}
+	return ""
+}
+
+func (x *GetTagsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type GetTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTagsResponse) Reset() {
+	*x = GetTagsResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagsResponse) ProtoMessage() {}
+
+func (x *GetTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagsResponse.ProtoReflect.Descriptor instead.
+func (*GetTagsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Request to rename a tag on all notes authored by the user. Fails with
+// ALREADY_EXISTS if the new name is used already, use MergeTags for that.
+// Increases the revision of every note having the tag
+type RenameTagRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Name    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NewName string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagRequest) Reset() {
+	*x = RenameTagRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagRequest) ProtoMessage() {}
+
+func (x *RenameTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagRequest.ProtoReflect.Descriptor instead.
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RenameTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenameTagRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+func (x *RenameTagRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to replace the source tags with the target tag on all notes
+// authored by the user. Increases the revision of every note having one of
+// the source tags
+type MergeTagsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Sources []string               `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	Target  string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsRequest) ProtoMessage() {}
+
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MergeTagsRequest) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *MergeTagsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *MergeTagsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type AlterTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"` // the renamed or merged tag
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlterTagsResponse) Reset() {
+	*x = AlterTagsResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlterTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlterTagsResponse) ProtoMessage() {}
+
+func (x *AlterTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlterTagsResponse.ProtoReflect.Descriptor instead.
+func (*AlterTagsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AlterTagsResponse) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+// Folder of notes, which may be nested. Permissions of a notebook are
+// inherited by all notes and notebooks inside, unless they set their own
+type Notebook struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId  *int32                 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"` // unset for top level notebooks
+	OwnerId   int32                  `protobuf:"varint,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// permissions set on the notebook itself. The owner gets all of them,
+	// other users only those which grant them access
+	Permissions []*NotePermission `protobuf:"bytes,7,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// access of the requesting user including inherited access, Write for the owner
+	AccessLevel   NotePermission_Level `protobuf:"varint,8,opt,name=access_level,json=accessLevel,proto3,enum=proto.NotePermission_Level" json:"access_level,omitempty"`
+	NoteCount     int32                `protobuf:"varint,9,opt,name=note_count,json=noteCount,proto3" json:"note_count,omitempty"` // notes directly inside the notebook
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notebook) Reset() {
+	*x = Notebook{}
+	mi := &file_src_proto_note_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notebook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notebook) ProtoMessage() {}
+
+func (x *Notebook) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notebook.ProtoReflect.Descriptor instead.
+func (*Notebook) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Notebook) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Notebook) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Notebook) GetParentId() int32 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *Notebook) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *Notebook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Notebook) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Notebook) GetPermissions() []*NotePermission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *Notebook) GetAccessLevel() NotePermission_Level {
+	if x != nil {
+		return x.AccessLevel
+	}
+	return NotePermission_None
+}
+
+func (x *Notebook) GetNoteCount() int32 {
+	if x != nil {
+		return x.NoteCount
+	}
+	return 0
+}
+
+type GetNotebookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotebookRequest) Reset() {
+	*x = GetNotebookRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotebookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotebookRequest) ProtoMessage() {}
+
+func (x *GetNotebookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotebookRequest.ProtoReflect.Descriptor instead.
+func (*GetNotebookRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetNotebookRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetNotebookRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request for all notebooks the user has access to. Notebooks whose parent
+// the user can't access are returned as top level notebooks
+type GetNotebooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotebooksRequest) Reset() {
+	*x = GetNotebooksRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotebooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotebooksRequest) ProtoMessage() {}
+
+func (x *GetNotebooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotebooksRequest.ProtoReflect.Descriptor instead.
+func (*GetNotebooksRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetNotebooksRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetNotebooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notebooks     []*Notebook            `protobuf:"bytes,1,rep,name=notebooks,proto3" json:"notebooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotebooksResponse) Reset() {
+	*x = GetNotebooksResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotebooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotebooksResponse) ProtoMessage() {}
+
+func (x *GetNotebooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotebooksResponse.ProtoReflect.Descriptor instead.
+func (*GetNotebooksResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetNotebooksResponse) GetNotebooks() []*Notebook {
+	if x != nil {
+		return x.Notebooks
+	}
+	return nil
+}
+
+type PostNotebookRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId *int32                 `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostNotebookRequest) Reset() {
+	*x = PostNotebookRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostNotebookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostNotebookRequest) ProtoMessage() {}
+
+func (x *PostNotebookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostNotebookRequest.ProtoReflect.Descriptor instead.
+func (*PostNotebookRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PostNotebookRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PostNotebookRequest) GetParentId() int32 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *PostNotebookRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type AlterNotebookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// moves the notebook, 0 moves it to the top level. Fails with
+	// FAILED_PRECONDITION if the new parent is inside the notebook
+	ParentId *int32 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlterNotebookRequest) Reset() {
+	*x = AlterNotebookRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlterNotebookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlterNotebookRequest) ProtoMessage() {}
+
+func (x *AlterNotebookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlterNotebookRequest.ProtoReflect.Descriptor instead.
+func (*AlterNotebookRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AlterNotebookRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlterNotebookRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *AlterNotebookRequest) GetParentId() int32 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *AlterNotebookRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to delete a notebook. The notes and notebooks inside are moved to
+// its parent. Only the owner may delete a notebook
+type DeleteNotebookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotebookRequest) Reset() {
+	*x = DeleteNotebookRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotebookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotebookRequest) ProtoMessage() {}
+
+func (x *DeleteNotebookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotebookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNotebookRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DeleteNotebookRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteNotebookRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteNotebookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotebookResponse) Reset() {
+	*x = DeleteNotebookResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotebookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotebookResponse) ProtoMessage() {}
+
+func (x *DeleteNotebookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotebookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNotebookResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteNotebookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Request for the permissions set on a notebook. Only the owner may list them
+type GetNotebookPermissionsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	NotebookId int32                  `protobuf:"varint,1,opt,name=notebook_id,json=notebookId,proto3" json:"notebook_id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotebookPermissionsRequest) Reset() {
+	*x = GetNotebookPermissionsRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotebookPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotebookPermissionsRequest) ProtoMessage() {}
+
+func (x *GetNotebookPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotebookPermissionsRequest.ProtoReflect.Descriptor instead.
+func (*GetNotebookPermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetNotebookPermissionsRequest) GetNotebookId() int32 {
+	if x != nil {
+		return x.NotebookId
+	}
+	return 0
+}
+
+func (x *GetNotebookPermissionsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to grant a permission on a notebook. An existing permission of the
+// same user or role is replaced. Only the owner may grant permissions
+type GrantNotebookPermissionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	NotebookId int32                  `protobuf:"varint,1,opt,name=notebook_id,json=notebookId,proto3" json:"notebook_id,omitempty"`
+	Permission *NotePermission        `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantNotebookPermissionRequest) Reset() {
+	*x = GrantNotebookPermissionRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantNotebookPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantNotebookPermissionRequest) ProtoMessage() {}
+
+func (x *GrantNotebookPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantNotebookPermissionRequest.ProtoReflect.Descriptor instead.
+func (*GrantNotebookPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GrantNotebookPermissionRequest) GetNotebookId() int32 {
+	if x != nil {
+		return x.NotebookId
+	}
+	return 0
+}
+
+func (x *GrantNotebookPermissionRequest) GetPermission() *NotePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return nil
+}
+
+func (x *GrantNotebookPermissionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to revoke the permission of the user or role set in permission.
+// Only the owner may revoke permissions
+type RevokeNotebookPermissionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	NotebookId int32                  `protobuf:"varint,1,opt,name=notebook_id,json=notebookId,proto3" json:"notebook_id,omitempty"`
+	Permission *NotePermission        `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"` // level is ignored
+	// authentication
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeNotebookPermissionRequest) Reset() {
+	*x = RevokeNotebookPermissionRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeNotebookPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeNotebookPermissionRequest) ProtoMessage() {}
+
+func (x *RevokeNotebookPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeNotebookPermissionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeNotebookPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RevokeNotebookPermissionRequest) GetNotebookId() int32 {
+	if x != nil {
+		return x.NotebookId
+	}
+	return 0
+}
+
+func (x *RevokeNotebookPermissionRequest) GetPermission() *NotePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return nil
+}
+
+func (x *RevokeNotebookPermissionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Link of a note together with the note it points to
+type ResolvedNoteLink struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Link          *NoteLink               `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	Status        ResolvedNoteLink_Status `protobuf:"varint,2,opt,name=status,proto3,enum=proto.ResolvedNoteLink_Status" json:"status,omitempty"`
+	Note          *MinimalNote            `protobuf:"bytes,3,opt,name=note,proto3,oneof" json:"note,omitempty"` // unset for dangling links
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvedNoteLink) Reset() {
+	*x = ResolvedNoteLink{}
+	mi := &file_src_proto_note_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvedNoteLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedNoteLink) ProtoMessage() {}
+
+func (x *ResolvedNoteLink) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedNoteLink.ProtoReflect.Descriptor instead.
+func (*ResolvedNoteLink) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ResolvedNoteLink) GetLink() *NoteLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *ResolvedNoteLink) GetStatus() ResolvedNoteLink_Status {
+	if x != nil {
+		return x.Status
+	}
+	return ResolvedNoteLink_Dangling
+}
+
+func (x *ResolvedNoteLink) GetNote() *MinimalNote {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+// Request for the links of a note, or the notes linking to it
+type GetNoteLinksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteLinksRequest) Reset() {
+	*x = GetNoteLinksRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteLinksRequest) ProtoMessage() {}
+
+func (x *GetNoteLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteLinksRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteLinksRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetNoteLinksRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GetNoteLinksRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// links in order of their appearance in the content
+type GetNoteLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*ResolvedNoteLink    `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteLinksResponse) Reset() {
+	*x = GetNoteLinksResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteLinksResponse) ProtoMessage() {}
+
+func (x *GetNoteLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteLinksResponse.ProtoReflect.Descriptor instead.
+func (*GetNoteLinksResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetNoteLinksResponse) GetLinks() []*ResolvedNoteLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// Request for the notes the user can read and the links between them
+type GetNoteGraphRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// only include notes inside this notebook, and its sub notebooks if
+	// include_sub_notebooks is set
+	NotebookId          *int32 `protobuf:"varint,2,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"`
+	IncludeSubNotebooks bool   `protobuf:"varint,3,opt,name=include_sub_notebooks,json=includeSubNotebooks,proto3" json:"include_sub_notebooks,omitempty"`
+	Limit               int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // maximum number of notes, latest updated first
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetNoteGraphRequest) Reset() {
+	*x = GetNoteGraphRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteGraphRequest) ProtoMessage() {}
+
+func (x *GetNoteGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteGraphRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetNoteGraphRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetNoteGraphRequest) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
+}
+
+func (x *GetNoteGraphRequest) GetIncludeSubNotebooks() bool {
+	if x != nil {
+		return x.IncludeSubNotebooks
+	}
+	return false
+}
+
+func (x *GetNoteGraphRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Resolved link from one note to another
+type NoteGraphEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromId        int32                  `protobuf:"varint,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToId          int32                  `protobuf:"varint,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteGraphEdge) Reset() {
+	*x = NoteGraphEdge{}
+	mi := &file_src_proto_note_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteGraphEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteGraphEdge) ProtoMessage() {}
+
+func (x *NoteGraphEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteGraphEdge.ProtoReflect.Descriptor instead.
+func (*NoteGraphEdge) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *NoteGraphEdge) GetFromId() int32 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *NoteGraphEdge) GetToId() int32 {
+	if x != nil {
+		return x.ToId
+	}
+	return 0
+}
+
+// Notes and the links between them. Only links between the returned notes
+// are included, dangling links are left out
+type NoteGraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*MinimalNote         `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*NoteGraphEdge       `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteGraph) Reset() {
+	*x = NoteGraph{}
+	mi := &file_src_proto_note_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteGraph) ProtoMessage() {}
+
+func (x *NoteGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteGraph.ProtoReflect.Descriptor instead.
+func (*NoteGraph) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *NoteGraph) GetNodes() []*MinimalNote {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *NoteGraph) GetEdges() []*NoteGraphEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+// Request for the notes most similar to a note, compared by the stored
+// embeddings which are also used by the Context search. Only notes the user
+// can read are returned, most similar first. Fails with FAILED_PRECONDITION
+// if the embedding of the note wasn't computed yet
+type GetRelatedNotesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// authentication
+	UserId int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// only return notes with at least this score
+	MinScore      float64 `protobuf:"fixed64,4,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedNotesRequest) Reset() {
+	*x = GetRelatedNotesRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedNotesRequest) ProtoMessage() {}
+
+func (x *GetRelatedNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedNotesRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedNotesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetRelatedNotesRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GetRelatedNotesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetRelatedNotesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetRelatedNotesRequest) GetMinScore() float64 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+type RelatedNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Note          *MinimalNote           `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // cosine similarity of the embeddings, 1 for equal ones
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedNote) Reset() {
+	*x = RelatedNote{}
+	mi := &file_src_proto_note_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedNote) ProtoMessage() {}
+
+func (x *RelatedNote) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedNote.ProtoReflect.Descriptor instead.
+func (*RelatedNote) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *RelatedNote) GetNote() *MinimalNote {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *RelatedNote) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetRelatedNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notes         []*RelatedNote         `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedNotesResponse) Reset() {
+	*x = GetRelatedNotesResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedNotesResponse) ProtoMessage() {}
+
+func (x *GetRelatedNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedNotesResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedNotesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetRelatedNotesResponse) GetNotes() []*RelatedNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// Request for the notes in the trash of a user, latest deleted first
+type GetTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrashRequest) Reset() {
+	*x = GetTrashRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrashRequest) ProtoMessage() {}
+
+func (x *GetTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrashRequest.ProtoReflect.Descriptor instead.
+func (*GetTrashRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetTrashRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetTrashRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTrashRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Request to restore notes from the trash of the user. Notes whose notebook
+// was deleted are restored to the top level. Fails with NOT_FOUND without
+// restoring any note, if one of them isn't in the trash of the user
+type RestoreNotesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ids   []int32                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreNotesRequest) Reset() {
+	*x = RestoreNotesRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreNotesRequest) ProtoMessage() {}
+
+func (x *RestoreNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreNotesRequest.ProtoReflect.Descriptor instead.
+func (*RestoreNotesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RestoreNotesRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *RestoreNotesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type RestoreNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreNotesResponse) Reset() {
+	*x = RestoreNotesResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreNotesResponse) ProtoMessage() {}
+
+func (x *RestoreNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreNotesResponse.ProtoReflect.Descriptor instead.
+func (*RestoreNotesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RestoreNotesResponse) GetNotes() []*Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// Request to permanently delete notes in the trash. All conditions which are
+// set have to match
+type PurgeNotesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only purge these notes
+	Ids []int32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// only purge the trash of this user. Unset for the purge job
+	UserId *int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// only purge notes which were moved to the trash before this time
+	DeletedBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deleted_before,json=deletedBefore,proto3,oneof" json:"deleted_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeNotesRequest) Reset() {
+	*x = PurgeNotesRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeNotesRequest) ProtoMessage() {}
+
+func (x *PurgeNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeNotesRequest.ProtoReflect.Descriptor instead.
+func (*PurgeNotesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *PurgeNotesRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *PurgeNotesRequest) GetUserId() int32 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *PurgeNotesRequest) GetDeletedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedBefore
+	}
+	return nil
+}
+
+type PurgeNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int32                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"` // number of purged notes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeNotesResponse) Reset() {
+	*x = PurgeNotesResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeNotesResponse) ProtoMessage() {}
+
+func (x *PurgeNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeNotesResponse.ProtoReflect.Descriptor instead.
+func (*PurgeNotesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *PurgeNotesResponse) GetPurged() int32 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+// A search stored by a user to run it again later. Saved searches are only
+// visible to their owner
+type SavedSearch struct {
+	state      protoimpl.MessageState           `protogen:"open.v1"`
+	Id         int32                            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId    int32                            `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Name       string                           `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	SearchType GetSearchNotesRequest_SearchType `protobuf:"varint,4,opt,name=search_type,json=searchType,proto3,enum=proto.GetSearchNotesRequest_SearchType" json:"search_type,omitempty"`
+	// the query as typed, including filters like tag:infra. It is parsed by
+	// the REST service whenever the search runs
+	Query               string          `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	Tags                []string        `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	NotebookId          *int32          `protobuf:"varint,7,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"`
+	IncludeSubNotebooks bool            `protobuf:"varint,8,opt,name=include_sub_notebooks,json=includeSubNotebooks,proto3" json:"include_sub_notebooks,omitempty"`
+	Weights             []*SearchWeight `protobuf:"bytes,9,rep,name=weights,proto3" json:"weights,omitempty"`
+	// shown as smart notebook in the notebook tree
+	Pinned        bool                   `protobuf:"varint,10,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedSearch) Reset() {
+	*x = SavedSearch{}
+	mi := &file_src_proto_note_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedSearch) ProtoMessage() {}
+
+func (x *SavedSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedSearch.ProtoReflect.Descriptor instead.
+func (*SavedSearch) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SavedSearch) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SavedSearch) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *SavedSearch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedSearch) GetSearchType() GetSearchNotesRequest_SearchType {
+	if x != nil {
+		return x.SearchType
+	}
+	return GetSearchNotesRequest_Undefined
+}
+
+func (x *SavedSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SavedSearch) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
+}
+
+func (x *SavedSearch) GetIncludeSubNotebooks() bool {
+	if x != nil {
+		return x.IncludeSubNotebooks
+	}
+	return false
+}
+
+func (x *SavedSearch) GetWeights() []*SearchWeight {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *SavedSearch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetSavedSearchesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// authentication
+	UserId        int32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSavedSearchesRequest) Reset() {
+	*x = GetSavedSearchesRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSavedSearchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedSearchesRequest) ProtoMessage() {}
+
+func (x *GetSavedSearchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedSearchesRequest.ProtoReflect.Descriptor instead.
+func (*GetSavedSearchesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetSavedSearchesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetSavedSearchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Searches      []*SavedSearch         `protobuf:"bytes,1,rep,name=searches,proto3" json:"searches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSavedSearchesResponse) Reset() {
+	*x = GetSavedSearchesResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSavedSearchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedSearchesResponse) ProtoMessage() {}
+
+func (x *GetSavedSearchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedSearchesResponse.ProtoReflect.Descriptor instead.
+func (*GetSavedSearchesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetSavedSearchesResponse) GetSearches() []*SavedSearch {
+	if x != nil {
+		return x.Searches
+	}
+	return nil
+}
+
+// Request for a single saved search. Fails with NOT_FOUND for saved searches
+// of other users
+type GetSavedSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSavedSearchRequest) Reset() {
+	*x = GetSavedSearchRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedSearchRequest) ProtoMessage() {}
+
+func (x *GetSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*GetSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetSavedSearchRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetSavedSearchRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type PostSavedSearchRequest struct {
+	state               protoimpl.MessageState           `protogen:"open.v1"`
+	Name                string                           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SearchType          GetSearchNotesRequest_SearchType `protobuf:"varint,2,opt,name=search_type,json=searchType,proto3,enum=proto.GetSearchNotesRequest_SearchType" json:"search_type,omitempty"`
+	Query               string                           `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Tags                []string                         `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	NotebookId          *int32                           `protobuf:"varint,5,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"`
+	IncludeSubNotebooks bool                             `protobuf:"varint,6,opt,name=include_sub_notebooks,json=includeSubNotebooks,proto3" json:"include_sub_notebooks,omitempty"`
+	Weights             []*SearchWeight                  `protobuf:"bytes,7,rep,name=weights,proto3" json:"weights,omitempty"`
+	Pinned              bool                             `protobuf:"varint,8,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostSavedSearchRequest) Reset() {
+	*x = PostSavedSearchRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSavedSearchRequest) ProtoMessage() {}
+
+func (x *PostSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*PostSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *PostSavedSearchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PostSavedSearchRequest) GetSearchType() GetSearchNotesRequest_SearchType {
+	if x != nil {
+		return x.SearchType
+	}
+	return GetSearchNotesRequest_Undefined
+}
+
+func (x *PostSavedSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *PostSavedSearchRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PostSavedSearchRequest) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
+}
+
+func (x *PostSavedSearchRequest) GetIncludeSubNotebooks() bool {
+	if x != nil {
+		return x.IncludeSubNotebooks
+	}
+	return false
+}
+
+func (x *PostSavedSearchRequest) GetWeights() []*SearchWeight {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *PostSavedSearchRequest) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *PostSavedSearchRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Weights of a saved search. Wrapped, so an empty list can be told apart
+// from no change
+type SearchWeightList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weights       []*SearchWeight        `protobuf:"bytes,1,rep,name=weights,proto3" json:"weights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchWeightList) Reset() {
+	*x = SearchWeightList{}
+	mi := &file_src_proto_note_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchWeightList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchWeightList) ProtoMessage() {}
+
+func (x *SearchWeightList) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchWeightList.ProtoReflect.Descriptor instead.
+func (*SearchWeightList) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SearchWeightList) GetWeights() []*SearchWeight {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+// Request to change a saved search. Fields which are unset are left
+// untouched. Fails with NOT_FOUND for saved searches of other users
+type AlterSavedSearchRequest struct {
+	state      protoimpl.MessageState            `protogen:"open.v1"`
+	Id         int32                             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       *string                           `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	SearchType *GetSearchNotesRequest_SearchType `protobuf:"varint,3,opt,name=search_type,json=searchType,proto3,enum=proto.GetSearchNotesRequest_SearchType,oneof" json:"search_type,omitempty"`
+	Query      *string                           `protobuf:"bytes,4,opt,name=query,proto3,oneof" json:"query,omitempty"`
+	Tags       *TagList                          `protobuf:"bytes,5,opt,name=tags,proto3,oneof" json:"tags,omitempty"`
+	// 0 removes the notebook filter
+	NotebookId          *int32            `protobuf:"varint,6,opt,name=notebook_id,json=notebookId,proto3,oneof" json:"notebook_id,omitempty"`
+	IncludeSubNotebooks *bool             `protobuf:"varint,7,opt,name=include_sub_notebooks,json=includeSubNotebooks,proto3,oneof" json:"include_sub_notebooks,omitempty"`
+	Weights             *SearchWeightList `protobuf:"bytes,8,opt,name=weights,proto3,oneof" json:"weights,omitempty"`
+	Pinned              *bool             `protobuf:"varint,9,opt,name=pinned,proto3,oneof" json:"pinned,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,10,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlterSavedSearchRequest) Reset() {
+	*x = AlterSavedSearchRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlterSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlterSavedSearchRequest) ProtoMessage() {}
+
+func (x *AlterSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlterSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*AlterSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *AlterSavedSearchRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlterSavedSearchRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *AlterSavedSearchRequest) GetSearchType() GetSearchNotesRequest_SearchType {
+	if x != nil && x.SearchType != nil {
+		return *x.SearchType
+	}
+	return GetSearchNotesRequest_Undefined
+}
+
+func (x *AlterSavedSearchRequest) GetQuery() string {
+	if x != nil && x.Query != nil {
+		return *x.Query
+	}
+	return ""
+}
+
+func (x *AlterSavedSearchRequest) GetTags() *TagList {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AlterSavedSearchRequest) GetNotebookId() int32 {
+	if x != nil && x.NotebookId != nil {
+		return *x.NotebookId
+	}
+	return 0
+}
+
+func (x *AlterSavedSearchRequest) GetIncludeSubNotebooks() bool {
+	if x != nil && x.IncludeSubNotebooks != nil {
+		return *x.IncludeSubNotebooks
+	}
+	return false
+}
+
+func (x *AlterSavedSearchRequest) GetWeights() *SearchWeightList {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *AlterSavedSearchRequest) GetPinned() bool {
+	if x != nil && x.Pinned != nil {
+		return *x.Pinned
+	}
+	return false
+}
+
+func (x *AlterSavedSearchRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to delete a saved search. Fails with NOT_FOUND for saved searches
+// of other users
+type DeleteSavedSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// authentication
+	UserId        int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedSearchRequest) Reset() {
+	*x = DeleteSavedSearchRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedSearchRequest) ProtoMessage() {}
+
+func (x *DeleteSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *DeleteSavedSearchRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteSavedSearchRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteSavedSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedSearchResponse) Reset() {
+	*x = DeleteSavedSearchResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedSearchResponse) ProtoMessage() {}
+
+func (x *DeleteSavedSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedSearchResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSavedSearchResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *AlterNoteRequest) GetId() int32 {
+func (x *DeleteSavedSearchResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Id
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *AlterNoteRequest) GetTitle() string {
-	if x != nil && x.Title != nil {
-		return *x.Title
+// Request for completions of a note title while the user types. It is sent
+// on every keystroke, so it has to be answered fast
+type SuggestNoteTitlesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// titles containing a word starting with the prefix, ignoring case.
+	// Titles starting with it come first
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// authentication, only notes the user can read are suggested
+	UserId        int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestNoteTitlesRequest) Reset() {
+	*x = SuggestNoteTitlesRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestNoteTitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestNoteTitlesRequest) ProtoMessage() {}
+
+func (x *SuggestNoteTitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestNoteTitlesRequest.ProtoReflect.Descriptor instead.
+func (*SuggestNoteTitlesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *SuggestNoteTitlesRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
 	}
 	return ""
 }
 
-func (x *AlterNoteRequest) GetContent() string {
-	if x != nil && x.Content != nil {
-		return *x.Content
+func (x *SuggestNoteTitlesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SuggestNoteTitlesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type TitleSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TitleSuggestion) Reset() {
+	*x = TitleSuggestion{}
+	mi := &file_src_proto_note_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TitleSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TitleSuggestion) ProtoMessage() {}
+
+func (x *TitleSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TitleSuggestion.ProtoReflect.Descriptor instead.
+func (*TitleSuggestion) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *TitleSuggestion) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *TitleSuggestion) GetTitle() string {
+	if x != nil {
+		return x.Title
 	}
 	return ""
 }
 
-func (x *AlterNoteRequest) GetAuthorId() int32 {
-	if x != nil && x.AuthorId != nil {
-		return *x.AuthorId
+type SuggestNoteTitlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*TitleSuggestion     `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestNoteTitlesResponse) Reset() {
+	*x = SuggestNoteTitlesResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestNoteTitlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestNoteTitlesResponse) ProtoMessage() {}
+
+func (x *SuggestNoteTitlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestNoteTitlesResponse.ProtoReflect.Descriptor instead.
+func (*SuggestNoteTitlesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *SuggestNoteTitlesResponse) GetSuggestions() []*TitleSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+// Request for all notes authored by a user, e.g. to export them
+type GetUserNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserNotesRequest) Reset() {
+	*x = GetUserNotesRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserNotesRequest) ProtoMessage() {}
+
+func (x *GetUserNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserNotesRequest.ProtoReflect.Descriptor instead.
+func (*GetUserNotesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetUserNotesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Request to permanently delete all notes authored by a user, including
+// those in the trash
+type DeleteUserNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserNotesRequest) Reset() {
+	*x = DeleteUserNotesRequest{}
+	mi := &file_src_proto_note_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserNotesRequest) ProtoMessage() {}
+
+func (x *DeleteUserNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserNotesRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserNotesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *DeleteUserNotesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteUserNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int32                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"` // number of deleted notes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserNotesResponse) Reset() {
+	*x = DeleteUserNotesResponse{}
+	mi := &file_src_proto_note_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserNotesResponse) ProtoMessage() {}
+
+func (x *DeleteUserNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_note_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserNotesResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserNotesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_note_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *DeleteUserNotesResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
 	}
 	return 0
 }
@@ -605,28 +5595,91 @@ const file_src_proto_note_proto_rawDesc = "" +
 	"\x14src/proto/note.proto\x12\x05proto\x1a\x1fgoogle/protobuf/timestamp.proto\"9\n" +
 	"\x0eGetNoteRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\x94\x02\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\x94\a\n" +
 	"\x15GetSearchNotesRequest\x12H\n" +
 	"\vsearch_type\x18\x01 \x01(\x0e2'.proto.GetSearchNotesRequest.SearchTypeR\n" +
 	"searchType\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x17\n" +
-	"\auser_id\x18\x05 \x01(\x05R\x06userId\"T\n" +
+	"\auser_id\x18\x05 \x01(\x05R\x06userId\x12.\n" +
+	"\x05after\x18\x06 \x01(\v2\x13.proto.SearchCursorH\x00R\x05after\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12$\n" +
+	"\vnotebook_id\x18\b \x01(\x05H\x01R\n" +
+	"notebookId\x88\x01\x01\x122\n" +
+	"\x15include_sub_notebooks\x18\t \x01(\bR\x13includeSubNotebooks\x12-\n" +
+	"\aweights\x18\n" +
+	" \x03(\v2\x13.proto.SearchWeightR\aweights\x12:\n" +
+	"\thighlight\x18\v \x01(\v2\x17.proto.HighlightOptionsH\x02R\thighlight\x88\x01\x01\x12D\n" +
+	"\rupdated_after\x18\f \x01(\v2\x1a.google.protobuf.TimestampH\x03R\fupdatedAfter\x88\x01\x01\x12F\n" +
+	"\x0eupdated_before\x18\r \x01(\v2\x1a.google.protobuf.TimestampH\x04R\rupdatedBefore\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"author_ids\x18\x0e \x03(\x05R\tauthorIds\x12#\n" +
+	"\rexcluded_tags\x18\x0f \x03(\tR\fexcludedTags\x12#\n" +
+	"\rtitle_phrases\x18\x10 \x03(\tR\ftitlePhrases\x12%\n" +
+	"\x0eexcluded_terms\x18\x11 \x03(\tR\rexcludedTerms\"`\n" +
 	"\n" +
 	"SearchType\x12\r\n" +
 	"\tUndefined\x10\x00\x12\f\n" +
 	"\bNoSearch\x10\x01\x12\x11\n" +
 	"\rFullTextTitle\x10\x02\x12\t\n" +
 	"\x05Fuzzy\x10\x03\x12\v\n" +
-	"\aContext\x10\x04\"\xb6\x01\n" +
+	"\aContext\x10\x04\x12\n" +
+	"\n" +
+	"\x06Hybrid\x10\x05B\b\n" +
+	"\x06_afterB\x0e\n" +
+	"\f_notebook_idB\f\n" +
+	"\n" +
+	"_highlightB\x10\n" +
+	"\x0e_updated_afterB\x11\n" +
+	"\x0f_updated_before\"\xb3\x01\n" +
+	"\x10HighlightOptions\x12#\n" +
+	"\rfragment_size\x18\x01 \x01(\x05R\ffragmentSize\x12%\n" +
+	"\x0efragment_count\x18\x02 \x01(\x05R\rfragmentCount\x12\x17\n" +
+	"\apre_tag\x18\x03 \x01(\tR\x06preTag\x12\x19\n" +
+	"\bpost_tag\x18\x04 \x01(\tR\apostTag\x12\x1f\n" +
+	"\vescape_html\x18\x05 \x01(\bR\n" +
+	"escapeHtml\"L\n" +
+	"\bFragment\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"p\n" +
+	"\fSearchWeight\x12H\n" +
+	"\vsearch_type\x18\x01 \x01(\x0e2'.proto.GetSearchNotesRequest.SearchTypeR\n" +
+	"searchType\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\"\x81\x01\n" +
+	"\vSearchMatch\x12H\n" +
+	"\vsearch_type\x18\x01 \x01(\x0e2'.proto.GetSearchNotesRequest.SearchTypeR\n" +
+	"searchType\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x05R\x04rank\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"o\n" +
+	"\fSearchCursor\x129\n" +
+	"\n" +
+	"updated_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\x05R\x02id\"\xf7\x04\n" +
 	"\vMinimalNote\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1b\n" +
 	"\tauthor_id\x18\x03 \x01(\x05R\bauthorId\x129\n" +
 	"\n" +
 	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12)\n" +
-	"\x10stripped_content\x18\x05 \x01(\tR\x0fstrippedContent\"\xdd\x01\n" +
+	"\x10stripped_content\x18\x05 \x01(\tR\x0fstrippedContent\x12+\n" +
+	"\x06cursor\x18\x06 \x01(\v2\x13.proto.SearchCursorR\x06cursor\x12>\n" +
+	"\faccess_level\x18\a \x01(\x0e2\x1b.proto.NotePermission.LevelR\vaccessLevel\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12$\n" +
+	"\vnotebook_id\x18\t \x01(\x05H\x00R\n" +
+	"notebookId\x88\x01\x01\x12>\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tdeletedAt\x88\x01\x01\x12\x14\n" +
+	"\x05score\x18\v \x01(\x01R\x05score\x12,\n" +
+	"\amatches\x18\f \x03(\v2\x12.proto.SearchMatchR\amatches\x120\n" +
+	"\x11highlighted_title\x18\r \x01(\tH\x02R\x10highlightedTitle\x88\x01\x01\x12-\n" +
+	"\tfragments\x18\x0e \x03(\v2\x0f.proto.FragmentR\tfragmentsB\x0e\n" +
+	"\f_notebook_idB\r\n" +
+	"\v_deleted_atB\x14\n" +
+	"\x12_highlighted_title\"\x9d\x03\n" +
 	"\x04Note\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
@@ -634,32 +5687,439 @@ const file_src_proto_note_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
 	"\tauthor_id\x18\x05 \x01(\x05R\bauthorId\x127\n" +
-	"\vpermissions\x18\a \x03(\v2\x15.proto.NotePermissionR\vpermissionsJ\x04\b\x06\x10\a\"C\n" +
+	"\vpermissions\x18\a \x03(\v2\x15.proto.NotePermissionR\vpermissions\x12>\n" +
+	"\faccess_level\x18\b \x01(\x0e2\x1b.proto.NotePermission.LevelR\vaccessLevel\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12$\n" +
+	"\vnotebook_id\x18\v \x01(\x05H\x00R\n" +
+	"notebookId\x88\x01\x01\x12\x1a\n" +
+	"\brevision\x18\f \x01(\x03R\brevisionB\x0e\n" +
+	"\f_notebook_idJ\x04\b\x06\x10\a\"C\n" +
 	"\rNoteEmbedding\x12\x14\n" +
 	"\x05model\x18\x01 \x01(\tR\x05model\x12\x1c\n" +
-	"\tembedding\x18\x02 \x03(\x02R\tembedding\")\n" +
+	"\tembedding\x18\x02 \x03(\x02R\tembedding\"\xaa\x01\n" +
 	"\x0eNotePermission\x12\x17\n" +
-	"\arole_id\x18\x01 \x01(\x05R\x06roleId\"o\n" +
+	"\arole_id\x18\x01 \x01(\x05R\x06roleId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x121\n" +
+	"\x05level\x18\x03 \x01(\x0e2\x1b.proto.NotePermission.LevelR\x05level\"3\n" +
+	"\x05Level\x12\b\n" +
+	"\x04None\x10\x00\x12\b\n" +
+	"\x04Read\x10\x01\x12\v\n" +
+	"\aComment\x10\x02\x12\t\n" +
+	"\x05Write\x10\x03\"\xe0\x01\n" +
 	"\x0fPostNoteRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1d\n" +
 	"\acontent\x18\x02 \x01(\tH\x00R\acontent\x88\x01\x01\x12\x1b\n" +
-	"\tauthor_id\x18\x03 \x01(\x05R\bauthorIdB\n" +
+	"\tauthor_id\x18\x03 \x01(\x05R\bauthorId\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12$\n" +
+	"\vnotebook_id\x18\x05 \x01(\x05H\x01R\n" +
+	"notebookId\x88\x01\x01\x12%\n" +
+	"\x05links\x18\x06 \x03(\v2\x0f.proto.NoteLinkR\x05linksB\n" +
 	"\n" +
-	"\b_content\"\xa2\x01\n" +
+	"\b_contentB\x0e\n" +
+	"\f_notebook_id\"\x1d\n" +
+	"\aTagList\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"9\n" +
+	"\bNoteLink\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x17\n" +
+	"\anote_id\x18\x02 \x01(\x05R\x06noteId\"5\n" +
+	"\fNoteLinkList\x12%\n" +
+	"\x05links\x18\x01 \x03(\v2\x0f.proto.NoteLinkR\x05links\"\xee\x03\n" +
 	"\x10AlterNoteRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x19\n" +
 	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1d\n" +
 	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01\x12 \n" +
-	"\tauthor_id\x18\x04 \x01(\x05H\x02R\bauthorId\x88\x01\x01B\b\n" +
+	"\tauthor_id\x18\x04 \x01(\x05H\x02R\bauthorId\x88\x01\x01\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\x05R\x06userId\x122\n" +
+	"\x10expected_version\x18\x06 \x01(\x05B\x02\x18\x01H\x03R\x0fexpectedVersion\x88\x01\x01\x12'\n" +
+	"\x04tags\x18\a \x01(\v2\x0e.proto.TagListH\x04R\x04tags\x88\x01\x01\x12$\n" +
+	"\vnotebook_id\x18\b \x01(\x05H\x05R\n" +
+	"notebookId\x88\x01\x01\x12.\n" +
+	"\x05links\x18\t \x01(\v2\x13.proto.NoteLinkListH\x06R\x05links\x88\x01\x01\x120\n" +
+	"\x11expected_revision\x18\n" +
+	" \x01(\x03H\aR\x10expectedRevision\x88\x01\x01B\b\n" +
 	"\x06_titleB\n" +
 	"\n" +
 	"\b_contentB\f\n" +
 	"\n" +
-	"_author_id2\xb0\x01\n" +
+	"_author_idB\x13\n" +
+	"\x11_expected_versionB\a\n" +
+	"\x05_tagsB\x0e\n" +
+	"\f_notebook_idB\b\n" +
+	"\x06_linksB\x14\n" +
+	"\x12_expected_revision\"\xcd\x01\n" +
+	"\x11DeleteNoteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x122\n" +
+	"\x10expected_version\x18\x03 \x01(\x05B\x02\x18\x01H\x00R\x0fexpectedVersion\x88\x01\x01\x120\n" +
+	"\x11expected_revision\x18\x04 \x01(\x03H\x01R\x10expectedRevision\x88\x01\x01B\x13\n" +
+	"\x11_expected_versionB\x14\n" +
+	"\x12_expected_revision\".\n" +
+	"\x12DeleteNoteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"M\n" +
+	"\x19GetNotePermissionsRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"U\n" +
+	"\x1aGetNotePermissionsResponse\x127\n" +
+	"\vpermissions\x18\x01 \x03(\v2\x15.proto.NotePermissionR\vpermissions\"\x85\x01\n" +
+	"\x1aGrantNotePermissionRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x125\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\v2\x15.proto.NotePermissionR\n" +
+	"permission\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"\x86\x01\n" +
+	"\x1bRevokeNotePermissionRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x125\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\v2\x15.proto.NotePermissionR\n" +
+	"permission\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"8\n" +
+	"\x1cRevokeNotePermissionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"^\n" +
+	"\x15GetSharedNotesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xe4\x02\n" +
+	"\tShareLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\anote_id\x18\x02 \x01(\x05R\x06noteId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01\x12!\n" +
+	"\fhas_password\x18\x05 \x01(\bR\vhasPassword\x12!\n" +
+	"\faccess_count\x18\x06 \x01(\x03R\vaccessCount\x12I\n" +
+	"\x10last_accessed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x0elastAccessedAt\x88\x01\x01B\r\n" +
+	"\v_expires_atB\x13\n" +
+	"\x11_last_accessed_at\"\xf2\x01\n" +
+	"\x14PostShareLinkRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x1d\n" +
+	"\n" +
+	"token_hash\x18\x02 \x01(\fR\ttokenHash\x12>\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01\x12(\n" +
+	"\rpassword_hash\x18\x04 \x01(\fH\x01R\fpasswordHash\x88\x01\x01\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\x05R\x06userIdB\r\n" +
+	"\v_expires_atB\x10\n" +
+	"\x0e_password_hash\"H\n" +
+	"\x14GetShareLinksRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"?\n" +
+	"\x15GetShareLinksResponse\x12&\n" +
+	"\x05links\x18\x01 \x03(\v2\x10.proto.ShareLinkR\x05links\"Z\n" +
+	"\x16DeleteShareLinkRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\anote_id\x18\x02 \x01(\x05R\x06noteId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"3\n" +
+	"\x17DeleteShareLinkResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\":\n" +
+	"\x19GetNoteByShareLinkRequest\x12\x1d\n" +
+	"\n" +
+	"token_hash\x18\x01 \x01(\fR\ttokenHash\"\x9f\x01\n" +
+	"\x1aGetNoteByShareLinkResponse\x12$\n" +
+	"\x04link\x18\x01 \x01(\v2\x10.proto.ShareLinkR\x04link\x12\x1f\n" +
+	"\x04note\x18\x02 \x01(\v2\v.proto.NoteR\x04note\x12(\n" +
+	"\rpassword_hash\x18\x03 \x01(\fH\x00R\fpasswordHash\x88\x01\x01B\x10\n" +
+	"\x0e_password_hash\".\n" +
+	"\x1cRecordShareLinkAccessRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"B\n" +
+	"\x1dRecordShareLinkAccessResponse\x12!\n" +
+	"\faccess_count\x18\x01 \x01(\x03R\vaccessCount\"\x84\x02\n" +
+	"\vNoteVersion\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12\x1b\n" +
+	"\tauthor_id\x18\x05 \x01(\x05R\bauthorId\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12(\n" +
+	"\rrestored_from\x18\a \x01(\x05H\x00R\frestoredFrom\x88\x01\x01B\x10\n" +
+	"\x0e_restored_from\"x\n" +
+	"\x16GetNoteVersionsRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x05R\x06userId\"I\n" +
+	"\x17GetNoteVersionsResponse\x12.\n" +
+	"\bversions\x18\x01 \x03(\v2\x12.proto.NoteVersionR\bversions\"c\n" +
+	"\x15GetNoteVersionRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"\x8e\x01\n" +
+	"\x19RestoreNoteVersionRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12%\n" +
+	"\x05links\x18\x04 \x03(\v2\x0f.proto.NoteLinkR\x05links\"8\n" +
+	"\x03Tag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"note_count\x18\x02 \x01(\x05R\tnoteCount\"v\n" +
+	"\x0eGetTagsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\x06prefix\x18\x02 \x01(\tH\x00R\x06prefix\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x03 \x01(\x05H\x01R\x05limit\x88\x01\x01B\t\n" +
+	"\a_prefixB\b\n" +
+	"\x06_limit\"1\n" +
+	"\x0fGetTagsResponse\x12\x1e\n" +
+	"\x04tags\x18\x01 \x03(\v2\n" +
+	".proto.TagR\x04tags\"Z\n" +
+	"\x10RenameTagRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"]\n" +
+	"\x10MergeTagsRequest\x12\x18\n" +
+	"\asources\x18\x01 \x03(\tR\asources\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"1\n" +
+	"\x11AlterTagsResponse\x12\x1c\n" +
+	"\x03tag\x18\x01 \x01(\v2\n" +
+	".proto.TagR\x03tag\"\x87\x03\n" +
+	"\bNotebook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x05H\x00R\bparentId\x88\x01\x01\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\x05R\aownerId\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
+	"\vpermissions\x18\a \x03(\v2\x15.proto.NotePermissionR\vpermissions\x12>\n" +
+	"\faccess_level\x18\b \x01(\x0e2\x1b.proto.NotePermission.LevelR\vaccessLevel\x12\x1d\n" +
+	"\n" +
+	"note_count\x18\t \x01(\x05R\tnoteCountB\f\n" +
+	"\n" +
+	"_parent_id\"=\n" +
+	"\x12GetNotebookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\".\n" +
+	"\x13GetNotebooksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"E\n" +
+	"\x14GetNotebooksResponse\x12-\n" +
+	"\tnotebooks\x18\x01 \x03(\v2\x0f.proto.NotebookR\tnotebooks\"r\n" +
+	"\x13PostNotebookRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\tparent_id\x18\x02 \x01(\x05H\x00R\bparentId\x88\x01\x01\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userIdB\f\n" +
+	"\n" +
+	"_parent_id\"\x91\x01\n" +
+	"\x14AlterNotebookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x05H\x01R\bparentId\x88\x01\x01\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x05R\x06userIdB\a\n" +
+	"\x05_nameB\f\n" +
+	"\n" +
+	"_parent_id\"@\n" +
+	"\x15DeleteNotebookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"2\n" +
+	"\x16DeleteNotebookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Y\n" +
+	"\x1dGetNotebookPermissionsRequest\x12\x1f\n" +
+	"\vnotebook_id\x18\x01 \x01(\x05R\n" +
+	"notebookId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\x91\x01\n" +
+	"\x1eGrantNotebookPermissionRequest\x12\x1f\n" +
+	"\vnotebook_id\x18\x01 \x01(\x05R\n" +
+	"notebookId\x125\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\v2\x15.proto.NotePermissionR\n" +
+	"permission\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"\x92\x01\n" +
+	"\x1fRevokeNotebookPermissionRequest\x12\x1f\n" +
+	"\vnotebook_id\x18\x01 \x01(\x05R\n" +
+	"notebookId\x125\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\v2\x15.proto.NotePermissionR\n" +
+	"permission\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"\xda\x01\n" +
+	"\x10ResolvedNoteLink\x12#\n" +
+	"\x04link\x18\x01 \x01(\v2\x0f.proto.NoteLinkR\x04link\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.proto.ResolvedNoteLink.StatusR\x06status\x12+\n" +
+	"\x04note\x18\x03 \x01(\v2\x12.proto.MinimalNoteH\x00R\x04note\x88\x01\x01\"3\n" +
+	"\x06Status\x12\f\n" +
+	"\bDangling\x10\x00\x12\f\n" +
+	"\bResolved\x10\x01\x12\r\n" +
+	"\tAmbiguous\x10\x02B\a\n" +
+	"\x05_note\"G\n" +
+	"\x13GetNoteLinksRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"E\n" +
+	"\x14GetNoteLinksResponse\x12-\n" +
+	"\x05links\x18\x01 \x03(\v2\x17.proto.ResolvedNoteLinkR\x05links\"\xae\x01\n" +
+	"\x13GetNoteGraphRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12$\n" +
+	"\vnotebook_id\x18\x02 \x01(\x05H\x00R\n" +
+	"notebookId\x88\x01\x01\x122\n" +
+	"\x15include_sub_notebooks\x18\x03 \x01(\bR\x13includeSubNotebooks\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limitB\x0e\n" +
+	"\f_notebook_id\"=\n" +
+	"\rNoteGraphEdge\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\x05R\x06fromId\x12\x13\n" +
+	"\x05to_id\x18\x02 \x01(\x05R\x04toId\"a\n" +
+	"\tNoteGraph\x12(\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x12.proto.MinimalNoteR\x05nodes\x12*\n" +
+	"\x05edges\x18\x02 \x03(\v2\x14.proto.NoteGraphEdgeR\x05edges\"}\n" +
+	"\x16GetRelatedNotesRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tmin_score\x18\x04 \x01(\x01R\bminScore\"K\n" +
+	"\vRelatedNote\x12&\n" +
+	"\x04note\x18\x01 \x01(\v2\x12.proto.MinimalNoteR\x04note\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"C\n" +
+	"\x17GetRelatedNotesResponse\x12(\n" +
+	"\x05notes\x18\x01 \x03(\v2\x12.proto.RelatedNoteR\x05notes\"X\n" +
+	"\x0fGetTrashRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"@\n" +
+	"\x13RestoreNotesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x05R\x03ids\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"9\n" +
+	"\x14RestoreNotesResponse\x12!\n" +
+	"\x05notes\x18\x01 \x03(\v2\v.proto.NoteR\x05notes\"\xaa\x01\n" +
+	"\x11PurgeNotesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x05R\x03ids\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\x05H\x00R\x06userId\x88\x01\x01\x12F\n" +
+	"\x0edeleted_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\rdeletedBefore\x88\x01\x01B\n" +
+	"\n" +
+	"\b_user_idB\x11\n" +
+	"\x0f_deleted_before\",\n" +
+	"\x12PurgeNotesResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x05R\x06purged\"\xe7\x03\n" +
+	"\vSavedSearch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\x05R\aownerId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12H\n" +
+	"\vsearch_type\x18\x04 \x01(\x0e2'.proto.GetSearchNotesRequest.SearchTypeR\n" +
+	"searchType\x12\x14\n" +
+	"\x05query\x18\x05 \x01(\tR\x05query\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12$\n" +
+	"\vnotebook_id\x18\a \x01(\x05H\x00R\n" +
+	"notebookId\x88\x01\x01\x122\n" +
+	"\x15include_sub_notebooks\x18\b \x01(\bR\x13includeSubNotebooks\x12-\n" +
+	"\aweights\x18\t \x03(\v2\x13.proto.SearchWeightR\aweights\x12\x16\n" +
+	"\x06pinned\x18\n" +
+	" \x01(\bR\x06pinned\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"\f_notebook_id\"2\n" +
+	"\x17GetSavedSearchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"J\n" +
+	"\x18GetSavedSearchesResponse\x12.\n" +
+	"\bsearches\x18\x01 \x03(\v2\x12.proto.SavedSearchR\bsearches\"@\n" +
+	"\x15GetSavedSearchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\xea\x02\n" +
+	"\x16PostSavedSearchRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12H\n" +
+	"\vsearch_type\x18\x02 \x01(\x0e2'.proto.GetSearchNotesRequest.SearchTypeR\n" +
+	"searchType\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12$\n" +
+	"\vnotebook_id\x18\x05 \x01(\x05H\x00R\n" +
+	"notebookId\x88\x01\x01\x122\n" +
+	"\x15include_sub_notebooks\x18\x06 \x01(\bR\x13includeSubNotebooks\x12-\n" +
+	"\aweights\x18\a \x03(\v2\x13.proto.SearchWeightR\aweights\x12\x16\n" +
+	"\x06pinned\x18\b \x01(\bR\x06pinned\x12\x17\n" +
+	"\auser_id\x18\t \x01(\x05R\x06userIdB\x0e\n" +
+	"\f_notebook_id\"A\n" +
+	"\x10SearchWeightList\x12-\n" +
+	"\aweights\x18\x01 \x03(\v2\x13.proto.SearchWeightR\aweights\"\x8f\x04\n" +
+	"\x17AlterSavedSearchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12M\n" +
+	"\vsearch_type\x18\x03 \x01(\x0e2'.proto.GetSearchNotesRequest.SearchTypeH\x01R\n" +
+	"searchType\x88\x01\x01\x12\x19\n" +
+	"\x05query\x18\x04 \x01(\tH\x02R\x05query\x88\x01\x01\x12'\n" +
+	"\x04tags\x18\x05 \x01(\v2\x0e.proto.TagListH\x03R\x04tags\x88\x01\x01\x12$\n" +
+	"\vnotebook_id\x18\x06 \x01(\x05H\x04R\n" +
+	"notebookId\x88\x01\x01\x127\n" +
+	"\x15include_sub_notebooks\x18\a \x01(\bH\x05R\x13includeSubNotebooks\x88\x01\x01\x126\n" +
+	"\aweights\x18\b \x01(\v2\x17.proto.SearchWeightListH\x06R\aweights\x88\x01\x01\x12\x1b\n" +
+	"\x06pinned\x18\t \x01(\bH\aR\x06pinned\x88\x01\x01\x12\x17\n" +
+	"\auser_id\x18\n" +
+	" \x01(\x05R\x06userIdB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_search_typeB\b\n" +
+	"\x06_queryB\a\n" +
+	"\x05_tagsB\x0e\n" +
+	"\f_notebook_idB\x18\n" +
+	"\x16_include_sub_notebooksB\n" +
+	"\n" +
+	"\b_weightsB\t\n" +
+	"\a_pinned\"C\n" +
+	"\x18DeleteSavedSearchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"5\n" +
+	"\x19DeleteSavedSearchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"a\n" +
+	"\x18SuggestNoteTitlesRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"@\n" +
+	"\x0fTitleSuggestion\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\"U\n" +
+	"\x19SuggestNoteTitlesResponse\x128\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x16.proto.TitleSuggestionR\vsuggestions\".\n" +
+	"\x13GetUserNotesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"1\n" +
+	"\x16DeleteUserNotesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"3\n" +
+	"\x17DeleteUserNotesResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x05R\adeleted2\xe8\x18\n" +
 	"\vNoteService\x12-\n" +
 	"\aGetNote\x12\x15.proto.GetNoteRequest\x1a\v.proto.Note\x12/\n" +
-	"\bPostNote\x12\x16.proto.PostNoteRequest\x1a\v.proto.Note\x12A\n" +
-	"\vSearchNotes\x12\x1c.proto.GetSearchNotesRequest\x1a\x12.proto.MinimalNote0\x01B1Z/github.com/KuramaSyu/Wersu-Rest/src/proto;protob\x06proto3"
+	"\bPostNote\x12\x16.proto.PostNoteRequest\x1a\v.proto.Note\x121\n" +
+	"\tAlterNote\x12\x17.proto.AlterNoteRequest\x1a\v.proto.Note\x12A\n" +
+	"\n" +
+	"DeleteNote\x12\x18.proto.DeleteNoteRequest\x1a\x19.proto.DeleteNoteResponse\x12A\n" +
+	"\vSearchNotes\x12\x1c.proto.GetSearchNotesRequest\x1a\x12.proto.MinimalNote0\x01\x129\n" +
+	"\fGetUserNotes\x12\x1a.proto.GetUserNotesRequest\x1a\v.proto.Note0\x01\x12P\n" +
+	"\x0fDeleteUserNotes\x12\x1d.proto.DeleteUserNotesRequest\x1a\x1e.proto.DeleteUserNotesResponse\x12Y\n" +
+	"\x12GetNotePermissions\x12 .proto.GetNotePermissionsRequest\x1a!.proto.GetNotePermissionsResponse\x12O\n" +
+	"\x13GrantNotePermission\x12!.proto.GrantNotePermissionRequest\x1a\x15.proto.NotePermission\x12_\n" +
+	"\x14RevokeNotePermission\x12\".proto.RevokeNotePermissionRequest\x1a#.proto.RevokeNotePermissionResponse\x12D\n" +
+	"\x0eGetSharedNotes\x12\x1c.proto.GetSharedNotesRequest\x1a\x12.proto.MinimalNote0\x01\x12>\n" +
+	"\rPostShareLink\x12\x1b.proto.PostShareLinkRequest\x1a\x10.proto.ShareLink\x12J\n" +
+	"\rGetShareLinks\x12\x1b.proto.GetShareLinksRequest\x1a\x1c.proto.GetShareLinksResponse\x12P\n" +
+	"\x0fDeleteShareLink\x12\x1d.proto.DeleteShareLinkRequest\x1a\x1e.proto.DeleteShareLinkResponse\x12Y\n" +
+	"\x12GetNoteByShareLink\x12 .proto.GetNoteByShareLinkRequest\x1a!.proto.GetNoteByShareLinkResponse\x12b\n" +
+	"\x15RecordShareLinkAccess\x12#.proto.RecordShareLinkAccessRequest\x1a$.proto.RecordShareLinkAccessResponse\x12P\n" +
+	"\x0fGetNoteVersions\x12\x1d.proto.GetNoteVersionsRequest\x1a\x1e.proto.GetNoteVersionsResponse\x12B\n" +
+	"\x0eGetNoteVersion\x12\x1c.proto.GetNoteVersionRequest\x1a\x12.proto.NoteVersion\x12C\n" +
+	"\x12RestoreNoteVersion\x12 .proto.RestoreNoteVersionRequest\x1a\v.proto.Note\x128\n" +
+	"\aGetTags\x12\x15.proto.GetTagsRequest\x1a\x16.proto.GetTagsResponse\x12>\n" +
+	"\tRenameTag\x12\x17.proto.RenameTagRequest\x1a\x18.proto.AlterTagsResponse\x12>\n" +
+	"\tMergeTags\x12\x17.proto.MergeTagsRequest\x1a\x18.proto.AlterTagsResponse\x129\n" +
+	"\vGetNotebook\x12\x19.proto.GetNotebookRequest\x1a\x0f.proto.Notebook\x12G\n" +
+	"\fGetNotebooks\x12\x1a.proto.GetNotebooksRequest\x1a\x1b.proto.GetNotebooksResponse\x12;\n" +
+	"\fPostNotebook\x12\x1a.proto.PostNotebookRequest\x1a\x0f.proto.Notebook\x12=\n" +
+	"\rAlterNotebook\x12\x1b.proto.AlterNotebookRequest\x1a\x0f.proto.Notebook\x12M\n" +
+	"\x0eDeleteNotebook\x12\x1c.proto.DeleteNotebookRequest\x1a\x1d.proto.DeleteNotebookResponse\x12a\n" +
+	"\x16GetNotebookPermissions\x12$.proto.GetNotebookPermissionsRequest\x1a!.proto.GetNotePermissionsResponse\x12W\n" +
+	"\x17GrantNotebookPermission\x12%.proto.GrantNotebookPermissionRequest\x1a\x15.proto.NotePermission\x12g\n" +
+	"\x18RevokeNotebookPermission\x12&.proto.RevokeNotebookPermissionRequest\x1a#.proto.RevokeNotePermissionResponse\x12G\n" +
+	"\fGetNoteLinks\x12\x1a.proto.GetNoteLinksRequest\x1a\x1b.proto.GetNoteLinksResponse\x12D\n" +
+	"\x10GetNoteBacklinks\x12\x1a.proto.GetNoteLinksRequest\x1a\x12.proto.MinimalNote0\x01\x12<\n" +
+	"\fGetNoteGraph\x12\x1a.proto.GetNoteGraphRequest\x1a\x10.proto.NoteGraph\x12P\n" +
+	"\x0fGetRelatedNotes\x12\x1d.proto.GetRelatedNotesRequest\x1a\x1e.proto.GetRelatedNotesResponse\x128\n" +
+	"\bGetTrash\x12\x16.proto.GetTrashRequest\x1a\x12.proto.MinimalNote0\x01\x12G\n" +
+	"\fRestoreNotes\x12\x1a.proto.RestoreNotesRequest\x1a\x1b.proto.RestoreNotesResponse\x12A\n" +
+	"\n" +
+	"PurgeNotes\x12\x18.proto.PurgeNotesRequest\x1a\x19.proto.PurgeNotesResponse\x12S\n" +
+	"\x10GetSavedSearches\x12\x1e.proto.GetSavedSearchesRequest\x1a\x1f.proto.GetSavedSearchesResponse\x12B\n" +
+	"\x0eGetSavedSearch\x12\x1c.proto.GetSavedSearchRequest\x1a\x12.proto.SavedSearch\x12D\n" +
+	"\x0fPostSavedSearch\x12\x1d.proto.PostSavedSearchRequest\x1a\x12.proto.SavedSearch\x12F\n" +
+	"\x10AlterSavedSearch\x12\x1e.proto.AlterSavedSearchRequest\x1a\x12.proto.SavedSearch\x12V\n" +
+	"\x11DeleteSavedSearch\x12\x1f.proto.DeleteSavedSearchRequest\x1a .proto.DeleteSavedSearchResponse\x12V\n" +
+	"\x11SuggestNoteTitles\x12\x1f.proto.SuggestNoteTitlesRequest\x1a .proto.SuggestNoteTitlesResponseB1Z/github.com/KuramaSyu/Wersu-Rest/src/proto;protob\x06proto3"
 
 var (
 	file_src_proto_note_proto_rawDescOnce sync.Once
@@ -673,36 +6133,258 @@ func file_src_proto_note_proto_rawDescGZIP() []byte {
 	return file_src_proto_note_proto_rawDescData
 }
 
-var file_src_proto_note_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_src_proto_note_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_src_proto_note_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_src_proto_note_proto_msgTypes = make([]protoimpl.MessageInfo, 85)
 var file_src_proto_note_proto_goTypes = []any{
-	(GetSearchNotesRequest_SearchType)(0), // 0: proto.GetSearchNotesRequest.SearchType
-	(*GetNoteRequest)(nil),                // 1: proto.GetNoteRequest
-	(*GetSearchNotesRequest)(nil),         // 2: proto.GetSearchNotesRequest
-	(*MinimalNote)(nil),                   // 3: proto.MinimalNote
-	(*Note)(nil),                          // 4: proto.Note
-	(*NoteEmbedding)(nil),                 // 5: proto.NoteEmbedding
-	(*NotePermission)(nil),                // 6: proto.NotePermission
-	(*PostNoteRequest)(nil),               // 7: proto.PostNoteRequest
-	(*AlterNoteRequest)(nil),              // 8: proto.AlterNoteRequest
-	(*timestamppb.Timestamp)(nil),         // 9: google.protobuf.Timestamp
+	(GetSearchNotesRequest_SearchType)(0),   // 0: proto.GetSearchNotesRequest.SearchType
+	(NotePermission_Level)(0),               // 1: proto.NotePermission.Level
+	(ResolvedNoteLink_Status)(0),            // 2: proto.ResolvedNoteLink.Status
+	(*GetNoteRequest)(nil),                  // 3: proto.GetNoteRequest
+	(*GetSearchNotesRequest)(nil),           // 4: proto.GetSearchNotesRequest
+	(*HighlightOptions)(nil),                // 5: proto.HighlightOptions
+	(*Fragment)(nil),                        // 6: proto.Fragment
+	(*SearchWeight)(nil),                    // 7: proto.SearchWeight
+	(*SearchMatch)(nil),                     // 8: proto.SearchMatch
+	(*SearchCursor)(nil),                    // 9: proto.SearchCursor
+	(*MinimalNote)(nil),                     // 10: proto.MinimalNote
+	(*Note)(nil),                            // 11: proto.Note
+	(*NoteEmbedding)(nil),                   // 12: proto.NoteEmbedding
+	(*NotePermission)(nil),                  // 13: proto.NotePermission
+	(*PostNoteRequest)(nil),                 // 14: proto.PostNoteRequest
+	(*TagList)(nil),                         // 15: proto.TagList
+	(*NoteLink)(nil),                        // 16: proto.NoteLink
+	(*NoteLinkList)(nil),                    // 17: proto.NoteLinkList
+	(*AlterNoteRequest)(nil),                // 18: proto.AlterNoteRequest
+	(*DeleteNoteRequest)(nil),               // 19: proto.DeleteNoteRequest
+	(*DeleteNoteResponse)(nil),              // 20: proto.DeleteNoteResponse
+	(*GetNotePermissionsRequest)(nil),       // 21: proto.GetNotePermissionsRequest
+	(*GetNotePermissionsResponse)(nil),      // 22: proto.GetNotePermissionsResponse
+	(*GrantNotePermissionRequest)(nil),      // 23: proto.GrantNotePermissionRequest
+	(*RevokeNotePermissionRequest)(nil),     // 24: proto.RevokeNotePermissionRequest
+	(*RevokeNotePermissionResponse)(nil),    // 25: proto.RevokeNotePermissionResponse
+	(*GetSharedNotesRequest)(nil),           // 26: proto.GetSharedNotesRequest
+	(*ShareLink)(nil),                       // 27: proto.ShareLink
+	(*PostShareLinkRequest)(nil),            // 28: proto.PostShareLinkRequest
+	(*GetShareLinksRequest)(nil),            // 29: proto.GetShareLinksRequest
+	(*GetShareLinksResponse)(nil),           // 30: proto.GetShareLinksResponse
+	(*DeleteShareLinkRequest)(nil),          // 31: proto.DeleteShareLinkRequest
+	(*DeleteShareLinkResponse)(nil),         // 32: proto.DeleteShareLinkResponse
+	(*GetNoteByShareLinkRequest)(nil),       // 33: proto.GetNoteByShareLinkRequest
+	(*GetNoteByShareLinkResponse)(nil),      // 34: proto.GetNoteByShareLinkResponse
+	(*RecordShareLinkAccessRequest)(nil),    // 35: proto.RecordShareLinkAccessRequest
+	(*RecordShareLinkAccessResponse)(nil),   // 36: proto.RecordShareLinkAccessResponse
+	(*NoteVersion)(nil),                     // 37: proto.NoteVersion
+	(*GetNoteVersionsRequest)(nil),          // 38: proto.GetNoteVersionsRequest
+	(*GetNoteVersionsResponse)(nil),         // 39: proto.GetNoteVersionsResponse
+	(*GetNoteVersionRequest)(nil),           // 40: proto.GetNoteVersionRequest
+	(*RestoreNoteVersionRequest)(nil),       // 41: proto.RestoreNoteVersionRequest
+	(*Tag)(nil),                             // 42: proto.Tag
+	(*GetTagsRequest)(nil),                  // 43: proto.GetTagsRequest
+	(*GetTagsResponse)(nil),                 // 44: proto.GetTagsResponse
+	(*RenameTagRequest)(nil),                // 45: proto.RenameTagRequest
+	(*MergeTagsRequest)(nil),                // 46: proto.MergeTagsRequest
+	(*AlterTagsResponse)(nil),               // 47: proto.AlterTagsResponse
+	(*Notebook)(nil),                        // 48: proto.Notebook
+	(*GetNotebookRequest)(nil),              // 49: proto.GetNotebookRequest
+	(*GetNotebooksRequest)(nil),             // 50: proto.GetNotebooksRequest
+	(*GetNotebooksResponse)(nil),            // 51: proto.GetNotebooksResponse
+	(*PostNotebookRequest)(nil),             // 52: proto.PostNotebookRequest
+	(*AlterNotebookRequest)(nil),            // 53: proto.AlterNotebookRequest
+	(*DeleteNotebookRequest)(nil),           // 54: proto.DeleteNotebookRequest
+	(*DeleteNotebookResponse)(nil),          // 55: proto.DeleteNotebookResponse
+	(*GetNotebookPermissionsRequest)(nil),   // 56: proto.GetNotebookPermissionsRequest
+	(*GrantNotebookPermissionRequest)(nil),  // 57: proto.GrantNotebookPermissionRequest
+	(*RevokeNotebookPermissionRequest)(nil), // 58: proto.RevokeNotebookPermissionRequest
+	(*ResolvedNoteLink)(nil),                // 59: proto.ResolvedNoteLink
+	(*GetNoteLinksRequest)(nil),             // 60: proto.GetNoteLinksRequest
+	(*GetNoteLinksResponse)(nil),            // 61: proto.GetNoteLinksResponse
+	(*GetNoteGraphRequest)(nil),             // 62: proto.GetNoteGraphRequest
+	(*NoteGraphEdge)(nil),                   // 63: proto.NoteGraphEdge
+	(*NoteGraph)(nil),                       // 64: proto.NoteGraph
+	(*GetRelatedNotesRequest)(nil),          // 65: proto.GetRelatedNotesRequest
+	(*RelatedNote)(nil),                     // 66: proto.RelatedNote
+	(*GetRelatedNotesResponse)(nil),         // 67: proto.GetRelatedNotesResponse
+	(*GetTrashRequest)(nil),                 // 68: proto.GetTrashRequest
+	(*RestoreNotesRequest)(nil),             // 69: proto.RestoreNotesRequest
+	(*RestoreNotesResponse)(nil),            // 70: proto.RestoreNotesResponse
+	(*PurgeNotesRequest)(nil),               // 71: proto.PurgeNotesRequest
+	(*PurgeNotesResponse)(nil),              // 72: proto.PurgeNotesResponse
+	(*SavedSearch)(nil),                     // 73: proto.SavedSearch
+	(*GetSavedSearchesRequest)(nil),         // 74: proto.GetSavedSearchesRequest
+	(*GetSavedSearchesResponse)(nil),        // 75: proto.GetSavedSearchesResponse
+	(*GetSavedSearchRequest)(nil),           // 76: proto.GetSavedSearchRequest
+	(*PostSavedSearchRequest)(nil),          // 77: proto.PostSavedSearchRequest
+	(*SearchWeightList)(nil),                // 78: proto.SearchWeightList
+	(*AlterSavedSearchRequest)(nil),         // 79: proto.AlterSavedSearchRequest
+	(*DeleteSavedSearchRequest)(nil),        // 80: proto.DeleteSavedSearchRequest
+	(*DeleteSavedSearchResponse)(nil),       // 81: proto.DeleteSavedSearchResponse
+	(*SuggestNoteTitlesRequest)(nil),        // 82: proto.SuggestNoteTitlesRequest
+	(*TitleSuggestion)(nil),                 // 83: proto.TitleSuggestion
+	(*SuggestNoteTitlesResponse)(nil),       // 84: proto.SuggestNoteTitlesResponse
+	(*GetUserNotesRequest)(nil),             // 85: proto.GetUserNotesRequest
+	(*DeleteUserNotesRequest)(nil),          // 86: proto.DeleteUserNotesRequest
+	(*DeleteUserNotesResponse)(nil),         // 87: proto.DeleteUserNotesResponse
+	(*timestamppb.Timestamp)(nil),           // 88: google.protobuf.Timestamp
 }
 var file_src_proto_note_proto_depIdxs = []int32{
-	0, // 0: proto.GetSearchNotesRequest.search_type:type_name -> proto.GetSearchNotesRequest.SearchType
-	9, // 1: proto.MinimalNote.updated_at:type_name -> google.protobuf.Timestamp
-	9, // 2: proto.Note.updated_at:type_name -> google.protobuf.Timestamp
-	6, // 3: proto.Note.permissions:type_name -> proto.NotePermission
-	1, // 4: proto.NoteService.GetNote:input_type -> proto.GetNoteRequest
-	7, // 5: proto.NoteService.PostNote:input_type -> proto.PostNoteRequest
-	2, // 6: proto.NoteService.SearchNotes:input_type -> proto.GetSearchNotesRequest
-	4, // 7: proto.NoteService.GetNote:output_type -> proto.Note
-	4, // 8: proto.NoteService.PostNote:output_type -> proto.Note
-	3, // 9: proto.NoteService.SearchNotes:output_type -> proto.MinimalNote
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,   // 0: proto.GetSearchNotesRequest.search_type:type_name -> proto.GetSearchNotesRequest.SearchType
+	9,   // 1: proto.GetSearchNotesRequest.after:type_name -> proto.SearchCursor
+	7,   // 2: proto.GetSearchNotesRequest.weights:type_name -> proto.SearchWeight
+	5,   // 3: proto.GetSearchNotesRequest.highlight:type_name -> proto.HighlightOptions
+	88,  // 4: proto.GetSearchNotesRequest.updated_after:type_name -> google.protobuf.Timestamp
+	88,  // 5: proto.GetSearchNotesRequest.updated_before:type_name -> google.protobuf.Timestamp
+	0,   // 6: proto.SearchWeight.search_type:type_name -> proto.GetSearchNotesRequest.SearchType
+	0,   // 7: proto.SearchMatch.search_type:type_name -> proto.GetSearchNotesRequest.SearchType
+	88,  // 8: proto.SearchCursor.updated_at:type_name -> google.protobuf.Timestamp
+	88,  // 9: proto.MinimalNote.updated_at:type_name -> google.protobuf.Timestamp
+	9,   // 10: proto.MinimalNote.cursor:type_name -> proto.SearchCursor
+	1,   // 11: proto.MinimalNote.access_level:type_name -> proto.NotePermission.Level
+	88,  // 12: proto.MinimalNote.deleted_at:type_name -> google.protobuf.Timestamp
+	8,   // 13: proto.MinimalNote.matches:type_name -> proto.SearchMatch
+	6,   // 14: proto.MinimalNote.fragments:type_name -> proto.Fragment
+	88,  // 15: proto.Note.updated_at:type_name -> google.protobuf.Timestamp
+	13,  // 16: proto.Note.permissions:type_name -> proto.NotePermission
+	1,   // 17: proto.Note.access_level:type_name -> proto.NotePermission.Level
+	1,   // 18: proto.NotePermission.level:type_name -> proto.NotePermission.Level
+	16,  // 19: proto.PostNoteRequest.links:type_name -> proto.NoteLink
+	16,  // 20: proto.NoteLinkList.links:type_name -> proto.NoteLink
+	15,  // 21: proto.AlterNoteRequest.tags:type_name -> proto.TagList
+	17,  // 22: proto.AlterNoteRequest.links:type_name -> proto.NoteLinkList
+	13,  // 23: proto.GetNotePermissionsResponse.permissions:type_name -> proto.NotePermission
+	13,  // 24: proto.GrantNotePermissionRequest.permission:type_name -> proto.NotePermission
+	13,  // 25: proto.RevokeNotePermissionRequest.permission:type_name -> proto.NotePermission
+	88,  // 26: proto.ShareLink.created_at:type_name -> google.protobuf.Timestamp
+	88,  // 27: proto.ShareLink.expires_at:type_name -> google.protobuf.Timestamp
+	88,  // 28: proto.ShareLink.last_accessed_at:type_name -> google.protobuf.Timestamp
+	88,  // 29: proto.PostShareLinkRequest.expires_at:type_name -> google.protobuf.Timestamp
+	27,  // 30: proto.GetShareLinksResponse.links:type_name -> proto.ShareLink
+	27,  // 31: proto.GetNoteByShareLinkResponse.link:type_name -> proto.ShareLink
+	11,  // 32: proto.GetNoteByShareLinkResponse.note:type_name -> proto.Note
+	88,  // 33: proto.NoteVersion.created_at:type_name -> google.protobuf.Timestamp
+	37,  // 34: proto.GetNoteVersionsResponse.versions:type_name -> proto.NoteVersion
+	16,  // 35: proto.RestoreNoteVersionRequest.links:type_name -> proto.NoteLink
+	42,  // 36: proto.GetTagsResponse.tags:type_name -> proto.Tag
+	42,  // 37: proto.AlterTagsResponse.tag:type_name -> proto.Tag
+	88,  // 38: proto.Notebook.created_at:type_name -> google.protobuf.Timestamp
+	88,  // 39: proto.Notebook.updated_at:type_name -> google.protobuf.Timestamp
+	13,  // 40: proto.Notebook.permissions:type_name -> proto.NotePermission
+	1,   // 41: proto.Notebook.access_level:type_name -> proto.NotePermission.Level
+	48,  // 42: proto.GetNotebooksResponse.notebooks:type_name -> proto.Notebook
+	13,  // 43: proto.GrantNotebookPermissionRequest.permission:type_name -> proto.NotePermission
+	13,  // 44: proto.RevokeNotebookPermissionRequest.permission:type_name -> proto.NotePermission
+	16,  // 45: proto.ResolvedNoteLink.link:type_name -> proto.NoteLink
+	2,   // 46: proto.ResolvedNoteLink.status:type_name -> proto.ResolvedNoteLink.Status
+	10,  // 47: proto.ResolvedNoteLink.note:type_name -> proto.MinimalNote
+	59,  // 48: proto.GetNoteLinksResponse.links:type_name -> proto.ResolvedNoteLink
+	10,  // 49: proto.NoteGraph.nodes:type_name -> proto.MinimalNote
+	63,  // 50: proto.NoteGraph.edges:type_name -> proto.NoteGraphEdge
+	10,  // 51: proto.RelatedNote.note:type_name -> proto.MinimalNote
+	66,  // 52: proto.GetRelatedNotesResponse.notes:type_name -> proto.RelatedNote
+	11,  // 53: proto.RestoreNotesResponse.notes:type_name -> proto.Note
+	88,  // 54: proto.PurgeNotesRequest.deleted_before:type_name -> google.protobuf.Timestamp
+	0,   // 55: proto.SavedSearch.search_type:type_name -> proto.GetSearchNotesRequest.SearchType
+	7,   // 56: proto.SavedSearch.weights:type_name -> proto.SearchWeight
+	88,  // 57: proto.SavedSearch.created_at:type_name -> google.protobuf.Timestamp
+	88,  // 58: proto.SavedSearch.updated_at:type_name -> google.protobuf.Timestamp
+	73,  // 59: proto.GetSavedSearchesResponse.searches:type_name -> proto.SavedSearch
+	0,   // 60: proto.PostSavedSearchRequest.search_type:type_name -> proto.GetSearchNotesRequest.SearchType
+	7,   // 61: proto.PostSavedSearchRequest.weights:type_name -> proto.SearchWeight
+	7,   // 62: proto.SearchWeightList.weights:type_name -> proto.SearchWeight
+	0,   // 63: proto.AlterSavedSearchRequest.search_type:type_name -> proto.GetSearchNotesRequest.SearchType
+	15,  // 64: proto.AlterSavedSearchRequest.tags:type_name -> proto.TagList
+	78,  // 65: proto.AlterSavedSearchRequest.weights:type_name -> proto.SearchWeightList
+	83,  // 66: proto.SuggestNoteTitlesResponse.suggestions:type_name -> proto.TitleSuggestion
+	3,   // 67: proto.NoteService.GetNote:input_type -> proto.GetNoteRequest
+	14,  // 68: proto.NoteService.PostNote:input_type -> proto.PostNoteRequest
+	18,  // 69: proto.NoteService.AlterNote:input_type -> proto.AlterNoteRequest
+	19,  // 70: proto.NoteService.DeleteNote:input_type -> proto.DeleteNoteRequest
+	4,   // 71: proto.NoteService.SearchNotes:input_type -> proto.GetSearchNotesRequest
+	85,  // 72: proto.NoteService.GetUserNotes:input_type -> proto.GetUserNotesRequest
+	86,  // 73: proto.NoteService.DeleteUserNotes:input_type -> proto.DeleteUserNotesRequest
+	21,  // 74: proto.NoteService.GetNotePermissions:input_type -> proto.GetNotePermissionsRequest
+	23,  // 75: proto.NoteService.GrantNotePermission:input_type -> proto.GrantNotePermissionRequest
+	24,  // 76: proto.NoteService.RevokeNotePermission:input_type -> proto.RevokeNotePermissionRequest
+	26,  // 77: proto.NoteService.GetSharedNotes:input_type -> proto.GetSharedNotesRequest
+	28,  // 78: proto.NoteService.PostShareLink:input_type -> proto.PostShareLinkRequest
+	29,  // 79: proto.NoteService.GetShareLinks:input_type -> proto.GetShareLinksRequest
+	31,  // 80: proto.NoteService.DeleteShareLink:input_type -> proto.DeleteShareLinkRequest
+	33,  // 81: proto.NoteService.GetNoteByShareLink:input_type -> proto.GetNoteByShareLinkRequest
+	35,  // 82: proto.NoteService.RecordShareLinkAccess:input_type -> proto.RecordShareLinkAccessRequest
+	38,  // 83: proto.NoteService.GetNoteVersions:input_type -> proto.GetNoteVersionsRequest
+	40,  // 84: proto.NoteService.GetNoteVersion:input_type -> proto.GetNoteVersionRequest
+	41,  // 85: proto.NoteService.RestoreNoteVersion:input_type -> proto.RestoreNoteVersionRequest
+	43,  // 86: proto.NoteService.GetTags:input_type -> proto.GetTagsRequest
+	45,  // 87: proto.NoteService.RenameTag:input_type -> proto.RenameTagRequest
+	46,  // 88: proto.NoteService.MergeTags:input_type -> proto.MergeTagsRequest
+	49,  // 89: proto.NoteService.GetNotebook:input_type -> proto.GetNotebookRequest
+	50,  // 90: proto.NoteService.GetNotebooks:input_type -> proto.GetNotebooksRequest
+	52,  // 91: proto.NoteService.PostNotebook:input_type -> proto.PostNotebookRequest
+	53,  // 92: proto.NoteService.AlterNotebook:input_type -> proto.AlterNotebookRequest
+	54,  // 93: proto.NoteService.DeleteNotebook:input_type -> proto.DeleteNotebookRequest
+	56,  // 94: proto.NoteService.GetNotebookPermissions:input_type -> proto.GetNotebookPermissionsRequest
+	57,  // 95: proto.NoteService.GrantNotebookPermission:input_type -> proto.GrantNotebookPermissionRequest
+	58,  // 96: proto.NoteService.RevokeNotebookPermission:input_type -> proto.RevokeNotebookPermissionRequest
+	60,  // 97: proto.NoteService.GetNoteLinks:input_type -> proto.GetNoteLinksRequest
+	60,  // 98: proto.NoteService.GetNoteBacklinks:input_type -> proto.GetNoteLinksRequest
+	62,  // 99: proto.NoteService.GetNoteGraph:input_type -> proto.GetNoteGraphRequest
+	65,  // 100: proto.NoteService.GetRelatedNotes:input_type -> proto.GetRelatedNotesRequest
+	68,  // 101: proto.NoteService.GetTrash:input_type -> proto.GetTrashRequest
+	69,  // 102: proto.NoteService.RestoreNotes:input_type -> proto.RestoreNotesRequest
+	71,  // 103: proto.NoteService.PurgeNotes:input_type -> proto.PurgeNotesRequest
+	74,  // 104: proto.NoteService.GetSavedSearches:input_type -> proto.GetSavedSearchesRequest
+	76,  // 105: proto.NoteService.GetSavedSearch:input_type -> proto.GetSavedSearchRequest
+	77,  // 106: proto.NoteService.PostSavedSearch:input_type -> proto.PostSavedSearchRequest
+	79,  // 107: proto.NoteService.AlterSavedSearch:input_type -> proto.AlterSavedSearchRequest
+	80,  // 108: proto.NoteService.DeleteSavedSearch:input_type -> proto.DeleteSavedSearchRequest
+	82,  // 109: proto.NoteService.SuggestNoteTitles:input_type -> proto.SuggestNoteTitlesRequest
+	11,  // 110: proto.NoteService.GetNote:output_type -> proto.Note
+	11,  // 111: proto.NoteService.PostNote:output_type -> proto.Note
+	11,  // 112: proto.NoteService.AlterNote:output_type -> proto.Note
+	20,  // 113: proto.NoteService.DeleteNote:output_type -> proto.DeleteNoteResponse
+	10,  // 114: proto.NoteService.SearchNotes:output_type -> proto.MinimalNote
+	11,  // 115: proto.NoteService.GetUserNotes:output_type -> proto.Note
+	87,  // 116: proto.NoteService.DeleteUserNotes:output_type -> proto.DeleteUserNotesResponse
+	22,  // 117: proto.NoteService.GetNotePermissions:output_type -> proto.GetNotePermissionsResponse
+	13,  // 118: proto.NoteService.GrantNotePermission:output_type -> proto.NotePermission
+	25,  // 119: proto.NoteService.RevokeNotePermission:output_type -> proto.RevokeNotePermissionResponse
+	10,  // 120: proto.NoteService.GetSharedNotes:output_type -> proto.MinimalNote
+	27,  // 121: proto.NoteService.PostShareLink:output_type -> proto.ShareLink
+	30,  // 122: proto.NoteService.GetShareLinks:output_type -> proto.GetShareLinksResponse
+	32,  // 123: proto.NoteService.DeleteShareLink:output_type -> proto.DeleteShareLinkResponse
+	34,  // 124: proto.NoteService.GetNoteByShareLink:output_type -> proto.GetNoteByShareLinkResponse
+	36,  // 125: proto.NoteService.RecordShareLinkAccess:output_type -> proto.RecordShareLinkAccessResponse
+	39,  // 126: proto.NoteService.GetNoteVersions:output_type -> proto.GetNoteVersionsResponse
+	37,  // 127: proto.NoteService.GetNoteVersion:output_type -> proto.NoteVersion
+	11,  // 128: proto.NoteService.RestoreNoteVersion:output_type -> proto.Note
+	44,  // 129: proto.NoteService.GetTags:output_type -> proto.GetTagsResponse
+	47,  // 130: proto.NoteService.RenameTag:output_type -> proto.AlterTagsResponse
+	47,  // 131: proto.NoteService.MergeTags:output_type -> proto.AlterTagsResponse
+	48,  // 132: proto.NoteService.GetNotebook:output_type -> proto.Notebook
+	51,  // 133: proto.NoteService.GetNotebooks:output_type -> proto.GetNotebooksResponse
+	48,  // 134: proto.NoteService.PostNotebook:output_type -> proto.Notebook
+	48,  // 135: proto.NoteService.AlterNotebook:output_type -> proto.Notebook
+	55,  // 136: proto.NoteService.DeleteNotebook:output_type -> proto.DeleteNotebookResponse
+	22,  // 137: proto.NoteService.GetNotebookPermissions:output_type -> proto.GetNotePermissionsResponse
+	13,  // 138: proto.NoteService.GrantNotebookPermission:output_type -> proto.NotePermission
+	25,  // 139: proto.NoteService.RevokeNotebookPermission:output_type -> proto.RevokeNotePermissionResponse
+	61,  // 140: proto.NoteService.GetNoteLinks:output_type -> proto.GetNoteLinksResponse
+	10,  // 141: proto.NoteService.GetNoteBacklinks:output_type -> proto.MinimalNote
+	64,  // 142: proto.NoteService.GetNoteGraph:output_type -> proto.NoteGraph
+	67,  // 143: proto.NoteService.GetRelatedNotes:output_type -> proto.GetRelatedNotesResponse
+	10,  // 144: proto.NoteService.GetTrash:output_type -> proto.MinimalNote
+	70,  // 145: proto.NoteService.RestoreNotes:output_type -> proto.RestoreNotesResponse
+	72,  // 146: proto.NoteService.PurgeNotes:output_type -> proto.PurgeNotesResponse
+	75,  // 147: proto.NoteService.GetSavedSearches:output_type -> proto.GetSavedSearchesResponse
+	73,  // 148: proto.NoteService.GetSavedSearch:output_type -> proto.SavedSearch
+	73,  // 149: proto.NoteService.PostSavedSearch:output_type -> proto.SavedSearch
+	73,  // 150: proto.NoteService.AlterSavedSearch:output_type -> proto.SavedSearch
+	81,  // 151: proto.NoteService.DeleteSavedSearch:output_type -> proto.DeleteSavedSearchResponse
+	84,  // 152: proto.NoteService.SuggestNoteTitles:output_type -> proto.SuggestNoteTitlesResponse
+	110, // [110:153] is the sub-list for method output_type
+	67,  // [67:110] is the sub-list for method input_type
+	67,  // [67:67] is the sub-list for extension type_name
+	67,  // [67:67] is the sub-list for extension extendee
+	0,   // [0:67] is the sub-list for field type_name
 }
 
 func init() { file_src_proto_note_proto_init() }
@@ -710,15 +6392,33 @@ func file_src_proto_note_proto_init() {
 	if File_src_proto_note_proto != nil {
 		return
 	}
-	file_src_proto_note_proto_msgTypes[6].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[1].OneofWrappers = []any{}
 	file_src_proto_note_proto_msgTypes[7].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[8].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[11].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[15].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[16].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[24].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[25].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[31].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[34].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[40].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[45].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[49].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[50].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[56].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[59].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[68].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[70].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[74].OneofWrappers = []any{}
+	file_src_proto_note_proto_msgTypes[76].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_src_proto_note_proto_rawDesc), len(file_src_proto_note_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   85,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
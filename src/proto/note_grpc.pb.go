@@ -19,9 +19,49 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NoteService_GetNote_FullMethodName     = "/proto.NoteService/GetNote"
-	NoteService_PostNote_FullMethodName    = "/proto.NoteService/PostNote"
-	NoteService_SearchNotes_FullMethodName = "/proto.NoteService/SearchNotes"
+	NoteService_GetNote_FullMethodName                  = "/proto.NoteService/GetNote"
+	NoteService_PostNote_FullMethodName                 = "/proto.NoteService/PostNote"
+	NoteService_AlterNote_FullMethodName                = "/proto.NoteService/AlterNote"
+	NoteService_DeleteNote_FullMethodName               = "/proto.NoteService/DeleteNote"
+	NoteService_SearchNotes_FullMethodName              = "/proto.NoteService/SearchNotes"
+	NoteService_GetUserNotes_FullMethodName             = "/proto.NoteService/GetUserNotes"
+	NoteService_DeleteUserNotes_FullMethodName          = "/proto.NoteService/DeleteUserNotes"
+	NoteService_GetNotePermissions_FullMethodName       = "/proto.NoteService/GetNotePermissions"
+	NoteService_GrantNotePermission_FullMethodName      = "/proto.NoteService/GrantNotePermission"
+	NoteService_RevokeNotePermission_FullMethodName     = "/proto.NoteService/RevokeNotePermission"
+	NoteService_GetSharedNotes_FullMethodName           = "/proto.NoteService/GetSharedNotes"
+	NoteService_PostShareLink_FullMethodName            = "/proto.NoteService/PostShareLink"
+	NoteService_GetShareLinks_FullMethodName            = "/proto.NoteService/GetShareLinks"
+	NoteService_DeleteShareLink_FullMethodName          = "/proto.NoteService/DeleteShareLink"
+	NoteService_GetNoteByShareLink_FullMethodName       = "/proto.NoteService/GetNoteByShareLink"
+	NoteService_RecordShareLinkAccess_FullMethodName    = "/proto.NoteService/RecordShareLinkAccess"
+	NoteService_GetNoteVersions_FullMethodName          = "/proto.NoteService/GetNoteVersions"
+	NoteService_GetNoteVersion_FullMethodName           = "/proto.NoteService/GetNoteVersion"
+	NoteService_RestoreNoteVersion_FullMethodName       = "/proto.NoteService/RestoreNoteVersion"
+	NoteService_GetTags_FullMethodName                  = "/proto.NoteService/GetTags"
+	NoteService_RenameTag_FullMethodName                = "/proto.NoteService/RenameTag"
+	NoteService_MergeTags_FullMethodName                = "/proto.NoteService/MergeTags"
+	NoteService_GetNotebook_FullMethodName              = "/proto.NoteService/GetNotebook"
+	NoteService_GetNotebooks_FullMethodName             = "/proto.NoteService/GetNotebooks"
+	NoteService_PostNotebook_FullMethodName             = "/proto.NoteService/PostNotebook"
+	NoteService_AlterNotebook_FullMethodName            = "/proto.NoteService/AlterNotebook"
+	NoteService_DeleteNotebook_FullMethodName           = "/proto.NoteService/DeleteNotebook"
+	NoteService_GetNotebookPermissions_FullMethodName   = "/proto.NoteService/GetNotebookPermissions"
+	NoteService_GrantNotebookPermission_FullMethodName  = "/proto.NoteService/GrantNotebookPermission"
+	NoteService_RevokeNotebookPermission_FullMethodName = "/proto.NoteService/RevokeNotebookPermission"
+	NoteService_GetNoteLinks_FullMethodName             = "/proto.NoteService/GetNoteLinks"
+	NoteService_GetNoteBacklinks_FullMethodName         = "/proto.NoteService/GetNoteBacklinks"
+	NoteService_GetNoteGraph_FullMethodName             = "/proto.NoteService/GetNoteGraph"
+	NoteService_GetRelatedNotes_FullMethodName          = "/proto.NoteService/GetRelatedNotes"
+	NoteService_GetTrash_FullMethodName                 = "/proto.NoteService/GetTrash"
+	NoteService_RestoreNotes_FullMethodName             = "/proto.NoteService/RestoreNotes"
+	NoteService_PurgeNotes_FullMethodName               = "/proto.NoteService/PurgeNotes"
+	NoteService_GetSavedSearches_FullMethodName         = "/proto.NoteService/GetSavedSearches"
+	NoteService_GetSavedSearch_FullMethodName           = "/proto.NoteService/GetSavedSearch"
+	NoteService_PostSavedSearch_FullMethodName          = "/proto.NoteService/PostSavedSearch"
+	NoteService_AlterSavedSearch_FullMethodName         = "/proto.NoteService/AlterSavedSearch"
+	NoteService_DeleteSavedSearch_FullMethodName        = "/proto.NoteService/DeleteSavedSearch"
+	NoteService_SuggestNoteTitles_FullMethodName        = "/proto.NoteService/SuggestNoteTitles"
 )
 
 // NoteServiceClient is the client API for NoteService service.
@@ -32,7 +72,56 @@ const (
 type NoteServiceClient interface {
 	GetNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error)
 	PostNote(ctx context.Context, in *PostNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	AlterNote(ctx context.Context, in *AlterNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error)
 	SearchNotes(ctx context.Context, in *GetSearchNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MinimalNote], error)
+	GetUserNotes(ctx context.Context, in *GetUserNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Note], error)
+	DeleteUserNotes(ctx context.Context, in *DeleteUserNotesRequest, opts ...grpc.CallOption) (*DeleteUserNotesResponse, error)
+	// sharing
+	GetNotePermissions(ctx context.Context, in *GetNotePermissionsRequest, opts ...grpc.CallOption) (*GetNotePermissionsResponse, error)
+	GrantNotePermission(ctx context.Context, in *GrantNotePermissionRequest, opts ...grpc.CallOption) (*NotePermission, error)
+	RevokeNotePermission(ctx context.Context, in *RevokeNotePermissionRequest, opts ...grpc.CallOption) (*RevokeNotePermissionResponse, error)
+	GetSharedNotes(ctx context.Context, in *GetSharedNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MinimalNote], error)
+	// public share links
+	PostShareLink(ctx context.Context, in *PostShareLinkRequest, opts ...grpc.CallOption) (*ShareLink, error)
+	GetShareLinks(ctx context.Context, in *GetShareLinksRequest, opts ...grpc.CallOption) (*GetShareLinksResponse, error)
+	DeleteShareLink(ctx context.Context, in *DeleteShareLinkRequest, opts ...grpc.CallOption) (*DeleteShareLinkResponse, error)
+	GetNoteByShareLink(ctx context.Context, in *GetNoteByShareLinkRequest, opts ...grpc.CallOption) (*GetNoteByShareLinkResponse, error)
+	RecordShareLinkAccess(ctx context.Context, in *RecordShareLinkAccessRequest, opts ...grpc.CallOption) (*RecordShareLinkAccessResponse, error)
+	// version history
+	GetNoteVersions(ctx context.Context, in *GetNoteVersionsRequest, opts ...grpc.CallOption) (*GetNoteVersionsResponse, error)
+	GetNoteVersion(ctx context.Context, in *GetNoteVersionRequest, opts ...grpc.CallOption) (*NoteVersion, error)
+	RestoreNoteVersion(ctx context.Context, in *RestoreNoteVersionRequest, opts ...grpc.CallOption) (*Note, error)
+	// tags
+	GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error)
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*AlterTagsResponse, error)
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*AlterTagsResponse, error)
+	// notebooks
+	GetNotebook(ctx context.Context, in *GetNotebookRequest, opts ...grpc.CallOption) (*Notebook, error)
+	GetNotebooks(ctx context.Context, in *GetNotebooksRequest, opts ...grpc.CallOption) (*GetNotebooksResponse, error)
+	PostNotebook(ctx context.Context, in *PostNotebookRequest, opts ...grpc.CallOption) (*Notebook, error)
+	AlterNotebook(ctx context.Context, in *AlterNotebookRequest, opts ...grpc.CallOption) (*Notebook, error)
+	DeleteNotebook(ctx context.Context, in *DeleteNotebookRequest, opts ...grpc.CallOption) (*DeleteNotebookResponse, error)
+	GetNotebookPermissions(ctx context.Context, in *GetNotebookPermissionsRequest, opts ...grpc.CallOption) (*GetNotePermissionsResponse, error)
+	GrantNotebookPermission(ctx context.Context, in *GrantNotebookPermissionRequest, opts ...grpc.CallOption) (*NotePermission, error)
+	RevokeNotebookPermission(ctx context.Context, in *RevokeNotebookPermissionRequest, opts ...grpc.CallOption) (*RevokeNotePermissionResponse, error)
+	// links between notes
+	GetNoteLinks(ctx context.Context, in *GetNoteLinksRequest, opts ...grpc.CallOption) (*GetNoteLinksResponse, error)
+	GetNoteBacklinks(ctx context.Context, in *GetNoteLinksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MinimalNote], error)
+	GetNoteGraph(ctx context.Context, in *GetNoteGraphRequest, opts ...grpc.CallOption) (*NoteGraph, error)
+	GetRelatedNotes(ctx context.Context, in *GetRelatedNotesRequest, opts ...grpc.CallOption) (*GetRelatedNotesResponse, error)
+	// trash
+	GetTrash(ctx context.Context, in *GetTrashRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MinimalNote], error)
+	RestoreNotes(ctx context.Context, in *RestoreNotesRequest, opts ...grpc.CallOption) (*RestoreNotesResponse, error)
+	PurgeNotes(ctx context.Context, in *PurgeNotesRequest, opts ...grpc.CallOption) (*PurgeNotesResponse, error)
+	// saved searches
+	GetSavedSearches(ctx context.Context, in *GetSavedSearchesRequest, opts ...grpc.CallOption) (*GetSavedSearchesResponse, error)
+	GetSavedSearch(ctx context.Context, in *GetSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error)
+	PostSavedSearch(ctx context.Context, in *PostSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error)
+	AlterSavedSearch(ctx context.Context, in *AlterSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, opts ...grpc.CallOption) (*DeleteSavedSearchResponse, error)
+	// search as you type
+	SuggestNoteTitles(ctx context.Context, in *SuggestNoteTitlesRequest, opts ...grpc.CallOption) (*SuggestNoteTitlesResponse, error)
 }
 
 type noteServiceClient struct {
@@ -63,120 +152,1417 @@ func (c *noteServiceClient) PostNote(ctx context.Context, in *PostNoteRequest, o
 	return out, nil
 }
 
+func (c *noteServiceClient) AlterNote(ctx context.Context, in *AlterNoteRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, NoteService_AlterNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteNoteResponse)
+	err := c.cc.Invoke(ctx, NoteService_DeleteNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *noteServiceClient) SearchNotes(ctx context.Context, in *GetSearchNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MinimalNote], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[0], NoteService_SearchNotes_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[GetSearchNotesRequest, MinimalNote]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	x := &grpc.GenericClientStream[GetSearchNotesRequest, MinimalNote]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_SearchNotesClient = grpc.ServerStreamingClient[MinimalNote]
+
+func (c *noteServiceClient) GetUserNotes(ctx context.Context, in *GetUserNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Note], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[1], NoteService_GetUserNotes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetUserNotesRequest, Note]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_GetUserNotesClient = grpc.ServerStreamingClient[Note]
+
+func (c *noteServiceClient) DeleteUserNotes(ctx context.Context, in *DeleteUserNotesRequest, opts ...grpc.CallOption) (*DeleteUserNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_DeleteUserNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNotePermissions(ctx context.Context, in *GetNotePermissionsRequest, opts ...grpc.CallOption) (*GetNotePermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotePermissionsResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetNotePermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GrantNotePermission(ctx context.Context, in *GrantNotePermissionRequest, opts ...grpc.CallOption) (*NotePermission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotePermission)
+	err := c.cc.Invoke(ctx, NoteService_GrantNotePermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RevokeNotePermission(ctx context.Context, in *RevokeNotePermissionRequest, opts ...grpc.CallOption) (*RevokeNotePermissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeNotePermissionResponse)
+	err := c.cc.Invoke(ctx, NoteService_RevokeNotePermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetSharedNotes(ctx context.Context, in *GetSharedNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MinimalNote], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[2], NoteService_GetSharedNotes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetSharedNotesRequest, MinimalNote]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_GetSharedNotesClient = grpc.ServerStreamingClient[MinimalNote]
+
+func (c *noteServiceClient) PostShareLink(ctx context.Context, in *PostShareLinkRequest, opts ...grpc.CallOption) (*ShareLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareLink)
+	err := c.cc.Invoke(ctx, NoteService_PostShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetShareLinks(ctx context.Context, in *GetShareLinksRequest, opts ...grpc.CallOption) (*GetShareLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShareLinksResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetShareLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DeleteShareLink(ctx context.Context, in *DeleteShareLinkRequest, opts ...grpc.CallOption) (*DeleteShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteShareLinkResponse)
+	err := c.cc.Invoke(ctx, NoteService_DeleteShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNoteByShareLink(ctx context.Context, in *GetNoteByShareLinkRequest, opts ...grpc.CallOption) (*GetNoteByShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNoteByShareLinkResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteByShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RecordShareLinkAccess(ctx context.Context, in *RecordShareLinkAccessRequest, opts ...grpc.CallOption) (*RecordShareLinkAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordShareLinkAccessResponse)
+	err := c.cc.Invoke(ctx, NoteService_RecordShareLinkAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNoteVersions(ctx context.Context, in *GetNoteVersionsRequest, opts ...grpc.CallOption) (*GetNoteVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNoteVersionsResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNoteVersion(ctx context.Context, in *GetNoteVersionRequest, opts ...grpc.CallOption) (*NoteVersion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteVersion)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RestoreNoteVersion(ctx context.Context, in *RestoreNoteVersionRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, NoteService_RestoreNoteVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTagsResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*AlterTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AlterTagsResponse)
+	err := c.cc.Invoke(ctx, NoteService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*AlterTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AlterTagsResponse)
+	err := c.cc.Invoke(ctx, NoteService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNotebook(ctx context.Context, in *GetNotebookRequest, opts ...grpc.CallOption) (*Notebook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notebook)
+	err := c.cc.Invoke(ctx, NoteService_GetNotebook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNotebooks(ctx context.Context, in *GetNotebooksRequest, opts ...grpc.CallOption) (*GetNotebooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotebooksResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetNotebooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) PostNotebook(ctx context.Context, in *PostNotebookRequest, opts ...grpc.CallOption) (*Notebook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notebook)
+	err := c.cc.Invoke(ctx, NoteService_PostNotebook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) AlterNotebook(ctx context.Context, in *AlterNotebookRequest, opts ...grpc.CallOption) (*Notebook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notebook)
+	err := c.cc.Invoke(ctx, NoteService_AlterNotebook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DeleteNotebook(ctx context.Context, in *DeleteNotebookRequest, opts ...grpc.CallOption) (*DeleteNotebookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteNotebookResponse)
+	err := c.cc.Invoke(ctx, NoteService_DeleteNotebook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNotebookPermissions(ctx context.Context, in *GetNotebookPermissionsRequest, opts ...grpc.CallOption) (*GetNotePermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotePermissionsResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetNotebookPermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GrantNotebookPermission(ctx context.Context, in *GrantNotebookPermissionRequest, opts ...grpc.CallOption) (*NotePermission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotePermission)
+	err := c.cc.Invoke(ctx, NoteService_GrantNotebookPermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RevokeNotebookPermission(ctx context.Context, in *RevokeNotebookPermissionRequest, opts ...grpc.CallOption) (*RevokeNotePermissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeNotePermissionResponse)
+	err := c.cc.Invoke(ctx, NoteService_RevokeNotebookPermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNoteLinks(ctx context.Context, in *GetNoteLinksRequest, opts ...grpc.CallOption) (*GetNoteLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNoteLinksResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetNoteBacklinks(ctx context.Context, in *GetNoteLinksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MinimalNote], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[3], NoteService_GetNoteBacklinks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetNoteLinksRequest, MinimalNote]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_GetNoteBacklinksClient = grpc.ServerStreamingClient[MinimalNote]
+
+func (c *noteServiceClient) GetNoteGraph(ctx context.Context, in *GetNoteGraphRequest, opts ...grpc.CallOption) (*NoteGraph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteGraph)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetRelatedNotes(ctx context.Context, in *GetRelatedNotesRequest, opts ...grpc.CallOption) (*GetRelatedNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetRelatedNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetTrash(ctx context.Context, in *GetTrashRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MinimalNote], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[4], NoteService_GetTrash_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetTrashRequest, MinimalNote]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_GetTrashClient = grpc.ServerStreamingClient[MinimalNote]
+
+func (c *noteServiceClient) RestoreNotes(ctx context.Context, in *RestoreNotesRequest, opts ...grpc.CallOption) (*RestoreNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_RestoreNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) PurgeNotes(ctx context.Context, in *PurgeNotesRequest, opts ...grpc.CallOption) (*PurgeNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_PurgeNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetSavedSearches(ctx context.Context, in *GetSavedSearchesRequest, opts ...grpc.CallOption) (*GetSavedSearchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSavedSearchesResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetSavedSearches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetSavedSearch(ctx context.Context, in *GetSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedSearch)
+	err := c.cc.Invoke(ctx, NoteService_GetSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) PostSavedSearch(ctx context.Context, in *PostSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedSearch)
+	err := c.cc.Invoke(ctx, NoteService_PostSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) AlterSavedSearch(ctx context.Context, in *AlterSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedSearch)
+	err := c.cc.Invoke(ctx, NoteService_AlterSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, opts ...grpc.CallOption) (*DeleteSavedSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSavedSearchResponse)
+	err := c.cc.Invoke(ctx, NoteService_DeleteSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) SuggestNoteTitles(ctx context.Context, in *SuggestNoteTitlesRequest, opts ...grpc.CallOption) (*SuggestNoteTitlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestNoteTitlesResponse)
+	err := c.cc.Invoke(ctx, NoteService_SuggestNoteTitles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NoteServiceServer is the server API for NoteService service.
+// All implementations must embed UnimplementedNoteServiceServer
+// for forward compatibility.
+//
+// Note Service
+type NoteServiceServer interface {
+	GetNote(context.Context, *GetNoteRequest) (*Note, error)
+	PostNote(context.Context, *PostNoteRequest) (*Note, error)
+	AlterNote(context.Context, *AlterNoteRequest) (*Note, error)
+	DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error)
+	SearchNotes(*GetSearchNotesRequest, grpc.ServerStreamingServer[MinimalNote]) error
+	GetUserNotes(*GetUserNotesRequest, grpc.ServerStreamingServer[Note]) error
+	DeleteUserNotes(context.Context, *DeleteUserNotesRequest) (*DeleteUserNotesResponse, error)
+	// sharing
+	GetNotePermissions(context.Context, *GetNotePermissionsRequest) (*GetNotePermissionsResponse, error)
+	GrantNotePermission(context.Context, *GrantNotePermissionRequest) (*NotePermission, error)
+	RevokeNotePermission(context.Context, *RevokeNotePermissionRequest) (*RevokeNotePermissionResponse, error)
+	GetSharedNotes(*GetSharedNotesRequest, grpc.ServerStreamingServer[MinimalNote]) error
+	// public share links
+	PostShareLink(context.Context, *PostShareLinkRequest) (*ShareLink, error)
+	GetShareLinks(context.Context, *GetShareLinksRequest) (*GetShareLinksResponse, error)
+	DeleteShareLink(context.Context, *DeleteShareLinkRequest) (*DeleteShareLinkResponse, error)
+	GetNoteByShareLink(context.Context, *GetNoteByShareLinkRequest) (*GetNoteByShareLinkResponse, error)
+	RecordShareLinkAccess(context.Context, *RecordShareLinkAccessRequest) (*RecordShareLinkAccessResponse, error)
+	// version history
+	GetNoteVersions(context.Context, *GetNoteVersionsRequest) (*GetNoteVersionsResponse, error)
+	GetNoteVersion(context.Context, *GetNoteVersionRequest) (*NoteVersion, error)
+	RestoreNoteVersion(context.Context, *RestoreNoteVersionRequest) (*Note, error)
+	// tags
+	GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error)
+	RenameTag(context.Context, *RenameTagRequest) (*AlterTagsResponse, error)
+	MergeTags(context.Context, *MergeTagsRequest) (*AlterTagsResponse, error)
+	// notebooks
+	GetNotebook(context.Context, *GetNotebookRequest) (*Notebook, error)
+	GetNotebooks(context.Context, *GetNotebooksRequest) (*GetNotebooksResponse, error)
+	PostNotebook(context.Context, *PostNotebookRequest) (*Notebook, error)
+	AlterNotebook(context.Context, *AlterNotebookRequest) (*Notebook, error)
+	DeleteNotebook(context.Context, *DeleteNotebookRequest) (*DeleteNotebookResponse, error)
+	GetNotebookPermissions(context.Context, *GetNotebookPermissionsRequest) (*GetNotePermissionsResponse, error)
+	GrantNotebookPermission(context.Context, *GrantNotebookPermissionRequest) (*NotePermission, error)
+	RevokeNotebookPermission(context.Context, *RevokeNotebookPermissionRequest) (*RevokeNotePermissionResponse, error)
+	// links between notes
+	GetNoteLinks(context.Context, *GetNoteLinksRequest) (*GetNoteLinksResponse, error)
+	GetNoteBacklinks(*GetNoteLinksRequest, grpc.ServerStreamingServer[MinimalNote]) error
+	GetNoteGraph(context.Context, *GetNoteGraphRequest) (*NoteGraph, error)
+	GetRelatedNotes(context.Context, *GetRelatedNotesRequest) (*GetRelatedNotesResponse, error)
+	// trash
+	GetTrash(*GetTrashRequest, grpc.ServerStreamingServer[MinimalNote]) error
+	RestoreNotes(context.Context, *RestoreNotesRequest) (*RestoreNotesResponse, error)
+	PurgeNotes(context.Context, *PurgeNotesRequest) (*PurgeNotesResponse, error)
+	// saved searches
+	GetSavedSearches(context.Context, *GetSavedSearchesRequest) (*GetSavedSearchesResponse, error)
+	GetSavedSearch(context.Context, *GetSavedSearchRequest) (*SavedSearch, error)
+	PostSavedSearch(context.Context, *PostSavedSearchRequest) (*SavedSearch, error)
+	AlterSavedSearch(context.Context, *AlterSavedSearchRequest) (*SavedSearch, error)
+	DeleteSavedSearch(context.Context, *DeleteSavedSearchRequest) (*DeleteSavedSearchResponse, error)
+	// search as you type
+	SuggestNoteTitles(context.Context, *SuggestNoteTitlesRequest) (*SuggestNoteTitlesResponse, error)
+	mustEmbedUnimplementedNoteServiceServer()
+}
+
+// UnimplementedNoteServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNoteServiceServer struct{}
+
+func (UnimplementedNoteServiceServer) GetNote(context.Context, *GetNoteRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNote not implemented")
+}
+func (UnimplementedNoteServiceServer) PostNote(context.Context, *PostNoteRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method PostNote not implemented")
+}
+func (UnimplementedNoteServiceServer) AlterNote(context.Context, *AlterNoteRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method AlterNote not implemented")
+}
+func (UnimplementedNoteServiceServer) DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteNote not implemented")
+}
+func (UnimplementedNoteServiceServer) SearchNotes(*GetSearchNotesRequest, grpc.ServerStreamingServer[MinimalNote]) error {
+	return status.Error(codes.Unimplemented, "method SearchNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) GetUserNotes(*GetUserNotesRequest, grpc.ServerStreamingServer[Note]) error {
+	return status.Error(codes.Unimplemented, "method GetUserNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) DeleteUserNotes(context.Context, *DeleteUserNotesRequest) (*DeleteUserNotesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteUserNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNotePermissions(context.Context, *GetNotePermissionsRequest) (*GetNotePermissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNotePermissions not implemented")
+}
+func (UnimplementedNoteServiceServer) GrantNotePermission(context.Context, *GrantNotePermissionRequest) (*NotePermission, error) {
+	return nil, status.Error(codes.Unimplemented, "method GrantNotePermission not implemented")
+}
+func (UnimplementedNoteServiceServer) RevokeNotePermission(context.Context, *RevokeNotePermissionRequest) (*RevokeNotePermissionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeNotePermission not implemented")
+}
+func (UnimplementedNoteServiceServer) GetSharedNotes(*GetSharedNotesRequest, grpc.ServerStreamingServer[MinimalNote]) error {
+	return status.Error(codes.Unimplemented, "method GetSharedNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) PostShareLink(context.Context, *PostShareLinkRequest) (*ShareLink, error) {
+	return nil, status.Error(codes.Unimplemented, "method PostShareLink not implemented")
+}
+func (UnimplementedNoteServiceServer) GetShareLinks(context.Context, *GetShareLinksRequest) (*GetShareLinksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetShareLinks not implemented")
+}
+func (UnimplementedNoteServiceServer) DeleteShareLink(context.Context, *DeleteShareLinkRequest) (*DeleteShareLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteShareLink not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteByShareLink(context.Context, *GetNoteByShareLinkRequest) (*GetNoteByShareLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteByShareLink not implemented")
+}
+func (UnimplementedNoteServiceServer) RecordShareLinkAccess(context.Context, *RecordShareLinkAccessRequest) (*RecordShareLinkAccessResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordShareLinkAccess not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteVersions(context.Context, *GetNoteVersionsRequest) (*GetNoteVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteVersions not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteVersion(context.Context, *GetNoteVersionRequest) (*NoteVersion, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteVersion not implemented")
+}
+func (UnimplementedNoteServiceServer) RestoreNoteVersion(context.Context, *RestoreNoteVersionRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreNoteVersion not implemented")
+}
+func (UnimplementedNoteServiceServer) GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTags not implemented")
+}
+func (UnimplementedNoteServiceServer) RenameTag(context.Context, *RenameTagRequest) (*AlterTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedNoteServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*AlterTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNotebook(context.Context, *GetNotebookRequest) (*Notebook, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNotebook not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNotebooks(context.Context, *GetNotebooksRequest) (*GetNotebooksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNotebooks not implemented")
+}
+func (UnimplementedNoteServiceServer) PostNotebook(context.Context, *PostNotebookRequest) (*Notebook, error) {
+	return nil, status.Error(codes.Unimplemented, "method PostNotebook not implemented")
+}
+func (UnimplementedNoteServiceServer) AlterNotebook(context.Context, *AlterNotebookRequest) (*Notebook, error) {
+	return nil, status.Error(codes.Unimplemented, "method AlterNotebook not implemented")
+}
+func (UnimplementedNoteServiceServer) DeleteNotebook(context.Context, *DeleteNotebookRequest) (*DeleteNotebookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteNotebook not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNotebookPermissions(context.Context, *GetNotebookPermissionsRequest) (*GetNotePermissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNotebookPermissions not implemented")
+}
+func (UnimplementedNoteServiceServer) GrantNotebookPermission(context.Context, *GrantNotebookPermissionRequest) (*NotePermission, error) {
+	return nil, status.Error(codes.Unimplemented, "method GrantNotebookPermission not implemented")
+}
+func (UnimplementedNoteServiceServer) RevokeNotebookPermission(context.Context, *RevokeNotebookPermissionRequest) (*RevokeNotePermissionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeNotebookPermission not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteLinks(context.Context, *GetNoteLinksRequest) (*GetNoteLinksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteLinks not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteBacklinks(*GetNoteLinksRequest, grpc.ServerStreamingServer[MinimalNote]) error {
+	return status.Error(codes.Unimplemented, "method GetNoteBacklinks not implemented")
+}
+func (UnimplementedNoteServiceServer) GetNoteGraph(context.Context, *GetNoteGraphRequest) (*NoteGraph, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteGraph not implemented")
+}
+func (UnimplementedNoteServiceServer) GetRelatedNotes(context.Context, *GetRelatedNotesRequest) (*GetRelatedNotesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRelatedNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) GetTrash(*GetTrashRequest, grpc.ServerStreamingServer[MinimalNote]) error {
+	return status.Error(codes.Unimplemented, "method GetTrash not implemented")
+}
+func (UnimplementedNoteServiceServer) RestoreNotes(context.Context, *RestoreNotesRequest) (*RestoreNotesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) PurgeNotes(context.Context, *PurgeNotesRequest) (*PurgeNotesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurgeNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) GetSavedSearches(context.Context, *GetSavedSearchesRequest) (*GetSavedSearchesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSavedSearches not implemented")
+}
+func (UnimplementedNoteServiceServer) GetSavedSearch(context.Context, *GetSavedSearchRequest) (*SavedSearch, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) PostSavedSearch(context.Context, *PostSavedSearchRequest) (*SavedSearch, error) {
+	return nil, status.Error(codes.Unimplemented, "method PostSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) AlterSavedSearch(context.Context, *AlterSavedSearchRequest) (*SavedSearch, error) {
+	return nil, status.Error(codes.Unimplemented, "method AlterSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) DeleteSavedSearch(context.Context, *DeleteSavedSearchRequest) (*DeleteSavedSearchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) SuggestNoteTitles(context.Context, *SuggestNoteTitlesRequest) (*SuggestNoteTitlesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuggestNoteTitles not implemented")
+}
+func (UnimplementedNoteServiceServer) mustEmbedUnimplementedNoteServiceServer() {}
+func (UnimplementedNoteServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeNoteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NoteServiceServer will
+// result in compilation errors.
+type UnsafeNoteServiceServer interface {
+	mustEmbedUnimplementedNoteServiceServer()
+}
+
+func RegisterNoteServiceServer(s grpc.ServiceRegistrar, srv NoteServiceServer) {
+	// If the following call panics, it indicates UnimplementedNoteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NoteService_ServiceDesc, srv)
+}
+
+func _NoteService_GetNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNote(ctx, req.(*GetNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_PostNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).PostNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_PostNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).PostNote(ctx, req.(*PostNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_AlterNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlterNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).AlterNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_AlterNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).AlterNote(ctx, req.(*AlterNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_DeleteNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DeleteNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DeleteNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DeleteNote(ctx, req.(*DeleteNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_SearchNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetSearchNotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoteServiceServer).SearchNotes(m, &grpc.GenericServerStream[GetSearchNotesRequest, MinimalNote]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_SearchNotesServer = grpc.ServerStreamingServer[MinimalNote]
+
+func _NoteService_GetUserNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetUserNotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoteServiceServer).GetUserNotes(m, &grpc.GenericServerStream[GetUserNotesRequest, Note]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_GetUserNotesServer = grpc.ServerStreamingServer[Note]
+
+func _NoteService_DeleteUserNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DeleteUserNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DeleteUserNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DeleteUserNotes(ctx, req.(*DeleteUserNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNotePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotePermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNotePermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNotePermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNotePermissions(ctx, req.(*GetNotePermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GrantNotePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantNotePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GrantNotePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GrantNotePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GrantNotePermission(ctx, req.(*GrantNotePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RevokeNotePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeNotePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RevokeNotePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RevokeNotePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RevokeNotePermission(ctx, req.(*RevokeNotePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetSharedNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetSharedNotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoteServiceServer).GetSharedNotes(m, &grpc.GenericServerStream[GetSharedNotesRequest, MinimalNote]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_GetSharedNotesServer = grpc.ServerStreamingServer[MinimalNote]
+
+func _NoteService_PostShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).PostShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_PostShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).PostShareLink(ctx, req.(*PostShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetShareLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShareLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetShareLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetShareLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetShareLinks(ctx, req.(*GetShareLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_DeleteShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DeleteShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DeleteShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DeleteShareLink(ctx, req.(*DeleteShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNoteByShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteByShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteByShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteByShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteByShareLink(ctx, req.(*GetNoteByShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RecordShareLinkAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordShareLinkAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RecordShareLinkAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RecordShareLinkAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RecordShareLinkAccess(ctx, req.(*RecordShareLinkAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNoteVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteVersions(ctx, req.(*GetNoteVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNoteVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteVersion(ctx, req.(*GetNoteVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RestoreNoteVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreNoteVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RestoreNoteVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RestoreNoteVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RestoreNoteVersion(ctx, req.(*RestoreNoteVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetTags(ctx, req.(*GetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNotebook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNotebook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNotebook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNotebook(ctx, req.(*GetNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNotebooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotebooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNotebooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNotebooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNotebooks(ctx, req.(*GetNotebooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_PostNotebook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).PostNotebook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_PostNotebook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).PostNotebook(ctx, req.(*PostNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_AlterNotebook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlterNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).AlterNotebook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_AlterNotebook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).AlterNotebook(ctx, req.(*AlterNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_DeleteNotebook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DeleteNotebook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DeleteNotebook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DeleteNotebook(ctx, req.(*DeleteNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNotebookPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotebookPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNotebookPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNotebookPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNotebookPermissions(ctx, req.(*GetNotebookPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GrantNotebookPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantNotebookPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GrantNotebookPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GrantNotebookPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GrantNotebookPermission(ctx, req.(*GrantNotebookPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RevokeNotebookPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeNotebookPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RevokeNotebookPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RevokeNotebookPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RevokeNotebookPermission(ctx, req.(*RevokeNotebookPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNoteLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteLinksRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteLinks(ctx, in)
 	}
-	return x, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteLinks(ctx, req.(*GetNoteLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetNoteBacklinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetNoteLinksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoteServiceServer).GetNoteBacklinks(m, &grpc.GenericServerStream[GetNoteLinksRequest, MinimalNote]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type NoteService_SearchNotesClient = grpc.ServerStreamingClient[MinimalNote]
+type NoteService_GetNoteBacklinksServer = grpc.ServerStreamingServer[MinimalNote]
 
-// NoteServiceServer is the server API for NoteService service.
-// All implementations must embed UnimplementedNoteServiceServer
-// for forward compatibility.
-//
-// Note Service
-type NoteServiceServer interface {
-	GetNote(context.Context, *GetNoteRequest) (*Note, error)
-	PostNote(context.Context, *PostNoteRequest) (*Note, error)
-	SearchNotes(*GetSearchNotesRequest, grpc.ServerStreamingServer[MinimalNote]) error
-	mustEmbedUnimplementedNoteServiceServer()
+func _NoteService_GetNoteGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteGraph(ctx, req.(*GetNoteGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedNoteServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedNoteServiceServer struct{}
+func _NoteService_GetRelatedNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetRelatedNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetRelatedNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetRelatedNotes(ctx, req.(*GetRelatedNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedNoteServiceServer) GetNote(context.Context, *GetNoteRequest) (*Note, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetNote not implemented")
+func _NoteService_GetTrash_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTrashRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoteServiceServer).GetTrash(m, &grpc.GenericServerStream[GetTrashRequest, MinimalNote]{ServerStream: stream})
 }
-func (UnimplementedNoteServiceServer) PostNote(context.Context, *PostNoteRequest) (*Note, error) {
-	return nil, status.Error(codes.Unimplemented, "method PostNote not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_GetTrashServer = grpc.ServerStreamingServer[MinimalNote]
+
+func _NoteService_RestoreNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RestoreNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RestoreNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RestoreNotes(ctx, req.(*RestoreNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedNoteServiceServer) SearchNotes(*GetSearchNotesRequest, grpc.ServerStreamingServer[MinimalNote]) error {
-	return status.Error(codes.Unimplemented, "method SearchNotes not implemented")
+
+func _NoteService_PurgeNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).PurgeNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_PurgeNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).PurgeNotes(ctx, req.(*PurgeNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedNoteServiceServer) mustEmbedUnimplementedNoteServiceServer() {}
-func (UnimplementedNoteServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeNoteServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to NoteServiceServer will
-// result in compilation errors.
-type UnsafeNoteServiceServer interface {
-	mustEmbedUnimplementedNoteServiceServer()
+func _NoteService_GetSavedSearches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSavedSearchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetSavedSearches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetSavedSearches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetSavedSearches(ctx, req.(*GetSavedSearchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterNoteServiceServer(s grpc.ServiceRegistrar, srv NoteServiceServer) {
-	// If the following call panics, it indicates UnimplementedNoteServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _NoteService_GetSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSavedSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&NoteService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetSavedSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetSavedSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetSavedSearch(ctx, req.(*GetSavedSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_GetNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNoteRequest)
+func _NoteService_PostSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostSavedSearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NoteServiceServer).GetNote(ctx, in)
+		return srv.(NoteServiceServer).PostSavedSearch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: NoteService_GetNote_FullMethodName,
+		FullMethod: NoteService_PostSavedSearch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NoteServiceServer).GetNote(ctx, req.(*GetNoteRequest))
+		return srv.(NoteServiceServer).PostSavedSearch(ctx, req.(*PostSavedSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_PostNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PostNoteRequest)
+func _NoteService_AlterSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlterSavedSearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NoteServiceServer).PostNote(ctx, in)
+		return srv.(NoteServiceServer).AlterSavedSearch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: NoteService_PostNote_FullMethodName,
+		FullMethod: NoteService_AlterSavedSearch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NoteServiceServer).PostNote(ctx, req.(*PostNoteRequest))
+		return srv.(NoteServiceServer).AlterSavedSearch(ctx, req.(*AlterSavedSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_SearchNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetSearchNotesRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _NoteService_DeleteSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(NoteServiceServer).SearchNotes(m, &grpc.GenericServerStream[GetSearchNotesRequest, MinimalNote]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DeleteSavedSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DeleteSavedSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DeleteSavedSearch(ctx, req.(*DeleteSavedSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type NoteService_SearchNotesServer = grpc.ServerStreamingServer[MinimalNote]
+func _NoteService_SuggestNoteTitles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestNoteTitlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).SuggestNoteTitles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_SuggestNoteTitles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).SuggestNoteTitles(ctx, req.(*SuggestNoteTitlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
 // NoteService_ServiceDesc is the grpc.ServiceDesc for NoteService service.
 // It's only intended for direct use with grpc.RegisterService,
@@ -193,6 +1579,150 @@ var NoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PostNote",
 			Handler:    _NoteService_PostNote_Handler,
 		},
+		{
+			MethodName: "AlterNote",
+			Handler:    _NoteService_AlterNote_Handler,
+		},
+		{
+			MethodName: "DeleteNote",
+			Handler:    _NoteService_DeleteNote_Handler,
+		},
+		{
+			MethodName: "DeleteUserNotes",
+			Handler:    _NoteService_DeleteUserNotes_Handler,
+		},
+		{
+			MethodName: "GetNotePermissions",
+			Handler:    _NoteService_GetNotePermissions_Handler,
+		},
+		{
+			MethodName: "GrantNotePermission",
+			Handler:    _NoteService_GrantNotePermission_Handler,
+		},
+		{
+			MethodName: "RevokeNotePermission",
+			Handler:    _NoteService_RevokeNotePermission_Handler,
+		},
+		{
+			MethodName: "PostShareLink",
+			Handler:    _NoteService_PostShareLink_Handler,
+		},
+		{
+			MethodName: "GetShareLinks",
+			Handler:    _NoteService_GetShareLinks_Handler,
+		},
+		{
+			MethodName: "DeleteShareLink",
+			Handler:    _NoteService_DeleteShareLink_Handler,
+		},
+		{
+			MethodName: "GetNoteByShareLink",
+			Handler:    _NoteService_GetNoteByShareLink_Handler,
+		},
+		{
+			MethodName: "RecordShareLinkAccess",
+			Handler:    _NoteService_RecordShareLinkAccess_Handler,
+		},
+		{
+			MethodName: "GetNoteVersions",
+			Handler:    _NoteService_GetNoteVersions_Handler,
+		},
+		{
+			MethodName: "GetNoteVersion",
+			Handler:    _NoteService_GetNoteVersion_Handler,
+		},
+		{
+			MethodName: "RestoreNoteVersion",
+			Handler:    _NoteService_RestoreNoteVersion_Handler,
+		},
+		{
+			MethodName: "GetTags",
+			Handler:    _NoteService_GetTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _NoteService_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _NoteService_MergeTags_Handler,
+		},
+		{
+			MethodName: "GetNotebook",
+			Handler:    _NoteService_GetNotebook_Handler,
+		},
+		{
+			MethodName: "GetNotebooks",
+			Handler:    _NoteService_GetNotebooks_Handler,
+		},
+		{
+			MethodName: "PostNotebook",
+			Handler:    _NoteService_PostNotebook_Handler,
+		},
+		{
+			MethodName: "AlterNotebook",
+			Handler:    _NoteService_AlterNotebook_Handler,
+		},
+		{
+			MethodName: "DeleteNotebook",
+			Handler:    _NoteService_DeleteNotebook_Handler,
+		},
+		{
+			MethodName: "GetNotebookPermissions",
+			Handler:    _NoteService_GetNotebookPermissions_Handler,
+		},
+		{
+			MethodName: "GrantNotebookPermission",
+			Handler:    _NoteService_GrantNotebookPermission_Handler,
+		},
+		{
+			MethodName: "RevokeNotebookPermission",
+			Handler:    _NoteService_RevokeNotebookPermission_Handler,
+		},
+		{
+			MethodName: "GetNoteLinks",
+			Handler:    _NoteService_GetNoteLinks_Handler,
+		},
+		{
+			MethodName: "GetNoteGraph",
+			Handler:    _NoteService_GetNoteGraph_Handler,
+		},
+		{
+			MethodName: "GetRelatedNotes",
+			Handler:    _NoteService_GetRelatedNotes_Handler,
+		},
+		{
+			MethodName: "RestoreNotes",
+			Handler:    _NoteService_RestoreNotes_Handler,
+		},
+		{
+			MethodName: "PurgeNotes",
+			Handler:    _NoteService_PurgeNotes_Handler,
+		},
+		{
+			MethodName: "GetSavedSearches",
+			Handler:    _NoteService_GetSavedSearches_Handler,
+		},
+		{
+			MethodName: "GetSavedSearch",
+			Handler:    _NoteService_GetSavedSearch_Handler,
+		},
+		{
+			MethodName: "PostSavedSearch",
+			Handler:    _NoteService_PostSavedSearch_Handler,
+		},
+		{
+			MethodName: "AlterSavedSearch",
+			Handler:    _NoteService_AlterSavedSearch_Handler,
+		},
+		{
+			MethodName: "DeleteSavedSearch",
+			Handler:    _NoteService_DeleteSavedSearch_Handler,
+		},
+		{
+			MethodName: "SuggestNoteTitles",
+			Handler:    _NoteService_SuggestNoteTitles_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -200,6 +1730,26 @@ var NoteService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _NoteService_SearchNotes_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetUserNotes",
+			Handler:       _NoteService_GetUserNotes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetSharedNotes",
+			Handler:       _NoteService_GetSharedNotes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetNoteBacklinks",
+			Handler:       _NoteService_GetNoteBacklinks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetTrash",
+			Handler:       _NoteService_GetTrash_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "src/proto/note.proto",
 }
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -30,8 +31,14 @@ type User struct {
 	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
 	Discriminator string                 `protobuf:"bytes,5,opt,name=discriminator,proto3" json:"discriminator,omitempty"`
 	Email         string                 `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// set by the user, take precedence over username and email of the login provider
+	DisplayName  string `protobuf:"bytes,7,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	ContactEmail string `protobuf:"bytes,8,opt,name=contact_email,json=contactEmail,proto3" json:"contact_email,omitempty"`
+	// identity the profile is synced from, so logging in with another linked
+	// identity doesn't overwrite it. The first identity linked to the user
+	ProfileIdentity *Identity `protobuf:"bytes,9,opt,name=profile_identity,json=profileIdentity,proto3" json:"profile_identity,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -106,17 +113,92 @@ func (x *User) GetEmail() string {
 	return ""
 }
 
+func (x *User) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *User) GetContactEmail() string {
+	if x != nil {
+		return x.ContactEmail
+	}
+	return ""
+}
+
+func (x *User) GetProfileIdentity() *Identity {
+	if x != nil {
+		return x.ProfileIdentity
+	}
+	return nil
+}
+
+// Identity of a user at a login provider
+type Identity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // e.g. discord, github
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`   // ID of the user at the provider
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	mi := &file_src_proto_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Identity) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Identity) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
 type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            *int32                 `protobuf:"varint,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
 	DiscordId     *int64                 `protobuf:"varint,2,opt,name=discord_id,json=discordId,proto3,oneof" json:"discord_id,omitempty"`
+	Identity      *Identity              `protobuf:"bytes,3,opt,name=identity,proto3,oneof" json:"identity,omitempty"` // any identity linked to the user
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_src_proto_user_proto_msgTypes[1]
+	mi := &file_src_proto_user_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -128,7 +210,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_user_proto_msgTypes[1]
+	mi := &file_src_proto_user_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -141,7 +223,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_user_proto_rawDescGZIP(), []int{1}
+	return file_src_proto_user_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetUserRequest) GetId() int32 {
@@ -158,20 +240,28 @@ func (x *GetUserRequest) GetDiscordId() int64 {
 	return 0
 }
 
+func (x *GetUserRequest) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
 type PostUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DiscordId     int64                  `protobuf:"varint,1,opt,name=discord_id,json=discordId,proto3" json:"discord_id,omitempty"`
+	DiscordId     int64                  `protobuf:"varint,1,opt,name=discord_id,json=discordId,proto3" json:"discord_id,omitempty"` // 0 if the user didn't log in via Discord
 	Avatar        string                 `protobuf:"bytes,2,opt,name=avatar,proto3" json:"avatar,omitempty"`
 	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
 	Discriminator string                 `protobuf:"bytes,4,opt,name=discriminator,proto3" json:"discriminator,omitempty"`
 	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Identity      *Identity              `protobuf:"bytes,6,opt,name=identity,proto3,oneof" json:"identity,omitempty"` // identity used to log in
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PostUserRequest) Reset() {
 	*x = PostUserRequest{}
-	mi := &file_src_proto_user_proto_msgTypes[2]
+	mi := &file_src_proto_user_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -183,7 +273,7 @@ func (x *PostUserRequest) String() string {
 func (*PostUserRequest) ProtoMessage() {}
 
 func (x *PostUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_user_proto_msgTypes[2]
+	mi := &file_src_proto_user_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -196,7 +286,7 @@ func (x *PostUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostUserRequest.ProtoReflect.Descriptor instead.
 func (*PostUserRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_user_proto_rawDescGZIP(), []int{2}
+	return file_src_proto_user_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PostUserRequest) GetDiscordId() int64 {
@@ -234,6 +324,13 @@ func (x *PostUserRequest) GetEmail() string {
 	return ""
 }
 
+func (x *PostUserRequest) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
 type AlterUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -242,13 +339,15 @@ type AlterUserRequest struct {
 	Username      *string                `protobuf:"bytes,4,opt,name=username,proto3,oneof" json:"username,omitempty"`
 	Discriminator *string                `protobuf:"bytes,5,opt,name=discriminator,proto3,oneof" json:"discriminator,omitempty"`
 	Email         *string                `protobuf:"bytes,6,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	DisplayName   *string                `protobuf:"bytes,7,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"`    // empty string removes the override
+	ContactEmail  *string                `protobuf:"bytes,8,opt,name=contact_email,json=contactEmail,proto3,oneof" json:"contact_email,omitempty"` // empty string removes the override
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AlterUserRequest) Reset() {
 	*x = AlterUserRequest{}
-	mi := &file_src_proto_user_proto_msgTypes[3]
+	mi := &file_src_proto_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -260,7 +359,7 @@ func (x *AlterUserRequest) String() string {
 func (*AlterUserRequest) ProtoMessage() {}
 
 func (x *AlterUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_user_proto_msgTypes[3]
+	mi := &file_src_proto_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,7 +372,7 @@ func (x *AlterUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AlterUserRequest.ProtoReflect.Descriptor instead.
 func (*AlterUserRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_user_proto_rawDescGZIP(), []int{3}
+	return file_src_proto_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AlterUserRequest) GetId() int32 {
@@ -318,6 +417,21 @@ func (x *AlterUserRequest) GetEmail() string {
 	return ""
 }
 
+func (x *AlterUserRequest) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
+	}
+	return ""
+}
+
+func (x *AlterUserRequest) GetContactEmail() string {
+	if x != nil && x.ContactEmail != nil {
+		return *x.ContactEmail
+	}
+	return ""
+}
+
+// Deletes the user together with their login identities and tokens
 type DeleteUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -327,7 +441,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_src_proto_user_proto_msgTypes[4]
+	mi := &file_src_proto_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -339,7 +453,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_user_proto_msgTypes[4]
+	mi := &file_src_proto_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +466,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_user_proto_rawDescGZIP(), []int{4}
+	return file_src_proto_user_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DeleteUserRequest) GetId() int32 {
@@ -371,7 +485,7 @@ type DeleteUserResponse struct {
 
 func (x *DeleteUserResponse) Reset() {
 	*x = DeleteUserResponse{}
-	mi := &file_src_proto_user_proto_msgTypes[5]
+	mi := &file_src_proto_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -383,7 +497,7 @@ func (x *DeleteUserResponse) String() string {
 func (*DeleteUserResponse) ProtoMessage() {}
 
 func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_user_proto_msgTypes[5]
+	mi := &file_src_proto_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,7 +510,7 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_src_proto_user_proto_rawDescGZIP(), []int{5}
+	return file_src_proto_user_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteUserResponse) GetSuccess() bool {
@@ -406,55 +520,910 @@ func (x *DeleteUserResponse) GetSuccess() bool {
 	return false
 }
 
-var File_src_proto_user_proto protoreflect.FileDescriptor
+// Personal access token of a user. Only the hash of the token is stored
+type ApiToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"` // e.g. notes:read
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3,oneof" json:"last_used_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_src_proto_user_proto_rawDesc = "" +
-	"\n" +
-	"\x14src/proto/user.proto\x12\x05proto\"\xa5\x01\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
-	"\n" +
-	"discord_id\x18\x02 \x01(\x03R\tdiscordId\x12\x16\n" +
-	"\x06avatar\x18\x03 \x01(\tR\x06avatar\x12\x1a\n" +
-	"\busername\x18\x04 \x01(\tR\busername\x12$\n" +
-	"\rdiscriminator\x18\x05 \x01(\tR\rdiscriminator\x12\x14\n" +
-	"\x05email\x18\x06 \x01(\tR\x05email\"_\n" +
-	"\x0eGetUserRequest\x12\x13\n" +
-	"\x02id\x18\x01 \x01(\x05H\x00R\x02id\x88\x01\x01\x12\"\n" +
-	"\n" +
-	"discord_id\x18\x02 \x01(\x03H\x01R\tdiscordId\x88\x01\x01B\x05\n" +
-	"\x03_idB\r\n" +
-	"\v_discord_id\"\xa0\x01\n" +
-	"\x0fPostUserRequest\x12\x1d\n" +
-	"\n" +
-	"discord_id\x18\x01 \x01(\x03R\tdiscordId\x12\x16\n" +
-	"\x06avatar\x18\x02 \x01(\tR\x06avatar\x12\x1a\n" +
-	"\busername\x18\x03 \x01(\tR\busername\x12$\n" +
-	"\rdiscriminator\x18\x04 \x01(\tR\rdiscriminator\x12\x14\n" +
-	"\x05email\x18\x05 \x01(\tR\x05email\"\x8d\x02\n" +
-	"\x10AlterUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\"\n" +
-	"\n" +
-	"discord_id\x18\x02 \x01(\x03H\x00R\tdiscordId\x88\x01\x01\x12\x1b\n" +
-	"\x06avatar\x18\x03 \x01(\tH\x01R\x06avatar\x88\x01\x01\x12\x1f\n" +
-	"\busername\x18\x04 \x01(\tH\x02R\busername\x88\x01\x01\x12)\n" +
-	"\rdiscriminator\x18\x05 \x01(\tH\x03R\rdiscriminator\x88\x01\x01\x12\x19\n" +
-	"\x05email\x18\x06 \x01(\tH\x04R\x05email\x88\x01\x01B\r\n" +
-	"\v_discord_idB\t\n" +
-	"\a_avatarB\v\n" +
-	"\t_usernameB\x10\n" +
-	"\x0e_discriminatorB\b\n" +
-	"\x06_email\"#\n" +
-	"\x11DeleteUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\".\n" +
-	"\x12DeleteUserResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xe3\x01\n" +
+func (x *ApiToken) Reset() {
+	*x = ApiToken{}
+	mi := &file_src_proto_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiToken) ProtoMessage() {}
+
+func (x *ApiToken) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiToken.ProtoReflect.Descriptor instead.
+func (*ApiToken) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ApiToken) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ApiToken) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ApiToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiToken) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *ApiToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+type PostApiTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TokenHash     []byte                 `protobuf:"bytes,3,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"` // SHA-256 of the token
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostApiTokenRequest) Reset() {
+	*x = PostApiTokenRequest{}
+	mi := &file_src_proto_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostApiTokenRequest) ProtoMessage() {}
+
+func (x *PostApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*PostApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PostApiTokenRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PostApiTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PostApiTokenRequest) GetTokenHash() []byte {
+	if x != nil {
+		return x.TokenHash
+	}
+	return nil
+}
+
+func (x *PostApiTokenRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *PostApiTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type GetApiTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApiTokensRequest) Reset() {
+	*x = GetApiTokensRequest{}
+	mi := &file_src_proto_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApiTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApiTokensRequest) ProtoMessage() {}
+
+func (x *GetApiTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApiTokensRequest.ProtoReflect.Descriptor instead.
+func (*GetApiTokensRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetApiTokensRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetApiTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*ApiToken            `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApiTokensResponse) Reset() {
+	*x = GetApiTokensResponse{}
+	mi := &file_src_proto_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApiTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApiTokensResponse) ProtoMessage() {}
+
+func (x *GetApiTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApiTokensResponse.ProtoReflect.Descriptor instead.
+func (*GetApiTokensResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetApiTokensResponse) GetTokens() []*ApiToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type DeleteApiTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApiTokenRequest) Reset() {
+	*x = DeleteApiTokenRequest{}
+	mi := &file_src_proto_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApiTokenRequest) ProtoMessage() {}
+
+func (x *DeleteApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*DeleteApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteApiTokenRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteApiTokenRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteApiTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApiTokenResponse) Reset() {
+	*x = DeleteApiTokenResponse{}
+	mi := &file_src_proto_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApiTokenResponse) ProtoMessage() {}
+
+func (x *DeleteApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*DeleteApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteApiTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Request to resolve a token into its user. Also updates last_used_at
+type GetUserByApiTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenHash     []byte                 `protobuf:"bytes,1,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"` // SHA-256 of the token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByApiTokenRequest) Reset() {
+	*x = GetUserByApiTokenRequest{}
+	mi := &file_src_proto_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByApiTokenRequest) ProtoMessage() {}
+
+func (x *GetUserByApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserByApiTokenRequest) GetTokenHash() []byte {
+	if x != nil {
+		return x.TokenHash
+	}
+	return nil
+}
+
+type GetUserByApiTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Token         *ApiToken              `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByApiTokenResponse) Reset() {
+	*x = GetUserByApiTokenResponse{}
+	mi := &file_src_proto_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByApiTokenResponse) ProtoMessage() {}
+
+func (x *GetUserByApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*GetUserByApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetUserByApiTokenResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetUserByApiTokenResponse) GetToken() *ApiToken {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+// An identity linked to a user, which can be used to log in
+type LinkedIdentity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identity      *Identity              `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // username at the provider
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	LinkedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=linked_at,json=linkedAt,proto3" json:"linked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkedIdentity) Reset() {
+	*x = LinkedIdentity{}
+	mi := &file_src_proto_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkedIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkedIdentity) ProtoMessage() {}
+
+func (x *LinkedIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkedIdentity.ProtoReflect.Descriptor instead.
+func (*LinkedIdentity) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LinkedIdentity) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *LinkedIdentity) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LinkedIdentity) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LinkedIdentity) GetLinkedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LinkedAt
+	}
+	return nil
+}
+
+type GetIdentitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIdentitiesRequest) Reset() {
+	*x = GetIdentitiesRequest{}
+	mi := &file_src_proto_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdentitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdentitiesRequest) ProtoMessage() {}
+
+func (x *GetIdentitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdentitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetIdentitiesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetIdentitiesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetIdentitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identities    []*LinkedIdentity      `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIdentitiesResponse) Reset() {
+	*x = GetIdentitiesResponse{}
+	mi := &file_src_proto_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdentitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdentitiesResponse) ProtoMessage() {}
+
+func (x *GetIdentitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdentitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetIdentitiesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetIdentitiesResponse) GetIdentities() []*LinkedIdentity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+// Request to link an identity to a user. Fails with ALREADY_EXISTS if the
+// identity is linked to another user
+type LinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Identity      *Identity              `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityRequest) Reset() {
+	*x = LinkIdentityRequest{}
+	mi := &file_src_proto_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityRequest) ProtoMessage() {}
+
+func (x *LinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*LinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LinkIdentityRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LinkIdentityRequest) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *LinkIdentityRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// Request to unlink an identity from a user. If it's the profile identity of
+// the user, the oldest remaining identity takes its place. Fails with
+// FAILED_PRECONDITION if it's the last identity of the user
+type UnlinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Identity      *Identity              `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityRequest) Reset() {
+	*x = UnlinkIdentityRequest{}
+	mi := &file_src_proto_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityRequest) ProtoMessage() {}
+
+func (x *UnlinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UnlinkIdentityRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UnlinkIdentityRequest) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+type UnlinkIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityResponse) Reset() {
+	*x = UnlinkIdentityResponse{}
+	mi := &file_src_proto_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityResponse) ProtoMessage() {}
+
+func (x *UnlinkIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UnlinkIdentityResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_src_proto_user_proto protoreflect.FileDescriptor
+
+const file_src_proto_user_proto_rawDesc = "" +
+	"\n" +
+	"\x14src/proto/user.proto\x12\x05proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x02\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"discord_id\x18\x02 \x01(\x03R\tdiscordId\x12\x16\n" +
+	"\x06avatar\x18\x03 \x01(\tR\x06avatar\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12$\n" +
+	"\rdiscriminator\x18\x05 \x01(\tR\rdiscriminator\x12\x14\n" +
+	"\x05email\x18\x06 \x01(\tR\x05email\x12!\n" +
+	"\fdisplay_name\x18\a \x01(\tR\vdisplayName\x12#\n" +
+	"\rcontact_email\x18\b \x01(\tR\fcontactEmail\x12:\n" +
+	"\x10profile_identity\x18\t \x01(\v2\x0f.proto.IdentityR\x0fprofileIdentity\"@\n" +
+	"\bIdentity\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\"\x9e\x01\n" +
+	"\x0eGetUserRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\x05H\x00R\x02id\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"discord_id\x18\x02 \x01(\x03H\x01R\tdiscordId\x88\x01\x01\x120\n" +
+	"\bidentity\x18\x03 \x01(\v2\x0f.proto.IdentityH\x02R\bidentity\x88\x01\x01B\x05\n" +
+	"\x03_idB\r\n" +
+	"\v_discord_idB\v\n" +
+	"\t_identity\"\xdf\x01\n" +
+	"\x0fPostUserRequest\x12\x1d\n" +
+	"\n" +
+	"discord_id\x18\x01 \x01(\x03R\tdiscordId\x12\x16\n" +
+	"\x06avatar\x18\x02 \x01(\tR\x06avatar\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12$\n" +
+	"\rdiscriminator\x18\x04 \x01(\tR\rdiscriminator\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x120\n" +
+	"\bidentity\x18\x06 \x01(\v2\x0f.proto.IdentityH\x00R\bidentity\x88\x01\x01B\v\n" +
+	"\t_identity\"\x82\x03\n" +
+	"\x10AlterUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\"\n" +
+	"\n" +
+	"discord_id\x18\x02 \x01(\x03H\x00R\tdiscordId\x88\x01\x01\x12\x1b\n" +
+	"\x06avatar\x18\x03 \x01(\tH\x01R\x06avatar\x88\x01\x01\x12\x1f\n" +
+	"\busername\x18\x04 \x01(\tH\x02R\busername\x88\x01\x01\x12)\n" +
+	"\rdiscriminator\x18\x05 \x01(\tH\x03R\rdiscriminator\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x06 \x01(\tH\x04R\x05email\x88\x01\x01\x12&\n" +
+	"\fdisplay_name\x18\a \x01(\tH\x05R\vdisplayName\x88\x01\x01\x12(\n" +
+	"\rcontact_email\x18\b \x01(\tH\x06R\fcontactEmail\x88\x01\x01B\r\n" +
+	"\v_discord_idB\t\n" +
+	"\a_avatarB\v\n" +
+	"\t_usernameB\x10\n" +
+	"\x0e_discriminatorB\b\n" +
+	"\x06_emailB\x0f\n" +
+	"\r_display_nameB\x10\n" +
+	"\x0e_contact_email\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\".\n" +
+	"\x12DeleteUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xbd\x02\n" +
+	"\bApiToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01\x12A\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
+	"lastUsedAt\x88\x01\x01B\r\n" +
+	"\v_expires_atB\x0f\n" +
+	"\r_last_used_at\"\xc8\x01\n" +
+	"\x13PostApiTokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"token_hash\x18\x03 \x01(\fR\ttokenHash\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12>\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"\v_expires_at\".\n" +
+	"\x13GetApiTokensRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"?\n" +
+	"\x14GetApiTokensResponse\x12'\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x0f.proto.ApiTokenR\x06tokens\"@\n" +
+	"\x15DeleteApiTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"2\n" +
+	"\x16DeleteApiTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"9\n" +
+	"\x18GetUserByApiTokenRequest\x12\x1d\n" +
+	"\n" +
+	"token_hash\x18\x01 \x01(\fR\ttokenHash\"c\n" +
+	"\x19GetUserByApiTokenResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.proto.UserR\x04user\x12%\n" +
+	"\x05token\x18\x02 \x01(\v2\x0f.proto.ApiTokenR\x05token\"\xa8\x01\n" +
+	"\x0eLinkedIdentity\x12+\n" +
+	"\bidentity\x18\x01 \x01(\v2\x0f.proto.IdentityR\bidentity\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x127\n" +
+	"\tlinked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blinkedAt\"/\n" +
+	"\x14GetIdentitiesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"N\n" +
+	"\x15GetIdentitiesResponse\x125\n" +
+	"\n" +
+	"identities\x18\x01 \x03(\v2\x15.proto.LinkedIdentityR\n" +
+	"identities\"\x8d\x01\n" +
+	"\x13LinkIdentityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12+\n" +
+	"\bidentity\x18\x02 \x01(\v2\x0f.proto.IdentityR\bidentity\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\"]\n" +
+	"\x15UnlinkIdentityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12+\n" +
+	"\bidentity\x18\x02 \x01(\v2\x0f.proto.IdentityR\bidentity\"2\n" +
+	"\x16UnlinkIdentityResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xee\x05\n" +
 	"\vUserService\x12-\n" +
 	"\aGetUser\x12\x15.proto.GetUserRequest\x1a\v.proto.User\x12/\n" +
 	"\bPostUser\x12\x16.proto.PostUserRequest\x1a\v.proto.User\x121\n" +
 	"\tAlterUser\x12\x17.proto.AlterUserRequest\x1a\v.proto.User\x12A\n" +
 	"\n" +
-	"DeleteUser\x12\x18.proto.DeleteUserRequest\x1a\x19.proto.DeleteUserResponseB1Z/github.com/KuramaSyu/WerSu-Rest/src/proto;protob\x06proto3"
+	"DeleteUser\x12\x18.proto.DeleteUserRequest\x1a\x19.proto.DeleteUserResponse\x12;\n" +
+	"\fPostApiToken\x12\x1a.proto.PostApiTokenRequest\x1a\x0f.proto.ApiToken\x12G\n" +
+	"\fGetApiTokens\x12\x1a.proto.GetApiTokensRequest\x1a\x1b.proto.GetApiTokensResponse\x12M\n" +
+	"\x0eDeleteApiToken\x12\x1c.proto.DeleteApiTokenRequest\x1a\x1d.proto.DeleteApiTokenResponse\x12V\n" +
+	"\x11GetUserByApiToken\x12\x1f.proto.GetUserByApiTokenRequest\x1a .proto.GetUserByApiTokenResponse\x12J\n" +
+	"\rGetIdentities\x12\x1b.proto.GetIdentitiesRequest\x1a\x1c.proto.GetIdentitiesResponse\x12A\n" +
+	"\fLinkIdentity\x12\x1a.proto.LinkIdentityRequest\x1a\x15.proto.LinkedIdentity\x12M\n" +
+	"\x0eUnlinkIdentity\x12\x1c.proto.UnlinkIdentityRequest\x1a\x1d.proto.UnlinkIdentityResponseB1Z/github.com/KuramaSyu/WerSu-Rest/src/proto;protob\x06proto3"
 
 var (
 	file_src_proto_user_proto_rawDescOnce sync.Once
@@ -468,29 +1437,74 @@ func file_src_proto_user_proto_rawDescGZIP() []byte {
 	return file_src_proto_user_proto_rawDescData
 }
 
-var file_src_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_src_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_src_proto_user_proto_goTypes = []any{
-	(*User)(nil),               // 0: proto.User
-	(*GetUserRequest)(nil),     // 1: proto.GetUserRequest
-	(*PostUserRequest)(nil),    // 2: proto.PostUserRequest
-	(*AlterUserRequest)(nil),   // 3: proto.AlterUserRequest
-	(*DeleteUserRequest)(nil),  // 4: proto.DeleteUserRequest
-	(*DeleteUserResponse)(nil), // 5: proto.DeleteUserResponse
+	(*User)(nil),                      // 0: proto.User
+	(*Identity)(nil),                  // 1: proto.Identity
+	(*GetUserRequest)(nil),            // 2: proto.GetUserRequest
+	(*PostUserRequest)(nil),           // 3: proto.PostUserRequest
+	(*AlterUserRequest)(nil),          // 4: proto.AlterUserRequest
+	(*DeleteUserRequest)(nil),         // 5: proto.DeleteUserRequest
+	(*DeleteUserResponse)(nil),        // 6: proto.DeleteUserResponse
+	(*ApiToken)(nil),                  // 7: proto.ApiToken
+	(*PostApiTokenRequest)(nil),       // 8: proto.PostApiTokenRequest
+	(*GetApiTokensRequest)(nil),       // 9: proto.GetApiTokensRequest
+	(*GetApiTokensResponse)(nil),      // 10: proto.GetApiTokensResponse
+	(*DeleteApiTokenRequest)(nil),     // 11: proto.DeleteApiTokenRequest
+	(*DeleteApiTokenResponse)(nil),    // 12: proto.DeleteApiTokenResponse
+	(*GetUserByApiTokenRequest)(nil),  // 13: proto.GetUserByApiTokenRequest
+	(*GetUserByApiTokenResponse)(nil), // 14: proto.GetUserByApiTokenResponse
+	(*LinkedIdentity)(nil),            // 15: proto.LinkedIdentity
+	(*GetIdentitiesRequest)(nil),      // 16: proto.GetIdentitiesRequest
+	(*GetIdentitiesResponse)(nil),     // 17: proto.GetIdentitiesResponse
+	(*LinkIdentityRequest)(nil),       // 18: proto.LinkIdentityRequest
+	(*UnlinkIdentityRequest)(nil),     // 19: proto.UnlinkIdentityRequest
+	(*UnlinkIdentityResponse)(nil),    // 20: proto.UnlinkIdentityResponse
+	(*timestamppb.Timestamp)(nil),     // 21: google.protobuf.Timestamp
 }
 var file_src_proto_user_proto_depIdxs = []int32{
-	1, // 0: proto.UserService.GetUser:input_type -> proto.GetUserRequest
-	2, // 1: proto.UserService.PostUser:input_type -> proto.PostUserRequest
-	3, // 2: proto.UserService.AlterUser:input_type -> proto.AlterUserRequest
-	4, // 3: proto.UserService.DeleteUser:input_type -> proto.DeleteUserRequest
-	0, // 4: proto.UserService.GetUser:output_type -> proto.User
-	0, // 5: proto.UserService.PostUser:output_type -> proto.User
-	0, // 6: proto.UserService.AlterUser:output_type -> proto.User
-	5, // 7: proto.UserService.DeleteUser:output_type -> proto.DeleteUserResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1,  // 0: proto.User.profile_identity:type_name -> proto.Identity
+	1,  // 1: proto.GetUserRequest.identity:type_name -> proto.Identity
+	1,  // 2: proto.PostUserRequest.identity:type_name -> proto.Identity
+	21, // 3: proto.ApiToken.created_at:type_name -> google.protobuf.Timestamp
+	21, // 4: proto.ApiToken.expires_at:type_name -> google.protobuf.Timestamp
+	21, // 5: proto.ApiToken.last_used_at:type_name -> google.protobuf.Timestamp
+	21, // 6: proto.PostApiTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	7,  // 7: proto.GetApiTokensResponse.tokens:type_name -> proto.ApiToken
+	0,  // 8: proto.GetUserByApiTokenResponse.user:type_name -> proto.User
+	7,  // 9: proto.GetUserByApiTokenResponse.token:type_name -> proto.ApiToken
+	1,  // 10: proto.LinkedIdentity.identity:type_name -> proto.Identity
+	21, // 11: proto.LinkedIdentity.linked_at:type_name -> google.protobuf.Timestamp
+	15, // 12: proto.GetIdentitiesResponse.identities:type_name -> proto.LinkedIdentity
+	1,  // 13: proto.LinkIdentityRequest.identity:type_name -> proto.Identity
+	1,  // 14: proto.UnlinkIdentityRequest.identity:type_name -> proto.Identity
+	2,  // 15: proto.UserService.GetUser:input_type -> proto.GetUserRequest
+	3,  // 16: proto.UserService.PostUser:input_type -> proto.PostUserRequest
+	4,  // 17: proto.UserService.AlterUser:input_type -> proto.AlterUserRequest
+	5,  // 18: proto.UserService.DeleteUser:input_type -> proto.DeleteUserRequest
+	8,  // 19: proto.UserService.PostApiToken:input_type -> proto.PostApiTokenRequest
+	9,  // 20: proto.UserService.GetApiTokens:input_type -> proto.GetApiTokensRequest
+	11, // 21: proto.UserService.DeleteApiToken:input_type -> proto.DeleteApiTokenRequest
+	13, // 22: proto.UserService.GetUserByApiToken:input_type -> proto.GetUserByApiTokenRequest
+	16, // 23: proto.UserService.GetIdentities:input_type -> proto.GetIdentitiesRequest
+	18, // 24: proto.UserService.LinkIdentity:input_type -> proto.LinkIdentityRequest
+	19, // 25: proto.UserService.UnlinkIdentity:input_type -> proto.UnlinkIdentityRequest
+	0,  // 26: proto.UserService.GetUser:output_type -> proto.User
+	0,  // 27: proto.UserService.PostUser:output_type -> proto.User
+	0,  // 28: proto.UserService.AlterUser:output_type -> proto.User
+	6,  // 29: proto.UserService.DeleteUser:output_type -> proto.DeleteUserResponse
+	7,  // 30: proto.UserService.PostApiToken:output_type -> proto.ApiToken
+	10, // 31: proto.UserService.GetApiTokens:output_type -> proto.GetApiTokensResponse
+	12, // 32: proto.UserService.DeleteApiToken:output_type -> proto.DeleteApiTokenResponse
+	14, // 33: proto.UserService.GetUserByApiToken:output_type -> proto.GetUserByApiTokenResponse
+	17, // 34: proto.UserService.GetIdentities:output_type -> proto.GetIdentitiesResponse
+	15, // 35: proto.UserService.LinkIdentity:output_type -> proto.LinkedIdentity
+	20, // 36: proto.UserService.UnlinkIdentity:output_type -> proto.UnlinkIdentityResponse
+	26, // [26:37] is the sub-list for method output_type
+	15, // [15:26] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_src_proto_user_proto_init() }
@@ -498,15 +1512,18 @@ func file_src_proto_user_proto_init() {
 	if File_src_proto_user_proto != nil {
 		return
 	}
-	file_src_proto_user_proto_msgTypes[1].OneofWrappers = []any{}
+	file_src_proto_user_proto_msgTypes[2].OneofWrappers = []any{}
 	file_src_proto_user_proto_msgTypes[3].OneofWrappers = []any{}
+	file_src_proto_user_proto_msgTypes[4].OneofWrappers = []any{}
+	file_src_proto_user_proto_msgTypes[7].OneofWrappers = []any{}
+	file_src_proto_user_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_src_proto_user_proto_rawDesc), len(file_src_proto_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -14,5 +14,21 @@ func (u *User) ParseJS() *models.JsUser {
 		Discriminator: u.Discriminator,
 		Avatar:        u.Avatar,
 		Email:         u.Email,
+		DisplayName:   u.DisplayName,
+		ContactEmail:  u.ContactEmail,
+	}
+}
+
+// ToModel converts the gRPC user into the user stored in sessions
+func (u *User) ToModel() models.User {
+	return models.User{
+		ID:            u.Id,
+		DiscordId:     models.Snowflake(u.DiscordId),
+		Username:      u.Username,
+		Discriminator: u.Discriminator,
+		Avatar:        u.Avatar,
+		Email:         u.Email,
+		DisplayName:   u.DisplayName,
+		ContactEmail:  u.ContactEmail,
 	}
 }
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter counts attempts per key, e.g. failed password attempts per client,
+// and blocks a key once it made limit attempts within the window. The window
+// starts with the first attempt of a key. Counts are kept in memory and lost
+// on restart.
+type Limiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	attempts  map[string]*attempts
+	lastSweep time.Time
+}
+
+// attempts of a key within the window starting at start
+type attempts struct {
+	start time.Time
+	count int
+}
+
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:    limit,
+		window:   window,
+		attempts: map[string]*attempts{},
+	}
+}
+
+// RetryAfter returns how long the key is blocked, or 0 if it may make another
+// attempt
+func (l *Limiter) RetryAfter(key string) time.Duration {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	a, ok := l.attempts[key]
+	if !ok || a.count < l.limit {
+		return 0
+	}
+	end := a.start.Add(l.window)
+	if !now.Before(end) {
+		return 0
+	}
+	return end.Sub(now)
+}
+
+// Add counts an attempt of the key
+func (l *Limiter) Add(key string) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+	a, ok := l.attempts[key]
+	if !ok || !now.Before(a.start.Add(l.window)) {
+		a = &attempts{start: now}
+		l.attempts[key] = a
+	}
+	a.count++
+}
+
+// sweep forgets keys whose window is over, at most once per window
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	l.lastSweep = now
+	for key, a := range l.attempts {
+		if !now.Before(a.start.Add(l.window)) {
+			delete(l.attempts, key)
+		}
+	}
+}
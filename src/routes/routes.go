@@ -1,8 +1,12 @@
 package routes
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/KuramaSyu/WerSu-Rest/src/controllers"
 	_ "github.com/KuramaSyu/WerSu-Rest/src/docs" // load docs
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -14,10 +18,34 @@ func SetupRouter(
 	authController *controllers.AuthController,
 	noteController *controllers.NoteController,
 	noteSearchController *controllers.SearchNotesController,
+	tokenController *controllers.TokenController,
+	sessionController *controllers.SessionController,
+	userController *controllers.UserController,
+	shareLinkController *controllers.ShareLinkController,
+	tagController *controllers.TagController,
+	notebookController *controllers.NotebookController,
+	trashController *controllers.TrashController,
+	savedSearchController *controllers.SavedSearchController,
+	suggestController *controllers.SuggestController,
 ) {
 
+	// respond with problem details for unknown routes
+	r.NoRoute(func(c *gin.Context) {
+		controllers.SetGinError(c, http.StatusNotFound, fmt.Errorf("route %s not found", c.Request.URL.Path))
+	})
+
+	// notes shared via link, which can be viewed without logging in. They are
+	// registered outside of the API group, so they aren't rejected because of
+	// an invalid or expired access token
+	public := r.Group("/api/public")
+	{
+		public.GET("/notes/:token", shareLinkController.GetPublicNote)
+		public.POST("/notes/:token", shareLinkController.PostPublicNote)
+	}
+
 	// API routes
 	api := r.Group("/api")
+	api.Use(tokenController.BearerAuth())
 	{
 		// Test route
 		api.GET("/ping", func(c *gin.Context) {
@@ -27,9 +55,108 @@ func SetupRouter(
 		// Note routes
 		notes := api.Group("/notes")
 		{
-			notes.GET("/:id", noteController.GetNote)
-			notes.GET("/search", noteSearchController.GetNotes)
-			notes.POST("", noteController.PostNote)
+			read := controllers.RequireScope(models.ScopeNotesRead)
+			write := controllers.RequireScope(models.ScopeNotesWrite)
+			search := controllers.RequireScope(models.ScopeSearch)
+
+			notes.GET("/:id", read, noteController.GetNote)
+			notes.GET("/shared", read, noteController.GetSharedNotes)
+			notes.GET("/graph", read, noteController.GetGraph)
+			notes.GET("/search", search, noteSearchController.GetNotes)
+			notes.GET("/search/stream", search, noteSearchController.StreamNotes)
+			notes.GET("/suggest", search, suggestController.GetSuggestions)
+			notes.POST("", write, noteController.PostNote)
+			notes.PATCH("/:id", write, noteController.PatchNote)
+			notes.DELETE("/:id", write, noteController.DeleteNote)
+
+			// sharing
+			notes.GET("/:id/permissions", read, noteController.GetPermissions)
+			notes.PUT("/:id/permissions/:subject_type/:subject_id", write, noteController.PutPermission)
+			notes.DELETE("/:id/permissions/:subject_type/:subject_id", write, noteController.DeletePermission)
+
+			// version history
+			notes.GET("/:id/versions", read, noteController.GetVersions)
+			notes.GET("/:id/versions/diff", read, noteController.GetDiff)
+			notes.GET("/:id/versions/:version", read, noteController.GetVersion)
+			notes.POST("/:id/versions/:version/restore", write, noteController.RestoreVersion)
+
+			// [[links]] between notes
+			notes.GET("/:id/links", read, noteController.GetLinks)
+			notes.GET("/:id/backlinks", read, noteController.GetBacklinks)
+
+			// similar notes by their embeddings
+			notes.GET("/:id/related", read, noteController.GetRelatedNotes)
+
+			// public share links
+			notes.GET("/:id/share-links", read, shareLinkController.GetShareLinks)
+			notes.POST("/:id/share-links", write, shareLinkController.PostShareLink)
+			notes.DELETE("/:id/share-links/:link_id", write, shareLinkController.DeleteShareLink)
+		}
+
+		// Tag routes
+		tags := api.Group("/tags")
+		{
+			read := controllers.RequireScope(models.ScopeNotesRead)
+			write := controllers.RequireScope(models.ScopeNotesWrite)
+
+			tags.GET("", read, tagController.GetTags)
+			tags.POST("/merge", write, tagController.MergeTags)
+			// tags may contain slashes, so the name is matched by a catch-all
+			tags.PATCH("/*name", write, tagController.PatchTag)
+		}
+
+		// Notebook routes
+		notebooks := api.Group("/notebooks")
+		{
+			read := controllers.RequireScope(models.ScopeNotesRead)
+			write := controllers.RequireScope(models.ScopeNotesWrite)
+
+			notebooks.GET("", read, notebookController.GetNotebooks)
+			notebooks.GET("/:id", read, notebookController.GetNotebook)
+			notebooks.POST("", write, notebookController.PostNotebook)
+			notebooks.PATCH("/:id", write, notebookController.PatchNotebook)
+			notebooks.DELETE("/:id", write, notebookController.DeleteNotebook)
+
+			// sharing, inherited by everything inside
+			notebooks.GET("/:id/permissions", read, notebookController.GetPermissions)
+			notebooks.PUT("/:id/permissions/:subject_type/:subject_id", write, notebookController.PutPermission)
+			notebooks.DELETE("/:id/permissions/:subject_type/:subject_id", write, notebookController.DeletePermission)
+		}
+
+		// Trash routes
+		trash := api.Group("/trash")
+		{
+			read := controllers.RequireScope(models.ScopeNotesRead)
+			write := controllers.RequireScope(models.ScopeNotesWrite)
+
+			trash.GET("", read, trashController.GetTrash)
+			trash.DELETE("", write, trashController.EmptyTrash)
+			trash.POST("/restore", write, trashController.RestoreNotes)
+			trash.POST("/:id/restore", write, trashController.RestoreNote)
+			trash.DELETE("/:id", write, trashController.DeleteNote)
+		}
+
+		// Saved search routes
+		searches := api.Group("/searches")
+		{
+			read := controllers.RequireScope(models.ScopeNotesRead)
+			write := controllers.RequireScope(models.ScopeNotesWrite)
+			search := controllers.RequireScope(models.ScopeSearch)
+
+			searches.GET("", read, savedSearchController.GetSavedSearches)
+			searches.GET("/:id", read, savedSearchController.GetSavedSearch)
+			searches.POST("", write, savedSearchController.PostSavedSearch)
+			searches.PATCH("/:id", write, savedSearchController.PatchSavedSearch)
+			searches.DELETE("/:id", write, savedSearchController.DeleteSavedSearch)
+			searches.GET("/:id/results", search, savedSearchController.GetResults)
+		}
+
+		// the own account can only be managed with a browser session
+		me := api.Group("/users/me", controllers.RequireSession())
+		{
+			me.PATCH("", userController.PatchMe)
+			me.DELETE("", userController.DeleteMe)
+			me.GET("/export", userController.ExportMe)
 		}
 
 		// route for swagger API docs
@@ -39,9 +166,33 @@ func SetupRouter(
 	// Auth routes
 	auth := api.Group("/auth")
 	{
-		auth.GET("/discord", authController.Login)
-		auth.GET("/discord/callback", authController.Callback)
+		auth.GET("/providers", authController.GetProviders)
+		auth.GET("/:provider", authController.Login)
+		auth.GET("/:provider/callback", authController.Callback)
 		auth.GET("/user", authController.GetUser)
 		auth.GET("/logout", authController.Logout)
+
+		// login identities can only be managed with a browser session
+		identities := auth.Group("/identities", controllers.RequireSession())
+		{
+			identities.GET("", authController.GetIdentities)
+			identities.GET("/:provider/link", authController.LinkIdentity)
+			identities.DELETE("/:provider/:subject", authController.DeleteIdentity)
+		}
+
+		// personal access tokens can only be managed with a browser session
+		tokens := auth.Group("/tokens", controllers.RequireSession())
+		{
+			tokens.GET("", tokenController.GetTokens)
+			tokens.POST("", tokenController.PostToken)
+			tokens.DELETE("/:id", tokenController.DeleteToken)
+		}
+
+		// login sessions can only be managed with a browser session
+		loginSessions := auth.Group("/sessions", controllers.RequireSession())
+		{
+			loginSessions.GET("", sessionController.GetSessions)
+			loginSessions.DELETE("/:id", sessionController.DeleteSession)
+		}
 	}
 }
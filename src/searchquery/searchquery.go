@@ -0,0 +1,330 @@
+package searchquery
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+)
+
+// Keys of the filters of a query, e.g. tag:infra
+const (
+	KeyTitle    = "title"
+	KeyTag      = "tag"
+	KeyAuthor   = "author"
+	KeyUpdated  = "updated"
+	KeyNotebook = "notebook"
+)
+
+// Keys are all filter keys. Words with other keys, like URLs, are free text.
+var Keys = []string{KeyTitle, KeyTag, KeyAuthor, KeyUpdated, KeyNotebook}
+
+// AuthorMe is the author value which stands for the searching user
+const AuthorMe = "me"
+
+// dateLayout is the format of dates in updated filters
+const dateLayout = "2006-01-02"
+
+// SyntaxError is a problem in a query. Position is the offset of the problem
+// in characters, starting at 0.
+type SyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid query at position %d: %s", e.Position, e.Message)
+}
+
+// NotebookFilter is the notebook given by ID or name
+type NotebookFilter struct {
+	Id   int32
+	Name string
+	// offset of the value, to report notebooks which don't exist
+	Position int
+}
+
+// Query is a parsed search query
+type Query struct {
+	// words and "phrases" for the search algorithm. Phrases keep their quotes
+	Text string
+	// phrases which the title has to contain
+	Titles []string
+	// tags the notes have to have, normalized
+	Tags         []string
+	ExcludedTags []string
+	// words and phrases which neither title nor content may contain
+	ExcludedTerms []string
+	// notes of any of these authors, empty for all authors
+	AuthorIds []int32
+	Notebook  *NotebookFilter
+	// range of the last update, After is inclusive and Before exclusive
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+}
+
+// term is a word, a "phrase" or a key:value filter of a query
+type term struct {
+	position int // of the first character, including the "-"
+	negated  bool
+	key      string // empty for words and phrases
+	value    string
+	// offset of the value
+	valuePosition int
+	quoted        bool
+}
+
+// Parse parses a query like
+//
+//	title:"sprint review" tag:infra updated:>2026-01-01 author:me -draft
+//
+// Filters are given as key:value with an optional "-" in front to exclude
+// them. Values and phrases containing spaces are quoted, a quote inside
+// quotes is escaped as \". Dates are in UTC. userID is the searching user,
+// who is meant by author:me.
+func Parse(input string, userID int32) (*Query, error) {
+	terms, err := tokenize([]rune(input))
+	if err != nil {
+		return nil, err
+	}
+
+	query := &Query{}
+	var text []string
+	for _, t := range terms {
+		if t.key == "" {
+			word := t.value
+			if t.quoted {
+				word = strconv.Quote(t.value)
+			}
+			if t.negated {
+				query.ExcludedTerms = append(query.ExcludedTerms, t.value)
+			} else {
+				text = append(text, word)
+			}
+			continue
+		}
+
+		if t.negated && t.key != KeyTag {
+			return nil, &SyntaxError{t.position, fmt.Sprintf("%s filters can't be excluded", t.key)}
+		}
+		switch t.key {
+		case KeyTitle:
+			query.Titles = append(query.Titles, t.value)
+		case KeyTag:
+			tag, err := models.NormalizeTag(t.value)
+			if err != nil {
+				return nil, &SyntaxError{t.valuePosition, err.Error()}
+			}
+			if t.negated {
+				query.ExcludedTags = appendNew(query.ExcludedTags, tag)
+			} else {
+				query.Tags = appendNew(query.Tags, tag)
+			}
+		case KeyAuthor:
+			id, err := parseAuthor(t.value, userID)
+			if err != nil {
+				return nil, &SyntaxError{t.valuePosition, err.Error()}
+			}
+			query.AuthorIds = appendNew(query.AuthorIds, id)
+		case KeyUpdated:
+			after, before, err := parseDateRange(t.value)
+			if err != nil {
+				return nil, &SyntaxError{t.valuePosition, err.Error()}
+			}
+			// several ranges have to match all
+			if after != nil && (query.UpdatedAfter == nil || after.After(*query.UpdatedAfter)) {
+				query.UpdatedAfter = after
+			}
+			if before != nil && (query.UpdatedBefore == nil || before.Before(*query.UpdatedBefore)) {
+				query.UpdatedBefore = before
+			}
+		case KeyNotebook:
+			if query.Notebook != nil {
+				return nil, &SyntaxError{t.position, "only one notebook filter is allowed"}
+			}
+			query.Notebook = &NotebookFilter{Name: t.value, Position: t.valuePosition}
+			if id, err := strconv.ParseInt(t.value, 10, 32); err == nil && id > 0 {
+				query.Notebook = &NotebookFilter{Id: int32(id), Position: t.valuePosition}
+			}
+		}
+	}
+	query.Text = strings.Join(text, " ")
+	return query, nil
+}
+
+// tokenize splits a query into its terms
+func tokenize(input []rune) ([]term, error) {
+	var terms []term
+	i := 0
+	for {
+		for i < len(input) && unicode.IsSpace(input[i]) {
+			i++
+		}
+		if i == len(input) {
+			return terms, nil
+		}
+
+		t := term{position: i}
+		if input[i] == '-' && i+1 < len(input) && !unicode.IsSpace(input[i+1]) {
+			t.negated = true
+			i++
+		}
+
+		// "phrase"
+		if input[i] == '"' {
+			value, end, err := readQuoted(input, i)
+			if err != nil {
+				return nil, err
+			}
+			t.value, t.valuePosition, t.quoted = value, i+1, true
+			i = end
+			if value != "" {
+				terms = append(terms, t)
+			}
+			continue
+		}
+
+		// key:value or word
+		start := i
+		for i < len(input) && !unicode.IsSpace(input[i]) && input[i] != ':' {
+			i++
+		}
+		key := strings.ToLower(string(input[start:i]))
+		if i < len(input) && input[i] == ':' && slices.Contains(Keys, key) {
+			i++
+			t.key, t.valuePosition = key, i
+			if i < len(input) && input[i] == '"' {
+				value, end, err := readQuoted(input, i)
+				if err != nil {
+					return nil, err
+				}
+				t.value, t.valuePosition, t.quoted = value, i+1, true
+				i = end
+			} else {
+				for i < len(input) && !unicode.IsSpace(input[i]) {
+					i++
+				}
+				t.value = string(input[t.valuePosition:i])
+			}
+			if strings.TrimSpace(t.value) == "" {
+				return nil, &SyntaxError{t.valuePosition, fmt.Sprintf("%s filter without value", key)}
+			}
+			terms = append(terms, t)
+			continue
+		}
+
+		// the word may contain colons of unknown keys, like in URLs
+		for i < len(input) && !unicode.IsSpace(input[i]) {
+			i++
+		}
+		t.value, t.valuePosition = string(input[start:i]), start
+		terms = append(terms, t)
+	}
+}
+
+// readQuoted reads the quoted string starting at input[start] and returns it
+// with the position behind the closing quote
+func readQuoted(input []rune, start int) (string, int, error) {
+	var value strings.Builder
+	for i := start + 1; i < len(input); i++ {
+		switch {
+		case input[i] == '\\' && i+1 < len(input) && input[i+1] == '"':
+			value.WriteRune('"')
+			i++
+		case input[i] == '"':
+			return strings.Join(strings.Fields(value.String()), " "), i + 1, nil
+		default:
+			value.WriteRune(input[i])
+		}
+	}
+	return "", 0, &SyntaxError{start, "missing closing quote"}
+}
+
+// parseAuthor parses the value of an author filter, which is "me" or the ID
+// of a user
+func parseAuthor(value string, userID int32) (int32, error) {
+	if strings.EqualFold(value, AuthorMe) {
+		return userID, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("author must be %q or the ID of a user, not %q", AuthorMe, value)
+	}
+	return int32(id), nil
+}
+
+// parseDateRange parses the value of an updated filter:
+//
+//	2026-01-01              the whole day
+//	>2026-01-01 >=2026-01-01 <2026-01-01 <=2026-01-01
+//	2026-01-01..2026-01-31  both days included
+//
+// It returns the inclusive start and the exclusive end of the range, nil if
+// the range is open on that side.
+func parseDateRange(value string) (*time.Time, *time.Time, error) {
+	day := func(s string) (time.Time, error) {
+		date, err := time.Parse(dateLayout, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q, dates are written like 2026-01-31", s)
+		}
+		return date, nil
+	}
+	nextDay := func(date time.Time) *time.Time {
+		next := date.AddDate(0, 0, 1)
+		return &next
+	}
+
+	for _, operator := range []string{">=", "<=", ">", "<"} {
+		rest, found := strings.CutPrefix(value, operator)
+		if !found {
+			continue
+		}
+		date, err := day(rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch operator {
+		case ">=":
+			return &date, nil, nil
+		case ">":
+			return nextDay(date), nil, nil
+		case "<=":
+			return nil, nextDay(date), nil
+		default:
+			return nil, &date, nil
+		}
+	}
+
+	if from, to, found := strings.Cut(value, ".."); found {
+		start, err := day(from)
+		if err != nil {
+			return nil, nil, err
+		}
+		end, err := day(to)
+		if err != nil {
+			return nil, nil, err
+		}
+		if end.Before(start) {
+			return nil, nil, fmt.Errorf("range %q ends before it starts", value)
+		}
+		return &start, nextDay(end), nil
+	}
+
+	date, err := day(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &date, nextDay(date), nil
+}
+
+// appendNew appends value to values, unless it is contained already
+func appendNew[T comparable](values []T, value T) []T {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
@@ -0,0 +1,188 @@
+package searchquery
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// date returns a pointer to the start of the day in UTC
+func date(s string) *time.Time {
+	d, err := time.Parse(dateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Query
+	}{
+		{
+			name:  "empty",
+			input: "   ",
+			want:  Query{},
+		},
+		{
+			name:  "words",
+			input: "  sprint   review ",
+			want:  Query{Text: "sprint review"},
+		},
+		{
+			name:  "phrase keeps its quotes",
+			input: `"sprint  review" notes`,
+			want:  Query{Text: `"sprint review" notes`},
+		},
+		{
+			name:  "escaped quote in phrase",
+			input: `"say \"hi\""`,
+			want:  Query{Text: `"say \"hi\""`},
+		},
+		{
+			name:  "empty phrase is dropped",
+			input: `"" notes`,
+			want:  Query{Text: "notes"},
+		},
+		{
+			name:  "quoted title",
+			input: `title:"Sprint Review" title:retro`,
+			want:  Query{Titles: []string{"Sprint Review", "retro"}},
+		},
+		{
+			name:  "tags are normalized and deduplicated",
+			input: "tag:Infra tag:infra -tag:Draft",
+			want:  Query{Tags: []string{"infra"}, ExcludedTags: []string{"draft"}},
+		},
+		{
+			name:  "excluded words and phrases",
+			input: `deploy -draft -"old version"`,
+			want:  Query{Text: "deploy", ExcludedTerms: []string{"draft", "old version"}},
+		},
+		{
+			name:  "lone dash is a word",
+			input: "a - b",
+			want:  Query{Text: "a - b"},
+		},
+		{
+			name:  "authors",
+			input: "author:me author:42 author:ME",
+			want:  Query{AuthorIds: []int32{7, 42}},
+		},
+		{
+			name:  "keys ignore case",
+			input: "TAG:infra",
+			want:  Query{Tags: []string{"infra"}},
+		},
+		{
+			name:  "unknown keys are text",
+			input: "https://example.com foo:bar",
+			want:  Query{Text: "https://example.com foo:bar"},
+		},
+		{
+			name:  "notebook by ID",
+			input: "notebook:12",
+			want:  Query{Notebook: &NotebookFilter{Id: 12, Position: 9}},
+		},
+		{
+			name:  "notebook by name",
+			input: `x notebook:"Work Stuff"`,
+			want:  Query{Text: "x", Notebook: &NotebookFilter{Name: "Work Stuff", Position: 12}},
+		},
+		{
+			name:  "single day",
+			input: "updated:2026-01-31",
+			want:  Query{UpdatedAfter: date("2026-01-31"), UpdatedBefore: date("2026-02-01")},
+		},
+		{
+			name:  "after",
+			input: "updated:>2026-01-31",
+			want:  Query{UpdatedAfter: date("2026-02-01")},
+		},
+		{
+			name:  "at or after",
+			input: "updated:>=2026-01-31",
+			want:  Query{UpdatedAfter: date("2026-01-31")},
+		},
+		{
+			name:  "before",
+			input: "updated:<2026-01-31",
+			want:  Query{UpdatedBefore: date("2026-01-31")},
+		},
+		{
+			name:  "at or before",
+			input: "updated:<=2026-01-31",
+			want:  Query{UpdatedBefore: date("2026-02-01")},
+		},
+		{
+			name:  "range includes both days",
+			input: "updated:2026-01-01..2026-01-31",
+			want:  Query{UpdatedAfter: date("2026-01-01"), UpdatedBefore: date("2026-02-01")},
+		},
+		{
+			name:  "several ranges are intersected",
+			input: "updated:>=2026-01-01 updated:2026-01-10..2026-03-01 updated:<2026-02-01",
+			want:  Query{UpdatedAfter: date("2026-01-10"), UpdatedBefore: date("2026-02-01")},
+		},
+		{
+			name:  "filters and text",
+			input: `title:"sprint review" tag:infra updated:>2026-01-01 author:me -draft standup`,
+			want: Query{
+				Text:          "standup",
+				Titles:        []string{"sprint review"},
+				Tags:          []string{"infra"},
+				ExcludedTerms: []string{"draft"},
+				AuthorIds:     []int32{7},
+				UpdatedAfter:  date("2026-01-02"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, 7)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantPosition int
+	}{
+		{name: "missing closing quote", input: `deploy "sprint review`, wantPosition: 7},
+		{name: "missing closing quote of value", input: `title:"sprint`, wantPosition: 6},
+		{name: "filter without value", input: "a tag: b", wantPosition: 6},
+		{name: "filter with empty quoted value", input: `title:""`, wantPosition: 7},
+		{name: "invalid tag", input: "tag:no+plus", wantPosition: 4},
+		{name: "excluded title", input: "x -title:draft", wantPosition: 2},
+		{name: "invalid author", input: "author:someone", wantPosition: 7},
+		{name: "negative author", input: "author:-3", wantPosition: 7},
+		{name: "invalid date", input: "updated:31.01.2026", wantPosition: 8},
+		{name: "invalid date after operator", input: "updated:>=yesterday", wantPosition: 8},
+		{name: "range ending before it starts", input: "updated:2026-02-01..2026-01-01", wantPosition: 8},
+		{name: "second notebook", input: "notebook:1 notebook:2", wantPosition: 11},
+		{name: "position counts characters", input: `äöü "x`, wantPosition: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, 7)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) = %+v, %v, want SyntaxError", tt.input, got, err)
+			}
+			if syntaxErr.Position != tt.wantPosition {
+				t.Errorf("Parse(%q) error at %d (%s), want %d", tt.input, syntaxErr.Position, syntaxErr.Message, tt.wantPosition)
+			}
+		})
+	}
+}
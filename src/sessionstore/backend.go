@@ -0,0 +1,105 @@
+package sessionstore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/config"
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+)
+
+// ErrNotFound is returned by a Backend if a session doesn't exist
+var ErrNotFound = errors.New("session not found")
+
+// Record is a session as persisted by a Backend
+type Record struct {
+	ID string `json:"id"`
+
+	// ID of the logged in user, 0 while nobody is logged in
+	UserID int32 `json:"user_id"`
+
+	// gob encoded session values
+	Data []byte `json:"data"`
+
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+
+	// metadata of the client which created the session
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+}
+
+// PublicID returns the ID under which the session is shown to its user.
+// It is derived from the session ID, so the session ID itself is never
+// sent to the client apart from the signed cookie.
+func (r *Record) PublicID() string {
+	sum := sha256.Sum256([]byte(r.ID))
+	return hex.EncodeToString(sum[:12])
+}
+
+// Expired reports whether the session has expired at the given time
+func (r *Record) Expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && now.After(r.ExpiresAt)
+}
+
+// Values decodes the session values
+func (r *Record) Values() (map[interface{}]interface{}, error) {
+	values := map[interface{}]interface{}{}
+	if err := gob.NewDecoder(bytes.NewReader(r.Data)).Decode(&values); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	return values, nil
+}
+
+// SetValues encodes the session values and updates the user of the session
+func (r *Record) SetValues(values map[interface{}]interface{}) error {
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(values); err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	r.Data = data.Bytes()
+	r.UserID = 0
+	if user, ok := values["user"].(models.User); ok {
+		r.UserID = user.ID
+	}
+	return nil
+}
+
+// Backend persists session records
+type Backend interface {
+	// Load returns the session with the given ID or ErrNotFound
+	Load(id string) (*Record, error)
+
+	// Save creates or replaces a session
+	Save(record *Record) error
+
+	// Delete removes a session. Deleting an unknown session is not an error.
+	Delete(id string) error
+
+	// ListByUser returns all sessions of a user, including expired ones
+	ListByUser(userID int32) ([]*Record, error)
+
+	// List returns all sessions, including expired ones
+	List() ([]*Record, error)
+
+	// DeleteExpired removes all sessions which expired before the given time
+	DeleteExpired(now time.Time) error
+}
+
+// NewBackend creates the backend selected in the configuration
+func NewBackend(cfg config.SessionStoreConfig) (Backend, error) {
+	switch cfg.Kind {
+	case config.SessionStoreMemory:
+		return NewMemoryBackend(), nil
+	case config.SessionStoreFile:
+		return NewFileBackend(cfg.Path)
+	default:
+		return nil, fmt.Errorf("unknown session store %q", cfg.Kind)
+	}
+}
@@ -0,0 +1,154 @@
+package sessionstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileBackend stores every session as JSON file in a directory, so sessions
+// survive restarts without running a database
+type FileBackend struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewFileBackend creates a file backend in dir. The directory is created if
+// it doesn't exist.
+func NewFileBackend(dir string) (*FileBackend, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create session directory: %w", err)
+	}
+	return &FileBackend{dir: dir}, nil
+}
+
+// path returns the file of a session. IDs are generated by the Store and only
+// contain base32 characters, anything else is rejected.
+func (f *FileBackend) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid session ID")
+	}
+	return filepath.Join(f.dir, id+".json"), nil
+}
+
+func (f *FileBackend) Load(id string) (*Record, error) {
+	path, err := f.path(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return readRecord(path)
+}
+
+func (f *FileBackend) Save(record *Record) error {
+	path, err := f.path(record.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// write to a temporary file first, so readers never see half a session
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (f *FileBackend) Delete(id string) error {
+	path, err := f.path(id)
+	if err != nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (f *FileBackend) ListByUser(userID int32) ([]*Record, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var records []*Record
+	err := f.each(func(path string, record *Record) error {
+		if record.UserID == userID {
+			records = append(records, record)
+		}
+		return nil
+	})
+	return records, err
+}
+
+func (f *FileBackend) List() ([]*Record, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var records []*Record
+	err := f.each(func(path string, record *Record) error {
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+func (f *FileBackend) DeleteExpired(now time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.each(func(path string, record *Record) error {
+		if !record.Expired(now) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	})
+}
+
+// each calls fn for every readable session file in the directory
+func (f *FileBackend) each(fn func(path string, record *Record) error) error {
+	paths, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		record, err := readRecord(path)
+		if err != nil {
+			// removed in the meantime or not a session
+			continue
+		}
+		if err := fn(path, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readRecord(path string) (*Record, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("invalid session file %s: %w", path, err)
+	}
+	return &record, nil
+}
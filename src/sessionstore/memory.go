@@ -0,0 +1,74 @@
+package sessionstore
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryBackend keeps sessions in memory. Sessions are lost on restart, so
+// it is meant for development.
+type MemoryBackend struct {
+	mu       sync.RWMutex
+	sessions map[string]Record
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{sessions: map[string]Record{}}
+}
+
+func (m *MemoryBackend) Load(id string) (*Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	record, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &record, nil
+}
+
+func (m *MemoryBackend) Save(record *Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[record.ID] = *record
+	return nil
+}
+
+func (m *MemoryBackend) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *MemoryBackend) ListByUser(userID int32) ([]*Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var records []*Record
+	for _, record := range m.sessions {
+		if record.UserID == userID {
+			records = append(records, &record)
+		}
+	}
+	return records, nil
+}
+
+func (m *MemoryBackend) List() ([]*Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	records := make([]*Record, 0, len(m.sessions))
+	for _, record := range m.sessions {
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+func (m *MemoryBackend) DeleteExpired(now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, record := range m.sessions {
+		if record.Expired(now) {
+			delete(m.sessions, id)
+		}
+	}
+	return nil
+}
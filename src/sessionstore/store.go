@@ -0,0 +1,342 @@
+package sessionstore
+
+import (
+	"context"
+	"encoding/base32"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/securecookie"
+	gsessions "github.com/gorilla/sessions"
+)
+
+// touchInterval limits how often the last seen time of a session is written
+// to the backend
+const touchInterval = time.Minute
+
+// CookieName is the name of the session cookie
+const CookieName = "discord_auth"
+
+// AnonymousMaxAge is how long a session without a logged in user is kept,
+// e.g. one which only carries the state of a login in progress
+const AnonymousMaxAge = 10 * time.Minute
+
+var base32RawStdEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Store is a sessions.Store which keeps the session values on the server.
+// The cookie only carries the signed session ID, so sessions can be listed
+// and revoked.
+type Store struct {
+	backend Backend
+	codecs  []securecookie.Codec
+	options *gsessions.Options
+
+	// serializes loading and saving a record, so a session revoked in
+	// between isn't saved again
+	mu sync.Mutex
+}
+
+// NewStore creates a store persisting sessions in backend. The key pairs sign
+// the session cookie, see securecookie.CodecsFromPairs.
+func NewStore(backend Backend, maxAge time.Duration, keyPairs ...[]byte) *Store {
+	s := &Store{
+		backend: backend,
+		codecs:  securecookie.CodecsFromPairs(keyPairs...),
+	}
+	s.Options(sessions.Options{
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+	})
+	return s
+}
+
+// Options sets the default options of new sessions
+func (s *Store) Options(options sessions.Options) {
+	s.options = options.ToGorillaOptions()
+	for _, codec := range s.codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(s.options.MaxAge)
+		}
+	}
+}
+
+// Get returns the session of the request, see gsessions.CookieStore.Get
+func (s *Store) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(s, name)
+}
+
+// New loads the session referenced by the cookie of the request. If the
+// session is unknown, expired or revoked, an empty session is returned.
+func (s *Store) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(s, name)
+	opts := *s.options
+	session.Options = &opts
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+	var id string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &id, s.codecs...); err != nil {
+		return session, nil
+	}
+	record, err := s.backend.Load(id)
+	if errors.Is(err, ErrNotFound) {
+		return session, nil
+	}
+	if err != nil {
+		return session, err
+	}
+	now := time.Now()
+	if record.Expired(now) {
+		return session, nil
+	}
+	values, err := record.Values()
+	if err != nil {
+		return session, err
+	}
+	session.Values = values
+	session.ID = id
+	session.IsNew = false
+
+	if now.Sub(record.LastSeenAt) > touchInterval {
+		err := s.modify(id, func(record *Record) (bool, error) {
+			record.LastSeenAt = now
+			record.IP = clientIP(r)
+			return true, nil
+		})
+		if err != nil {
+			log.Printf("Failed to update session: %v", err)
+		}
+	}
+	return session, nil
+}
+
+// modify loads a record, changes it with fn and saves it again if fn reports
+// a change. Records which don't exist anymore or expired are left alone.
+func (s *Store) modify(id string, fn func(record *Record) (bool, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, err := s.backend.Load(id)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if record.Expired(time.Now()) {
+		return nil
+	}
+	changed, err := fn(record)
+	if err != nil || !changed {
+		return err
+	}
+	return s.backend.Save(record)
+}
+
+// Save persists the session and sets the session cookie. A session with a
+// negative MaxAge is deleted from the backend. A session which was revoked or
+// expired while the request ran isn't brought back: its values are dropped
+// and it is saved under a new ID. Sessions without a logged in user expire
+// after AnonymousMaxAge.
+func (s *Store) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.backend.Delete(session.ID); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	now := time.Now()
+	record := &Record{
+		ID:        session.ID,
+		CreatedAt: now,
+		UserAgent: r.UserAgent(),
+	}
+	if session.ID != "" {
+		existing, err := s.backend.Load(session.ID)
+		switch {
+		case errors.Is(err, ErrNotFound) || err == nil && existing.Expired(now):
+			record.ID = ""
+			session.Values = map[interface{}]interface{}{}
+		case err != nil:
+			return err
+		default:
+			record.CreatedAt = existing.CreatedAt
+			record.UserAgent = existing.UserAgent
+		}
+	}
+	if record.ID == "" {
+		// the ID is used as file name, so only base32 characters are used
+		record.ID = base32RawStdEncoding.EncodeToString(securecookie.GenerateRandomKey(32))
+	}
+	record.LastSeenAt = now
+	record.IP = clientIP(r)
+	if err := record.SetValues(session.Values); err != nil {
+		return err
+	}
+	options := *session.Options
+	if record.UserID == 0 && (options.MaxAge == 0 || options.MaxAge > int(AnonymousMaxAge.Seconds())) {
+		options.MaxAge = int(AnonymousMaxAge.Seconds())
+	}
+	if options.MaxAge > 0 {
+		record.ExpiresAt = now.Add(time.Duration(options.MaxAge) * time.Second)
+	}
+	if err := s.backend.Save(record); err != nil {
+		return err
+	}
+	session.ID = record.ID
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), encoded, &options))
+	return nil
+}
+
+// Rotate deletes the record of the session of the request and clears its ID,
+// so the next Save stores the values under a new ID. It is called when the
+// user logs in, so a session ID planted before can't be used to act as the
+// user.
+func (s *Store) Rotate(r *http.Request, name string) error {
+	session, err := s.Get(r, name)
+	if err != nil {
+		return err
+	}
+	if session.ID == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.backend.Delete(session.ID); err != nil {
+		return err
+	}
+	session.ID = ""
+	return nil
+}
+
+// ListByUser returns the sessions of a user which haven't expired yet
+func (s *Store) ListByUser(userID int32) ([]*Record, error) {
+	return activeRecords(s.backend.ListByUser(userID))
+}
+
+// List returns all sessions which haven't expired yet
+func (s *Store) List() ([]*Record, error) {
+	return activeRecords(s.backend.List())
+}
+
+// Update changes the values of a session outside of a request, e.g. to
+// update the user of all sessions after the profile changed. fn reports
+// whether it changed the values, otherwise the session isn't saved. Loading
+// and saving happens under the lock of the store, so a session revoked or
+// saved by a request in between isn't overwritten. Unknown and expired
+// sessions are ignored.
+func (s *Store) Update(id string, fn func(values map[interface{}]interface{}) bool) error {
+	return s.modify(id, func(record *Record) (bool, error) {
+		values, err := record.Values()
+		if err != nil {
+			return false, err
+		}
+		if !fn(values) {
+			return false, nil
+		}
+		return true, record.SetValues(values)
+	})
+}
+
+func activeRecords(records []*Record, err error) ([]*Record, error) {
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	active := make([]*Record, 0, len(records))
+	for _, record := range records {
+		if !record.Expired(now) {
+			active = append(active, record)
+		}
+	}
+	return active, nil
+}
+
+// Revoke deletes a session, which logs out the client using it
+func (s *Store) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backend.Delete(id)
+}
+
+// RevokeUser deletes all sessions of a user
+func (s *Store) RevokeUser(userID int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, err := s.backend.ListByUser(userID)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := s.backend.Delete(record.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cleanup deletes expired sessions every interval until ctx is done
+func (s *Store) Cleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.backend.DeleteExpired(now); err != nil {
+				log.Printf("Failed to delete expired sessions: %v", err)
+			}
+		}
+	}
+}
+
+// clientIPKey is the context key of the client IP determined by gin
+type clientIPKey struct{}
+
+// ClientIP passes the client IP determined by gin on to the store. Gin only
+// honours the X-Forwarded-For header of trusted proxies, see
+// gin.Engine.SetTrustedProxies. It has to be used before the sessions
+// middleware, which keeps the request it was called with.
+func ClientIP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := context.WithValue(c.Request.Context(), clientIPKey{}, c.ClientIP())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// clientIP returns the IP of the client set by the ClientIP middleware or,
+// without it, the address of the connection
+func clientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,223 @@
+package sessionstore
+
+import (
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/models"
+	gsessions "github.com/gorilla/sessions"
+)
+
+func init() {
+	gob.Register(models.User{})
+}
+
+const testMaxAge = 24 * time.Hour
+
+func newTestStore() (*Store, *MemoryBackend) {
+	backend := NewMemoryBackend()
+	return NewStore(backend, testMaxAge, []byte("secret")), backend
+}
+
+// newRequest creates a request carrying the session cookie, if given
+func newRequest(cookie *http.Cookie) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if cookie != nil {
+		r.AddCookie(cookie)
+	}
+	return r
+}
+
+// load returns the session of a new request carrying the cookie
+func load(t *testing.T, store *Store, cookie *http.Cookie) (*http.Request, *gsessions.Session) {
+	t.Helper()
+	r := newRequest(cookie)
+	session, err := store.Get(r, CookieName)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	return r, session
+}
+
+// save saves the session and returns the cookie set for it
+func save(t *testing.T, store *Store, r *http.Request, session *gsessions.Session) *http.Cookie {
+	t.Helper()
+	w := httptest.NewRecorder()
+	if err := store.Save(r, w, session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("Save() set %d cookies, want 1", len(cookies))
+	}
+	return cookies[0]
+}
+
+// anonymousSession saves a session carrying only the state of a login
+func anonymousSession(t *testing.T, store *Store) (*http.Cookie, string) {
+	t.Helper()
+	r, session := load(t, store, nil)
+	session.Values["state"] = "the-state"
+	cookie := save(t, store, r, session)
+	return cookie, session.ID
+}
+
+// loggedInSession saves a session of the user
+func loggedInSession(t *testing.T, store *Store, userID int32) (*http.Cookie, string) {
+	t.Helper()
+	r, session := load(t, store, nil)
+	session.Values["user"] = models.User{ID: userID}
+	cookie := save(t, store, r, session)
+	return cookie, session.ID
+}
+
+func TestStoreLoadsSavedSession(t *testing.T) {
+	store, _ := newTestStore()
+	cookie, id := loggedInSession(t, store, 1)
+
+	_, session := load(t, store, cookie)
+	if session.IsNew || session.ID != id {
+		t.Fatalf("Get() = session %q (new: %v), want %q", session.ID, session.IsNew, id)
+	}
+	if user, ok := session.Values["user"].(models.User); !ok || user.ID != 1 {
+		t.Errorf("user = %v, want user 1", session.Values["user"])
+	}
+}
+
+func TestStoreRotateAtLogin(t *testing.T) {
+	store, backend := newTestStore()
+	cookie, anonymousID := anonymousSession(t, store)
+	anonymous, err := backend.Load(anonymousID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lifetime := time.Until(anonymous.ExpiresAt); lifetime > AnonymousMaxAge {
+		t.Errorf("anonymous session expires in %v, want at most %v", lifetime, AnonymousMaxAge)
+	}
+
+	// log in
+	r, session := load(t, store, cookie)
+	if err := store.Rotate(r, CookieName); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	delete(session.Values, "state")
+	session.Values["user"] = models.User{ID: 1}
+	newCookie := save(t, store, r, session)
+
+	if session.ID == "" || session.ID == anonymousID {
+		t.Fatalf("session ID after login = %q, want a new one", session.ID)
+	}
+	if _, err := backend.Load(anonymousID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load() of the old ID error = %v, want ErrNotFound", err)
+	}
+	record, err := backend.Load(session.ID)
+	if err != nil {
+		t.Fatalf("Load() of the new ID error = %v", err)
+	}
+	if record.UserID != 1 {
+		t.Errorf("UserID = %d, want 1", record.UserID)
+	}
+	if lifetime := time.Until(record.ExpiresAt); lifetime <= AnonymousMaxAge {
+		t.Errorf("logged in session expires in %v, want the max age", lifetime)
+	}
+
+	// the planted cookie doesn't lead to the logged in session
+	_, planted := load(t, store, cookie)
+	if !planted.IsNew || planted.Values["user"] != nil {
+		t.Errorf("old cookie loads session %q with %v, want a new empty session", planted.ID, planted.Values)
+	}
+	_, current := load(t, store, newCookie)
+	if current.ID != session.ID {
+		t.Errorf("new cookie loads session %q, want %q", current.ID, session.ID)
+	}
+}
+
+func TestStoreRotateNewSession(t *testing.T) {
+	store, _ := newTestStore()
+	r, session := load(t, store, nil)
+	if err := store.Rotate(r, CookieName); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	session.Values["user"] = models.User{ID: 1}
+	save(t, store, r, session)
+	if session.ID == "" {
+		t.Error("Save() after Rotate() didn't assign an ID")
+	}
+}
+
+func TestStoreDropsRevokedSessions(t *testing.T) {
+	store, backend := newTestStore()
+	cookie, id := loggedInSession(t, store, 1)
+
+	// the session is revoked while a request using it runs
+	r, session := load(t, store, cookie)
+	if err := store.Revoke(id); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	session.Values["theme"] = "dark"
+	save(t, store, r, session)
+
+	if session.ID == id {
+		t.Error("Save() brought the revoked session back")
+	}
+	if _, err := backend.Load(id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load() of the revoked ID error = %v, want ErrNotFound", err)
+	}
+	if len(session.Values) != 0 {
+		t.Errorf("values of the revoked session were kept: %v", session.Values)
+	}
+
+	_, revoked := load(t, store, cookie)
+	if !revoked.IsNew || len(revoked.Values) != 0 {
+		t.Errorf("revoked cookie loads session %q with %v, want a new empty session", revoked.ID, revoked.Values)
+	}
+}
+
+func TestStoreDropsExpiredSessions(t *testing.T) {
+	store, backend := newTestStore()
+	cookie, id := loggedInSession(t, store, 1)
+	record, err := backend.Load(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.ExpiresAt = time.Now().Add(-time.Minute)
+	backend.Save(record)
+
+	r, session := load(t, store, cookie)
+	if !session.IsNew || len(session.Values) != 0 {
+		t.Fatalf("expired cookie loads session %q with %v, want a new empty session", session.ID, session.Values)
+	}
+	session.Values["state"] = "the-state"
+	save(t, store, r, session)
+	if session.ID == id {
+		t.Error("Save() reused the expired session ID")
+	}
+}
+
+func TestStoreRevokeUser(t *testing.T) {
+	store, backend := newTestStore()
+	_, first := loggedInSession(t, store, 1)
+	_, second := loggedInSession(t, store, 1)
+	_, other := loggedInSession(t, store, 2)
+
+	if err := store.RevokeUser(1); err != nil {
+		t.Fatalf("RevokeUser() error = %v", err)
+	}
+	for _, id := range []string{first, second} {
+		if _, err := backend.Load(id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Load() of a revoked session error = %v, want ErrNotFound", err)
+		}
+	}
+	records, err := store.ListByUser(1)
+	if err != nil || len(records) != 0 {
+		t.Errorf("ListByUser(1) = %d sessions, %v, want none", len(records), err)
+	}
+	records, err = store.ListByUser(2)
+	if err != nil || len(records) != 1 || records[0].ID != other {
+		t.Errorf("ListByUser(2) = %v, %v, want the session of user 2", records, err)
+	}
+}
@@ -0,0 +1,76 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrSuperseded is returned for calls which were canceled, because a newer
+// call with the same key arrived
+var ErrSuperseded = errors.New("superseded by a newer request")
+
+// Debouncer runs only the latest of the calls sharing a key, e.g. the
+// requests of a client typing into a search box. Every call waits for the
+// delay first. A newer call with the same key cancels the older one, whether
+// it is still waiting or already running.
+type Debouncer struct {
+	delay time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*call
+}
+
+// call is a call of Do, compared by identity
+type call struct {
+	cancel context.CancelCauseFunc
+}
+
+func NewDebouncer(delay time.Duration) *Debouncer {
+	return &Debouncer{
+		delay:   delay,
+		pending: map[string]*call{},
+	}
+}
+
+// Do waits for the delay and calls fn, unless a newer call with the same key
+// arrives first. The context passed to fn is canceled as soon as a newer call
+// arrives, so fn should pass it on to everything it waits for. Do returns
+// ErrSuperseded if the call was canceled by a newer one, otherwise the error
+// of fn or ctx.
+func (d *Debouncer) Do(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	current := &call{cancel: cancel}
+
+	d.mu.Lock()
+	if previous, ok := d.pending[key]; ok {
+		previous.cancel(ErrSuperseded)
+	}
+	d.pending[key] = current
+	d.mu.Unlock()
+
+	defer func() {
+		cancel(nil)
+		d.mu.Lock()
+		if d.pending[key] == current {
+			delete(d.pending, key)
+		}
+		d.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(d.delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+	}
+
+	err := fn(ctx)
+	if errors.Is(context.Cause(ctx), ErrSuperseded) {
+		// fn may have failed with a gRPC status, which hides the cause
+		return ErrSuperseded
+	}
+	return err
+}
@@ -0,0 +1,154 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// doAsync calls Do in the background and delivers its result
+func doAsync(d *Debouncer, ctx context.Context, key string, fn func(ctx context.Context) error) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- d.Do(ctx, key, fn)
+	}()
+	return done
+}
+
+// wait returns the result of an asynchronous call, failing the test if it
+// takes too long
+func wait(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Do() didn't return")
+		return nil
+	}
+}
+
+func TestDebouncerRunsAfterDelay(t *testing.T) {
+	d := NewDebouncer(20 * time.Millisecond)
+	start := time.Now()
+	called := false
+	err := d.Do(context.Background(), "a", func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Fatalf("Do() = %v, called = %v, want fn to be called", err, called)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("fn was called after %v, want at least the delay", elapsed)
+	}
+}
+
+func TestDebouncerReturnsErrorOfFn(t *testing.T) {
+	d := NewDebouncer(0)
+	want := errors.New("failed")
+	if err := d.Do(context.Background(), "a", func(ctx context.Context) error { return want }); err != want {
+		t.Errorf("Do() = %v, want %v", err, want)
+	}
+}
+
+func TestDebouncerSupersedesWaitingCall(t *testing.T) {
+	d := NewDebouncer(200 * time.Millisecond)
+	var calls atomic.Int32
+	fn := func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}
+
+	first := doAsync(d, context.Background(), "a", fn)
+	waitPending(t, d, "a")
+	if err := d.Do(context.Background(), "a", fn); err != nil {
+		t.Fatalf("second Do() = %v, want nil", err)
+	}
+	if err := wait(t, first); !errors.Is(err, ErrSuperseded) {
+		t.Errorf("first Do() = %v, want ErrSuperseded", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("fn called %d times, want once", calls.Load())
+	}
+}
+
+func TestDebouncerCancelsRunningCall(t *testing.T) {
+	d := NewDebouncer(0)
+	running := make(chan struct{})
+	first := doAsync(d, context.Background(), "a", func(ctx context.Context) error {
+		close(running)
+		<-ctx.Done()
+		// like a gRPC call, which reports its own error
+		return errors.New("rpc canceled")
+	})
+	<-running
+
+	if err := d.Do(context.Background(), "a", func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("second Do() = %v, want nil", err)
+	}
+	if err := wait(t, first); !errors.Is(err, ErrSuperseded) {
+		t.Errorf("first Do() = %v, want ErrSuperseded", err)
+	}
+}
+
+func TestDebouncerKeysAreIndependent(t *testing.T) {
+	d := NewDebouncer(0)
+	running := make(chan struct{})
+	release := make(chan struct{})
+	first := doAsync(d, context.Background(), "a", func(ctx context.Context) error {
+		close(running)
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	<-running
+
+	if err := d.Do(context.Background(), "b", func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Do() with another key = %v, want nil", err)
+	}
+	close(release)
+	if err := wait(t, first); err != nil {
+		t.Errorf("Do() = %v, want nil", err)
+	}
+}
+
+func TestDebouncerParentCanceled(t *testing.T) {
+	d := NewDebouncer(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := doAsync(d, ctx, "a", func(ctx context.Context) error {
+		t.Error("fn called after the context was canceled")
+		return nil
+	})
+	waitPending(t, d, "a")
+	cancel()
+
+	err := wait(t, done)
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrSuperseded) {
+		t.Errorf("Do() = %v, want context.Canceled", err)
+	}
+	if _, ok := d.pending["a"]; ok {
+		t.Error("finished call is still pending")
+	}
+}
+
+// waitPending waits until a call with the key is registered
+func waitPending(t *testing.T, d *Debouncer, key string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		d.mu.Lock()
+		_, ok := d.pending[key]
+		d.mu.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("no call with key %q is pending", key)
+}
@@ -0,0 +1,62 @@
+package suggest
+
+import (
+	"strings"
+	"sync"
+)
+
+// MaxRecentQueries is the number of queries kept per user
+const MaxRecentQueries = 20
+
+// MaxRecentQueryLength is the length of the longest query which is kept.
+// Longer ones are most likely pasted text, which isn't worth suggesting.
+const MaxRecentQueryLength = 200
+
+// RecentQueries keeps the latest search queries of every user in memory.
+// They are lost on restart.
+type RecentQueries struct {
+	mu      sync.Mutex
+	queries map[int32][]string // latest first
+}
+
+func NewRecentQueries() *RecentQueries {
+	return &RecentQueries{queries: map[int32][]string{}}
+}
+
+// Add records a query of the user. A query which was recorded already,
+// ignoring case, moves to the front.
+func (r *RecentQueries) Add(userID int32, query string) {
+	query = strings.Join(strings.Fields(query), " ")
+	if query == "" || len([]rune(query)) > MaxRecentQueryLength {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queries := []string{query}
+	for _, recent := range r.queries[userID] {
+		if !strings.EqualFold(recent, query) && len(queries) < MaxRecentQueries {
+			queries = append(queries, recent)
+		}
+	}
+	r.queries[userID] = queries
+}
+
+// Matching returns up to limit queries of the user starting with the prefix,
+// ignoring case, latest first
+func (r *RecentQueries) Matching(userID int32, prefix string, limit int) []string {
+	prefix = strings.ToLower(prefix)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matching := []string{}
+	for _, query := range r.queries[userID] {
+		if len(matching) == limit {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(query), prefix) {
+			matching = append(matching, query)
+		}
+	}
+	return matching
+}
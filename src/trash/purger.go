@@ -0,0 +1,58 @@
+package trash
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/KuramaSyu/WerSu-Rest/src/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Purger periodically deletes notes which are in the trash for longer than
+// the retention period
+type Purger struct {
+	noteService *proto.NoteServiceClient
+	retention   time.Duration
+}
+
+func NewPurger(noteService *proto.NoteServiceClient, retention time.Duration) *Purger {
+	return &Purger{
+		noteService: noteService,
+		retention:   retention,
+	}
+}
+
+// Run purges expired notes every interval until ctx is done. The first purge
+// happens right away, so notes which expired while the server was down don't
+// wait for a full interval.
+func (p *Purger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.PurgeExpired(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpired permanently deletes the notes of all users which were moved to
+// the trash before the retention period
+func (p *Purger) PurgeExpired(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	response, err := (*p.noteService).PurgeNotes(ctx, &proto.PurgeNotesRequest{
+		DeletedBefore: timestamppb.New(time.Now().Add(-p.retention)),
+	})
+	if err != nil {
+		log.Printf("Failed to purge trash: %v", err)
+		return
+	}
+	if response.Purged > 0 {
+		log.Printf("Purged %d notes from the trash", response.Purged)
+	}
+}
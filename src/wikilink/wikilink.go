@@ -0,0 +1,120 @@
+package wikilink
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxTitleLength is the maximum length of a linked title. Longer brackets are
+// most likely not meant as link.
+const MaxTitleLength = 200
+
+// Link is a reference to another note in the content of a note. Either Title
+// or NoteId is set.
+type Link struct {
+	// [[Note Title]], with whitespace collapsed
+	Title string
+	// [[#42]]
+	NoteId int32
+}
+
+var (
+	// [[target]] or [[target|shown text]]
+	linkPattern  = regexp.MustCompile(`\[\[([^\[\]|\n]+)(?:\|[^\[\]\n]*)?\]\]`)
+	idPattern    = regexp.MustCompile(`^#([0-9]+)$`)
+	fencePattern = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+)
+
+// Parse returns the links in markdown content in order of their first
+// appearance. Every note is only returned once, titles are compared case
+// insensitively. Links inside code spans and fenced code blocks are ignored.
+func Parse(content string) []Link {
+	links := []Link{}
+	seen := map[Link]bool{}
+	fence := ""
+	for _, line := range strings.Split(content, "\n") {
+		// skip fenced code blocks
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			if fence == "" {
+				fence = match[1]
+			} else if match[1][0] == fence[0] && len(match[1]) >= len(fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		for _, match := range linkPattern.FindAllStringSubmatch(stripCodeSpans(line), -1) {
+			link, ok := parseTarget(match[1])
+			key := Link{Title: strings.ToLower(link.Title), NoteId: link.NoteId}
+			if !ok || seen[key] {
+				continue
+			}
+			seen[key] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// stripCodeSpans removes `code` spans from a line. A span ends with a run of
+// as many backticks as it started with, unclosed runs are kept as they are.
+func stripCodeSpans(line string) string {
+	var stripped strings.Builder
+	for {
+		start := strings.IndexByte(line, '`')
+		if start < 0 {
+			stripped.WriteString(line)
+			return stripped.String()
+		}
+		run := start
+		for run < len(line) && line[run] == '`' {
+			run++
+		}
+		opening := line[start:run]
+
+		// search the closing run of the same length
+		end := -1
+		for i := run; i < len(line); {
+			next := strings.Index(line[i:], opening)
+			if next < 0 {
+				break
+			}
+			i += next
+			closing := i + len(opening)
+			if (i == 0 || line[i-1] != '`') && (closing == len(line) || line[closing] != '`') {
+				end = closing
+				break
+			}
+			for i < len(line) && line[i] == '`' {
+				i++
+			}
+		}
+		if end < 0 {
+			stripped.WriteString(line[:run])
+			line = line[run:]
+			continue
+		}
+		stripped.WriteString(line[:start])
+		line = line[end:]
+	}
+}
+
+// parseTarget parses the part of a link before the "|"
+func parseTarget(target string) (Link, bool) {
+	target = strings.Join(strings.Fields(target), " ")
+	if target == "" || len(target) > MaxTitleLength {
+		return Link{}, false
+	}
+	if match := idPattern.FindStringSubmatch(target); match != nil {
+		id, err := strconv.ParseInt(match[1], 10, 32)
+		if err != nil || id <= 0 {
+			return Link{}, false
+		}
+		return Link{NoteId: int32(id)}, true
+	}
+	return Link{Title: target}, true
+}
@@ -0,0 +1,112 @@
+package wikilink
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Link
+	}{
+		{
+			name:    "no links",
+			content: "plain [text] and [[]] and [[ ]]",
+			want:    []Link{},
+		},
+		{
+			name:    "title",
+			content: "see [[Sprint Review]] for details",
+			want:    []Link{{Title: "Sprint Review"}},
+		},
+		{
+			name:    "whitespace is collapsed",
+			content: "[[  Sprint \t Review ]]",
+			want:    []Link{{Title: "Sprint Review"}},
+		},
+		{
+			name:    "shown text is ignored",
+			content: "[[Sprint Review|last review]] and [[#7|note seven]]",
+			want:    []Link{{Title: "Sprint Review"}, {NoteId: 7}},
+		},
+		{
+			name:    "note IDs",
+			content: "[[#42]] [[#0]] [[#99999999999]] [[#abc]]",
+			want:    []Link{{NoteId: 42}, {Title: "#abc"}},
+		},
+		{
+			name:    "duplicates ignore case and keep the first spelling",
+			content: "[[Retro]] [[retro]] [[#3]] [[#3]] [[RETRO|again]]",
+			want:    []Link{{Title: "Retro"}, {NoteId: 3}},
+		},
+		{
+			name:    "links don't span lines",
+			content: "[[Sprint\nReview]]",
+			want:    []Link{},
+		},
+		{
+			name:    "nested brackets",
+			content: "[[[Inner]]]",
+			want:    []Link{{Title: "Inner"}},
+		},
+		{
+			name:    "too long title",
+			content: "[[" + strings.Repeat("x", MaxTitleLength+1) + "]] [[" + strings.Repeat("y", MaxTitleLength) + "]]",
+			want:    []Link{{Title: strings.Repeat("y", MaxTitleLength)}},
+		},
+		{
+			name:    "code spans",
+			content: "`[[Code]]` [[Real]] ``a ` [[Double]]`` `unclosed [[Open]]",
+			want:    []Link{{Title: "Real"}, {Title: "Open"}},
+		},
+		{
+			name:    "fenced code blocks",
+			content: "[[Before]]\n```go\n[[Inside]]\n```\n[[After]]",
+			want:    []Link{{Title: "Before"}, {Title: "After"}},
+		},
+		{
+			name:    "fence is only closed by the same kind and length",
+			content: "````\n```\n~~~\n[[Inside]]\n````\n[[After]]",
+			want:    []Link{{Title: "After"}},
+		},
+		{
+			name:    "indented code fence",
+			content: "   ~~~\n[[Inside]]\n   ~~~\n    ```\n[[Indented]]",
+			want:    []Link{{Title: "Indented"}},
+		},
+		{
+			name:    "unclosed fence hides the rest",
+			content: "[[Before]]\n```\n[[Inside]]",
+			want:    []Link{{Title: "Before"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripCodeSpans(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: "no code", want: "no code"},
+		{line: "a `b` c", want: "a  c"},
+		{line: "a ``b ` c`` d", want: "a  d"},
+		{line: "a ``b` c", want: "a ``b` c"},
+		{line: "`a` and `b`", want: " and "},
+		{line: "```", want: "```"},
+	}
+	for _, tt := range tests {
+		if got := stripCodeSpans(tt.line); got != tt.want {
+			t.Errorf("stripCodeSpans(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}